@@ -63,8 +63,17 @@ func TestAddEnv3(t *testing.T) {
 	sampleMainConfigFilePath := filepath.Join(utils.ConfigDirPath, sampleMainConfigFileName)
 
 	var sampleMainConnfig = new(utils.MainConfig)
-	sampleMainConnfig.Config = utils.Config{utils.DefaultHttpRequestTimeout,
-		utils.DefaultExportDirPath, k8sUtils.DefaultKubernetesMode, utils.DefaultTokenType}
+	sampleMainConnfig.Config = utils.Config{
+		HttpRequestTimeout:    utils.DefaultHttpRequestTimeout,
+		ExportDirectory:       utils.DefaultExportDirPath,
+		KubernetesMode:        k8sUtils.DefaultKubernetesMode,
+		TokenType:             utils.DefaultTokenType,
+		SnapshotRetention:     utils.DefaultSnapshotRetention,
+		HttpRetryCount:        utils.DefaultHttpRetryCount,
+		HttpRetryWaitTime:     utils.DefaultHttpRetryWaitTime,
+		HttpRetryMaxWaitTime:  utils.DefaultHttpRetryMaxWaitTime,
+		CertExpiryWarningDays: utils.DefaultCertExpiryWarningDays,
+	}
 	sampleMainConnfig.Environments = make(map[string]utils.EnvEndpoints)
 	sampleMainConnfig.Environments["dev"] = utils.EnvEndpoints{
 		"sample-publisher-endpoint",
@@ -93,8 +102,17 @@ func TestAddEnv4(t *testing.T) {
 	sampleMainConfigFilePath := filepath.Join(utils.ConfigDirPath, sampleMainConfigFileName)
 
 	var sampleMainConnfig = new(utils.MainConfig)
-	sampleMainConnfig.Config = utils.Config{utils.DefaultHttpRequestTimeout,
-		utils.DefaultExportDirPath, k8sUtils.DefaultKubernetesMode, utils.DefaultTokenType}
+	sampleMainConnfig.Config = utils.Config{
+		HttpRequestTimeout:    utils.DefaultHttpRequestTimeout,
+		ExportDirectory:       utils.DefaultExportDirPath,
+		KubernetesMode:        k8sUtils.DefaultKubernetesMode,
+		TokenType:             utils.DefaultTokenType,
+		SnapshotRetention:     utils.DefaultSnapshotRetention,
+		HttpRetryCount:        utils.DefaultHttpRetryCount,
+		HttpRetryWaitTime:     utils.DefaultHttpRetryWaitTime,
+		HttpRetryMaxWaitTime:  utils.DefaultHttpRetryMaxWaitTime,
+		CertExpiryWarningDays: utils.DefaultCertExpiryWarningDays,
+	}
 	sampleMainConnfig.Environments = make(map[string]utils.EnvEndpoints)
 	sampleMainConnfig.Environments["dev"] = utils.EnvEndpoints{
 		"sample-publisher-endpoint",
@@ -0,0 +1,86 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var backupEnvironment string
+var backupTenant string
+var backupFormat string
+var backupWithKeys bool
+
+// Backup command related usage info
+const backupCmdLiteral = "backup"
+const backupCmdShortDesc = "Backup all APIs, API Products and Applications of an environment"
+
+const backupCmdLongDesc = `Export all the APIs, API Products and Applications of an environment into a single bundle
+with a manifest of counts and checksums. The bundle can be restored using the restore command`
+
+const backupCmdExamples = utils.ProjectName + ` ` + backupCmdLiteral + ` -e production
+` + utils.ProjectName + ` ` + backupCmdLiteral + ` -e production --withKeys
+` + utils.ProjectName + ` ` + backupCmdLiteral + ` -e production --tenant wso2.org
+NOTE: The flag (--environment (-e)) is mandatory`
+
+// BackupCmd represents the backup command
+var BackupCmd = &cobra.Command{
+	Use:     backupCmdLiteral + " (--environment <environment-to-be-backed-up>)",
+	Short:   backupCmdShortDesc,
+	Long:    backupCmdLongDesc,
+	Example: backupCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + backupCmdLiteral + " called")
+		cred, err := getCredentials(backupEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		accessToken, err := credentials.GetOAuthAccessToken(cred, backupEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while getting an access token for the backup", err)
+		}
+		bundlePath, manifest, err := impl.BackupToEnv(accessToken, backupEnvironment, backupTenant, backupFormat,
+			backupWithKeys)
+		if err != nil {
+			utils.HandleErrorAndExit("Error taking the backup", err)
+		}
+		fmt.Println("\nAPIs: " + strconv.Itoa(manifest.APIs.Count) + ", API Products: " +
+			strconv.Itoa(manifest.APIProducts.Count) + ", Applications: " + strconv.Itoa(manifest.Applications.Count))
+		fmt.Println("Successfully created the backup at " + bundlePath)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(BackupCmd)
+	BackupCmd.Flags().StringVarP(&backupEnvironment, "environment", "e", "",
+		"Environment to be backed up")
+	BackupCmd.Flags().StringVarP(&backupTenant, "tenant", "", "",
+		"Tenant domain of the artifacts to be backed up")
+	BackupCmd.Flags().StringVarP(&backupFormat, "format", "", "",
+		"File format of exported archives (json or yaml)")
+	BackupCmd.Flags().BoolVarP(&backupWithKeys, "withKeys", "", false,
+		"Export keys of the applications")
+	_ = BackupCmd.MarkFlagRequired("environment")
+}
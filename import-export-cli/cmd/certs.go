@@ -0,0 +1,50 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Certs command related usage Info
+const certsCmdLiteral = "certs"
+const certsCmdShortDesc = "Work with endpoint certificates of API projects"
+
+const certsCmdLongDesc = `Work with endpoint certificates given in the API params file (` + utils.ParamFileAPI +
+	`) of an API project`
+
+const certsCmdExamples = utils.ProjectName + ` ` + certsCmdLiteral + ` ` + certsInspectCmdLiteral + ` -f ./PizzaShackAPI -e prod`
+
+// CertsCmd represents the certs command
+var CertsCmd = &cobra.Command{
+	Use:     certsCmdLiteral,
+	Short:   certsCmdShortDesc,
+	Long:    certsCmdLongDesc,
+	Example: certsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + certsCmdLiteral + " called")
+
+	},
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(CertsCmd)
+}
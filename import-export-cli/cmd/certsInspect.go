@@ -0,0 +1,173 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	certAliasHeader   = "ALIAS"
+	certHostHeader    = "HOST"
+	certSubjectHeader = "SUBJECT"
+	certExpiresHeader = "EXPIRES"
+	certStatusHeader  = "STATUS"
+
+	defaultCertTableFormat = "table {{.Alias}}\t{{.Host}}\t{{.Subject}}\t{{.Expires}}\t{{.Status}}"
+)
+
+var certsInspectCmdProject string
+var certsInspectCmdEnvironment string
+var certsInspectCmdParamsFile string
+var certsInspectCmdFormat string
+
+// certsInspectCmd related info
+const certsInspectCmdLiteral = "inspect"
+const certsInspectCmdShortDesc = "Inspect endpoint certificates of an API project"
+
+const certsInspectCmdLongDesc = `Inspect the endpoint certificates given for an environment in the API params file of ` +
+	`an API project. Certificates which expire within cert_expiry_warning_days of the main config, private keys, ` +
+	`chains without a leaf certificate and hosts which do not match the certificate are reported. Exits with an ` +
+	`error if a certificate can not be imported`
+
+const certsInspectCmdExamples = utils.ProjectName + ` ` + certsCmdLiteral + ` ` + certsInspectCmdLiteral + ` -f ./PizzaShackAPI -e prod
+` + utils.ProjectName + ` ` + certsCmdLiteral + ` ` + certsInspectCmdLiteral + ` -f ./PizzaShackAPI -e prod --params prod/api_params.yaml
+NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory`
+
+// certsInspectCmd represents the certs inspect command
+var certsInspectCmd = &cobra.Command{
+	Use: certsInspectCmdLiteral + " (--file <path-to-api-project> --environment " +
+		"<environment-in-params-file>)",
+	Short:   certsInspectCmdShortDesc,
+	Long:    certsInspectCmdLongDesc,
+	Example: certsInspectCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + certsInspectCmdLiteral + " called")
+		certificates, err := impl.InspectCertificates(certsInspectCmdProject, certsInspectCmdParamsFile,
+			certsInspectCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error inspecting certificates", err)
+		}
+		printCertificates(certificates, certsInspectCmdFormat)
+
+		invalid := 0
+		for _, certificate := range certificates {
+			if certificate.Status == impl.CertStatusInvalid {
+				invalid++
+			}
+		}
+		if invalid > 0 {
+			utils.HandleErrorAndExit(fmt.Sprintf("%d certificate(s) can not be imported", invalid), nil)
+		}
+	},
+}
+
+// certificate holds information about an endpoint certificate for outputting
+type certificate struct {
+	info impl.CertificateInfo
+}
+
+// Alias of the certificate
+func (c certificate) Alias() string {
+	return c.info.Alias
+}
+
+// Host of the certificate
+func (c certificate) Host() string {
+	return c.info.Host
+}
+
+// Subject of the certificate
+func (c certificate) Subject() string {
+	return c.info.Subject
+}
+
+// Expires returns the expiry date of the certificate
+func (c certificate) Expires() string {
+	if c.info.NotAfter.IsZero() {
+		return ""
+	}
+	return c.info.NotAfter.Format(time.RFC3339)
+}
+
+// Status of the certificate along with the reason if it is expiring or invalid
+func (c certificate) Status() string {
+	if c.info.Message == "" {
+		return c.info.Status
+	}
+	return c.info.Status + ": " + c.info.Message
+}
+
+// MarshalJSON marshals certificate using custom marshaller which uses methods instead of fields
+func (c *certificate) MarshalJSON() ([]byte, error) {
+	return formatter.MarshalJSON(c)
+}
+
+// printCertificates prints the certificates using format
+func printCertificates(certificates []impl.CertificateInfo, format string) {
+	if format == "" {
+		format = defaultCertTableFormat
+	}
+	certContext := formatter.NewContext(os.Stdout, format)
+
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, info := range certificates {
+			if err := t.Execute(w, &certificate{info}); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+
+	certTableHeaders := map[string]string{
+		"Alias":   certAliasHeader,
+		"Host":    certHostHeader,
+		"Subject": certSubjectHeader,
+		"Expires": certExpiresHeader,
+		"Status":  certStatusHeader,
+	}
+
+	if err := certContext.Write(renderer, certTableHeaders); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
+
+func init() {
+	CertsCmd.AddCommand(certsInspectCmd)
+
+	certsInspectCmd.Flags().StringVarP(&certsInspectCmdProject, "file", "f", "", "Path of the API project")
+	certsInspectCmd.Flags().StringVarP(&certsInspectCmdEnvironment, "environment", "e", "",
+		"Environment in the params file to inspect certificates of")
+	certsInspectCmd.Flags().StringVarP(&certsInspectCmdParamsFile, "params", "", utils.ParamFileAPI,
+		"Provide an API Manager params file")
+	certsInspectCmd.Flags().StringVarP(&certsInspectCmdFormat, "format", "", "", "Pretty-print certificates "+
+		"using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
+	_ = certsInspectCmd.MarkFlagRequired("file")
+	_ = certsInspectCmd.MarkFlagRequired("environment")
+}
@@ -0,0 +1,175 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	envVarNameHeader   = "NAME"
+	envVarStatusHeader = "STATUS"
+	envVarFilesHeader  = "FILES"
+
+	envVarStatusSet     = "set"
+	envVarStatusDefault = "default"
+	envVarStatusUnset   = "unset"
+
+	defaultEnvVarTableFormat = "table {{.Name}}\t{{.Status}}\t{{.Files}}"
+)
+
+var envVarsCmdProject string
+var envVarsCmdParamsFile string
+var envVarsCmdEnvFiles []string
+var envVarsCmdFormat string
+
+// envVarsCmd related info
+const envVarsCmdLiteral = "env-vars"
+const envVarsCmdShortDesc = "List environment variables required by an API project"
+
+const envVarsCmdLongDesc = `List the environment variables referred in the API params file and in the files of the ` +
+	`API project which are substituted while importing, with whether they are set. Exits with an error if a ` +
+	`variable without a default value is not set`
+
+const envVarsCmdExamples = utils.ProjectName + ` ` + envVarsCmdLiteral + ` -f ./PizzaShackAPI
+` + utils.ProjectName + ` ` + envVarsCmdLiteral + ` -f ./PizzaShackAPI --params prod/api_params.yaml --env-file prod.env
+NOTE: The flag (--file (-f)) is mandatory`
+
+// envVarsCmd represents the env-vars command
+var envVarsCmd = &cobra.Command{
+	Use:     envVarsCmdLiteral + " (--file <path-to-api-project>)",
+	Short:   envVarsCmdShortDesc,
+	Long:    envVarsCmdLongDesc,
+	Example: envVarsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + envVarsCmdLiteral + " called")
+		loadEnvFiles(envVarsCmdEnvFiles)
+		variables, err := impl.ListProjectEnvVariables(envVarsCmdProject, envVarsCmdParamsFile)
+		if err != nil {
+			utils.HandleErrorAndExit("Error listing environment variables", err)
+		}
+		printEnvVariables(variables, envVarsCmdFormat)
+
+		var unset []string
+		for _, variable := range variables {
+			if !variable.IsSet() && !variable.HasDefault {
+				if variable.Message != "" {
+					unset = append(unset, variable.Name+" ("+variable.Message+")")
+				} else {
+					unset = append(unset, variable.Name)
+				}
+			}
+		}
+		if len(unset) > 0 {
+			utils.HandleErrorAndExit(fmt.Sprintf("%d environment variable(s) are not set", len(unset)),
+				fmt.Errorf("%s", strings.Join(unset, ", ")))
+		}
+	},
+}
+
+// loadEnvFiles loads the dotenv files given with --env-file to the environment
+func loadEnvFiles(envFiles []string) {
+	for _, envFile := range envFiles {
+		utils.Logln(utils.LogPrefixInfo+"Loading environment variables from", envFile)
+		if err := utils.LoadEnvFile(envFile); err != nil {
+			utils.HandleErrorAndExit("Error loading env file", err)
+		}
+	}
+}
+
+// envVariable holds information about an environment variable for outputting
+type envVariable struct {
+	variable impl.ProjectEnvVariable
+}
+
+// Name of the environment variable
+func (v envVariable) Name() string {
+	return v.variable.Name
+}
+
+// Status of the environment variable (set, default or unset)
+func (v envVariable) Status() string {
+	if v.variable.IsSet() {
+		return envVarStatusSet
+	}
+	if v.variable.HasDefault {
+		return envVarStatusDefault
+	}
+	return envVarStatusUnset
+}
+
+// Files referring the environment variable
+func (v envVariable) Files() string {
+	return strings.Join(v.variable.Files, ",")
+}
+
+// MarshalJSON marshals envVariable using custom marshaller which uses methods instead of fields
+func (v *envVariable) MarshalJSON() ([]byte, error) {
+	return formatter.MarshalJSON(v)
+}
+
+// printEnvVariables prints the environment variables using format
+func printEnvVariables(variables []impl.ProjectEnvVariable, format string) {
+	if format == "" {
+		format = defaultEnvVarTableFormat
+	}
+	envVarContext := formatter.NewContext(os.Stdout, format)
+
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, variable := range variables {
+			if err := t.Execute(w, &envVariable{variable}); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+
+	envVarTableHeaders := map[string]string{
+		"Name":   envVarNameHeader,
+		"Status": envVarStatusHeader,
+		"Files":  envVarFilesHeader,
+	}
+
+	if err := envVarContext.Write(renderer, envVarTableHeaders); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
+
+func init() {
+	RootCmd.AddCommand(envVarsCmd)
+
+	envVarsCmd.Flags().StringVarP(&envVarsCmdProject, "file", "f", "", "Path of the API project")
+	envVarsCmd.Flags().StringVarP(&envVarsCmdParamsFile, "params", "", utils.ParamFileAPI,
+		"Provide an API Manager params file")
+	envVarsCmd.Flags().StringArrayVarP(&envVarsCmdEnvFiles, "env-file", "", []string{},
+		"Load environment variables from a dotenv file")
+	envVarsCmd.Flags().StringVarP(&envVarsCmdFormat, "format", "", "", "Pretty-print environment variables "+
+		"using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
+	_ = envVarsCmd.MarkFlagRequired("file")
+}
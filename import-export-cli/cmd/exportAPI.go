@@ -20,13 +20,11 @@ package cmd
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
-	"strconv"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 
-	"github.com/go-resty/resty"
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 
@@ -77,22 +75,21 @@ func executeExportAPICmd(credential credentials.Credential, exportDirectory stri
 
 	if preCommandErr == nil {
 		adminEndpoint := utils.GetAdminEndpointOfEnv(cmdExportEnvironment, utils.MainConfigFilePath)
-		resp, err := getExportApiResponse(exportAPIName, exportAPIVersion, exportProvider, exportAPIFormat, adminEndpoint,
-			accessToken, exportAPIPreserveStatus)
-		if err != nil {
-			utils.HandleErrorAndExit("Error while exporting", err)
-		}
-		// Print info on response
-		utils.Logf(utils.LogPrefixInfo + "ResponseStatus: %v\n", resp.Status())
 		apiZipLocationPath := filepath.Join(exportDirectory, cmdExportEnvironment)
-		if resp.StatusCode() == http.StatusOK {
-			WriteToZip(exportAPIName, exportAPIVersion, apiZipLocationPath, resp)
-		} else if resp.StatusCode() == http.StatusInternalServerError {
-			// 500 Internal Server Error
-			fmt.Println(string(resp.Body()))
-		} else {
-			// neither 200 nor 500
-			fmt.Println("Error exporting API:", resp.Status(), "\n", string(resp.Body()))
+		err := ExportAPIToZip(exportAPIName, exportAPIVersion, exportProvider, exportAPIFormat, adminEndpoint,
+			accessToken, exportAPIPreserveStatus, apiZipLocationPath)
+		if downloadErr, ok := err.(*utils.DownloadError); ok {
+			// Print info on response
+			utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", downloadErr.Status)
+			if downloadErr.StatusCode == http.StatusInternalServerError {
+				// 500 Internal Server Error
+				fmt.Println(string(downloadErr.Body))
+			} else {
+				// neither 200 nor 500
+				fmt.Println("Error exporting API:", downloadErr.Status, "\n", string(downloadErr.Body))
+			}
+		} else if err != nil {
+			utils.HandleErrorAndExit("Error while exporting", err)
 		}
 	} else {
 		// error exporting Api
@@ -100,62 +97,33 @@ func executeExportAPICmd(credential credentials.Credential, exportDirectory stri
 	}
 }
 
-// WriteToZip
+// ExportAPIToZip streams the exported API to a zip file in zipLocationPath
 // @param exportAPIName : Name of the API to be exported
-// @param resp : Response returned from making the HTTP request (only pass a 200 OK)
-// Exported API will be written to a zip file
-func WriteToZip(exportAPIName, exportAPIVersion, zipLocationPath string, resp *resty.Response) {
-	// Write to file
-	//directory := filepath.Join(exportDirectory, cmdcmdExportEnvironment)
+// @param exportAPIVersion : Version of the API to be exported
+// @param zipLocationPath : Directory to write the zip file
+// @return error, *utils.DownloadError if the server returned an unsuccessful response
+func ExportAPIToZip(exportAPIName, exportAPIVersion, exportProvider, format, adminEndpoint, accessToken string,
+	preserveStatus bool, zipLocationPath string) error {
 	// create directory if it doesn't exist
 	if _, err := os.Stat(zipLocationPath); os.IsNotExist(err) {
 		err = os.Mkdir(zipLocationPath, 0777)
 		if err != nil {
-			utils.HandleErrorAndExit("Error creating zip archive", err)
+			return err
 		}
 		// permission 777 : Everyone can read, write, and execute
 	}
 	zipFilename := exportAPIName + "_" + exportAPIVersion + ".zip" // MyAPI_1.0.0.zip
 	pFile := filepath.Join(zipLocationPath, zipFilename)
-	err := ioutil.WriteFile(pFile, resp.Body(), 0644)
-	// permission 644 : Only the owner can read and write.. Everyone else can only read.
+	err := impl.ExportAPIToFile(exportAPIName, exportAPIVersion, exportProvider, format, adminEndpoint, accessToken,
+		preserveStatus, pFile)
 	if err != nil {
-		utils.HandleErrorAndExit("Error creating zip archive", err)
+		return err
 	}
 	if runnigExportApiCommand {
 		fmt.Println("Successfully exported API!")
 		fmt.Println("Find the exported API at " + pFile)
 	}
-}
-
-// ExportAPI
-// @param name : Name of the API to be exported
-// @param version : Version of the API to be exported
-// @param provider : Provider of the API 
-// @param adminEndpoint : API Manager Admin Endpoint for the environment
-// @param accessToken : Access Token for the resource
-// @return response Response in the form of *resty.Response
-func getExportApiResponse(name, version, provider, format, adminEndpoint, accessToken string, preserveStatus bool) (*resty.Response, error) {
-	adminEndpoint = utils.AppendSlashToString(adminEndpoint)
-	query := "export/api?name=" + name + "&version=" + version + "&providerName=" + provider +
-		"&preserveStatus=" + strconv.FormatBool(preserveStatus)
-	if format != "" {
-		query += "&format=" + format
-	}
-
-	url := adminEndpoint + query
-	utils.Logln(utils.LogPrefixInfo+"ExportAPI: URL:", url)
-	headers := make(map[string]string)
-	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
-	headers[utils.HeaderAccept] = utils.HeaderValueApplicationZip
-
-	resp, err := utils.InvokeGETRequest(url, headers)
-
-	if err != nil {
-		return nil, err
-	}
-	
-	return resp, nil
+	return nil
 }
 
 // init using Cobra
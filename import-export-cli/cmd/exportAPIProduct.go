@@ -20,12 +20,11 @@ package cmd
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 
-	"github.com/go-resty/resty"
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 
@@ -79,22 +78,21 @@ func executeExportAPIProductCmd(credential credentials.Credential, exportDirecto
 			// If the user has not specified the version, use the version as 1.0.0
 			exportAPIProductVersion = utils.DefaultApiProductVersion
 		}
-		resp, err := getExportApiProductResponse(exportAPIProductName, exportAPIProductVersion, exportAPIProductProvider, exportAPIProductFormat, adminEndpoint,
-			accessToken)
-		if err != nil {
-			utils.HandleErrorAndExit("Error while exporting", err)
-		}
-		// Print info on response
-		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 		apiProductZipLocationPath := filepath.Join(exportDirectory, cmdExportEnvironment)
-		if resp.StatusCode() == http.StatusOK {
-			WriteAPIProductToZip(exportAPIProductName, exportAPIProductVersion, apiProductZipLocationPath, resp)
-		} else if resp.StatusCode() == http.StatusInternalServerError {
-			// 500 Internal Server Error
-			fmt.Println(string(resp.Body()))
-		} else {
-			// neither 200 nor 500
-			fmt.Println("Error exporting API Product:", resp.Status(), "\n", string(resp.Body()))
+		err := ExportAPIProductToZip(exportAPIProductName, exportAPIProductVersion, exportAPIProductProvider,
+			exportAPIProductFormat, adminEndpoint, accessToken, apiProductZipLocationPath)
+		if downloadErr, ok := err.(*utils.DownloadError); ok {
+			// Print info on response
+			utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", downloadErr.Status)
+			if downloadErr.StatusCode == http.StatusInternalServerError {
+				// 500 Internal Server Error
+				fmt.Println(string(downloadErr.Body))
+			} else {
+				// neither 200 nor 500
+				fmt.Println("Error exporting API Product:", downloadErr.Status, "\n", string(downloadErr.Body))
+			}
+		} else if err != nil {
+			utils.HandleErrorAndExit("Error while exporting", err)
 		}
 	} else {
 		// error exporting API Product
@@ -102,59 +100,32 @@ func executeExportAPIProductCmd(credential credentials.Credential, exportDirecto
 	}
 }
 
-// WriteAPIProductToZip
+// ExportAPIProductToZip streams the exported API Product to a zip file in zipLocationPath
 // @param exportAPIProductName : Name of the API Product to be exported
-// @param resp : Response returned from making the HTTP request (only pass a 200 OK)
-// Exported API Product will be written to a zip file
-func WriteAPIProductToZip(exportAPIProductName, exportAPIProductVersion, zipLocationPath string, resp *resty.Response) {
-
+// @param exportAPIProductVersion : Version of the API Product to be exported
+// @param zipLocationPath : Directory to write the zip file
+// @return error, *utils.DownloadError if the server returned an unsuccessful response
+func ExportAPIProductToZip(exportAPIProductName, exportAPIProductVersion, exportAPIProductProvider, format,
+	adminEndpoint, accessToken, zipLocationPath string) error {
 	if _, err := os.Stat(zipLocationPath); os.IsNotExist(err) {
 		err = os.Mkdir(zipLocationPath, 0777)
 		if err != nil {
-			utils.HandleErrorAndExit("Error creating zip archive", err)
+			return err
 		}
 		// permission 777 : Everyone can read, write, and execute
 	}
 	zipFilename := exportAPIProductName + "_" + exportAPIProductVersion + ".zip" // MyAPIProduct_1.0.0.zip
 	pFile := filepath.Join(zipLocationPath, zipFilename)
-	err := ioutil.WriteFile(pFile, resp.Body(), 0644)
-	// permission 644 : Only the owner can read and write.. Everyone else can only read.
+	err := impl.ExportAPIProductToFile(exportAPIProductName, exportAPIProductVersion, exportAPIProductProvider, format,
+		adminEndpoint, accessToken, pFile)
 	if err != nil {
-		utils.HandleErrorAndExit("Error creating zip archive", err)
+		return err
 	}
 	if runningExportAPIProductCommand {
 		fmt.Println("Successfully exported API Product!")
 		fmt.Println("Find the exported API Product at " + pFile)
 	}
-}
-
-// ExportAPIProduct
-// @param name : Name of the API Product to be exported
-// @param version : Version of the API Product to be exported
-// @param provider : Provider of the API Product
-// @param adminEndpoint : API Manager Admin Endpoint for the environment
-// @param accessToken : Access Token for the resource
-// @return response Response in the form of *resty.Response
-func getExportApiProductResponse(name, version, provider, format, adminEndpoint, accessToken string) (*resty.Response, error) {
-	adminEndpoint = utils.AppendSlashToString(adminEndpoint)
-	query := "export/api-product?name=" + name + "&version=" + version + "&providerName=" + provider
-	if format != "" {
-		query += "&format=" + format
-	}
-
-	url := adminEndpoint + query
-	utils.Logln(utils.LogPrefixInfo+"ExportAPIProduct: URL:", url)
-	headers := make(map[string]string)
-	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
-	headers[utils.HeaderAccept] = utils.HeaderValueApplicationZip
-
-	resp, err := utils.InvokeGETRequest(url, headers)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return resp, nil
+	return nil
 }
 
 // init using Cobra
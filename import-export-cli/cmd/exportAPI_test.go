@@ -19,17 +19,14 @@
 package cmd
 
 import (
-	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
 
-	"github.com/go-resty/resty"
-
-	"net/http"
-	"net/http/httptest"
-
-	"github.com/renstrom/dedent"
+	"github.com/stretchr/testify/assert"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
@@ -44,30 +41,36 @@ func TestExportAPI(t *testing.T) {
 				r.Header.Get(utils.HeaderContentType))
 		}
 
+		w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationZip)
 		w.WriteHeader(http.StatusOK)
-		w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
-		w.Header().Set(utils.HeaderContentEncoding, utils.HeaderValueGZIP)
-		w.Header().Set(utils.HeaderTransferEncoding, utils.HeaderValueChunked)
-
-		body := dedent.Dedent(`
-		`)
-
-		w.Write([]byte(body))
+		w.Write([]byte("zip"))
 	}))
 	defer server.Close()
+	exportDirectory, err := ioutil.TempDir("", "")
+	assert.Nil(t, err, "should be able to create temp directory")
+	defer os.RemoveAll(exportDirectory)
 
-	resp, err := getExportApiResponse("test", "1.0", "admin", "json", server.URL, "", false)
+	err = ExportAPIToZip("test", "1.0", "admin", "json", server.URL, "", false, filepath.Join(exportDirectory, "dev"))
 	assert.Nil(t, err, "Error should be nil")
-	assert.Equal(t, 200, resp.StatusCode())
+	content, err := ioutil.ReadFile(filepath.Join(exportDirectory, "dev", "test_1.0.zip"))
+	assert.Nil(t, err, "Exported API should be written to a zip file")
+	assert.Equal(t, "zip", string(content))
 }
 
-func TestWriteToZip(t *testing.T) {
-	name := "sampleapi"
-	version := "1.0.0"
-	environment := "dev"
-	response := new(resty.Response)
-	exportDirectory := utils.CurrentDir
-	zipLocationPath := filepath.Join(exportDirectory, environment)
-	WriteToZip(name, version, zipLocationPath, response)
-	defer os.RemoveAll(filepath.Join(exportDirectory, "dev"))
+func TestExportAPIToZipError(t *testing.T) {
+	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("not found"))
+	}))
+	defer server.Close()
+	exportDirectory, err := ioutil.TempDir("", "")
+	assert.Nil(t, err, "should be able to create temp directory")
+	defer os.RemoveAll(exportDirectory)
+
+	err = ExportAPIToZip("test", "1.0", "admin", "json", server.URL, "", false, exportDirectory)
+	downloadErr, ok := err.(*utils.DownloadError)
+	assert.True(t, ok, "Error should be a download error")
+	assert.Equal(t, http.StatusNotFound, downloadErr.StatusCode)
+	assert.Equal(t, "not found", string(downloadErr.Body))
+	assert.False(t, utils.IsFileExist(filepath.Join(exportDirectory, "test_1.0.zip")), "Zip file should not be created")
 }
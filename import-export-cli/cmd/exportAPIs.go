@@ -19,8 +19,8 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
-	"net/http"
 	"path/filepath"
 	"strconv"
 
@@ -115,22 +115,17 @@ func exportAPIs() {
 					exportAPIVersion := apis[i].Version
 					exportApiProvider := apis[i].Provider
 					adminEndpoint := utils.GetAdminEndpointOfEnv(cmdExportEnvironment, utils.MainConfigFilePath)
-					resp, err := getExportApiResponse(exportAPIName, exportAPIVersion, exportApiProvider, exportAPIsFormat,
-						adminEndpoint, accessToken, exportAPIPreserveStatus)
-					if err != nil {
-						utils.HandleErrorAndExit("Error exporting", err)
-					}
-
-					if resp.StatusCode() == http.StatusOK {
-						utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
-						WriteToZip(exportAPIName, exportAPIVersion, apiExportDir, resp)
-						//write on last-succeeded-api.log
-						counterSuceededAPIs++
-						utils.WriteLastSuceededAPIFileData(exportRelatedFilesPath, apis[i])
-					} else {
+					err := ExportAPIToZip(exportAPIName, exportAPIVersion, exportApiProvider, exportAPIsFormat,
+						adminEndpoint, accessToken, exportAPIPreserveStatus, apiExportDir)
+					if downloadErr, ok := err.(*utils.DownloadError); ok {
 						fmt.Println("Error exporting API:", exportAPIName, "-", exportAPIVersion, " of Provider:", exportApiProvider)
-						utils.PrintErrorResponseAndExit(resp)
+						utils.HandleErrorAndExit("Response Status: "+downloadErr.Status, errors.New(string(downloadErr.Body)))
+					} else if err != nil {
+						utils.HandleErrorAndExit("Error exporting", err)
 					}
+					//write on last-succeeded-api.log
+					counterSuceededAPIs++
+					utils.WriteLastSuceededAPIFileData(exportRelatedFilesPath, apis[i])
 				}
 			} else {
 				// error getting OAuth tokens
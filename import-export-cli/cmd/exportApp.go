@@ -20,15 +20,13 @@ package cmd
 
 import (
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 
-	"github.com/go-resty/resty"
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
@@ -71,48 +69,45 @@ func executeExportAppCmd(credential credentials.Credential, appsExportDirectoryP
 
 	if preCommandErr == nil {
 		adminEndpiont := utils.GetAdminEndpointOfEnv(cmdExportEnvironment, utils.MainConfigFilePath)
-		resp, err := getExportAppResponse(exportAppName, exportAppOwner, adminEndpiont, accessToken)
-		if err != nil {
+		err := ExportApplicationToZip(exportAppName, exportAppOwner, adminEndpiont, accessToken, exportAppWithKeys,
+			appsExportDirectoryPath)
+		if downloadErr, ok := err.(*utils.DownloadError); ok {
+			// Print info on response
+			utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", downloadErr.Status)
+			fmt.Println("Error " + string(downloadErr.Body))
+		} else if err != nil {
 			utils.HandleErrorAndExit("Error exporting Application: "+exportAppName, err)
 		}
-
-		// Print info on response
-		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
-		if resp.StatusCode() == http.StatusOK {
-			WriteApplicationToZip(exportAppName, exportAppOwner, appsExportDirectoryPath, resp)
-		} else {
-			fmt.Println("Error " + string(resp.Body()))
-		}
 	} else {
 		// error exporting Application
 		fmt.Println("Error exporting Application:" + preCommandErr.Error())
 	}
 }
 
-// WriteApplicationToZip
+// ExportApplicationToZip streams the exported Application to a zip file in zipLocationPath
 // @param exportAppName : Name of the Application to be exported
 // @param exportAppOwner : Owner of the Application to be exported
-// @param resp : Response returned from making the HTTP request (only pass a 200 OK)
-// Exported Application will be written to a zip file
-func WriteApplicationToZip(exportAppName, exportAppOwner, zipLocationPath string,
-	resp *resty.Response) {
-	// Write to file
-	//directory := filepath.Join(exportDirectory, exportEnvironment)
-	// create directory if it doesn't exist
+// @param zipLocationPath : Directory to write the zip file
+// @return error, *utils.DownloadError if the server returned an unsuccessful response
+func ExportApplicationToZip(exportAppName, exportAppOwner, adminEndpoint, accessToken string, withKeys bool,
+	zipLocationPath string) error {
 	if _, err := os.Stat(zipLocationPath); os.IsNotExist(err) {
-		os.Mkdir(zipLocationPath, 0777)
+		err = os.MkdirAll(zipLocationPath, 0777)
+		if err != nil {
+			return err
+		}
 		// permission 777 : Everyone can read, write, and execute
 	}
 
 	zipFilename := replaceUserStoreDomainDelimiter(exportAppOwner) + "_" + exportAppName + ".zip" // admin_testApp.zip
 	pFile := filepath.Join(zipLocationPath, zipFilename)
-	err := ioutil.WriteFile(pFile, resp.Body(), 0644)
-	// permission 644 : Only the owner can read and write.. Everyone else can only read.
+	err := impl.ExportApplicationToFile(exportAppName, exportAppOwner, adminEndpoint, accessToken, withKeys, pFile)
 	if err != nil {
-		utils.HandleErrorAndExit("Error creating zip archive", err)
+		return err
 	}
 	fmt.Println("Successfully exported Application!")
 	fmt.Println("Find the exported Application at " + pFile)
+	return nil
 }
 
 // The Application owner name is used to construct a unique name for the app export zip.
@@ -124,32 +119,6 @@ func replaceUserStoreDomainDelimiter(username string) string {
 	return strings.ReplaceAll(username, "/", "#")
 }
 
-// ExportApp
-// @param name : Name of the Application to be exported
-// @param apimEndpoint : API Manager Endpoint for the environment
-// @param accessToken : Access Token for the resource
-// @return response Response in the form of *resty.Response
-func getExportAppResponse(name, owner, adminEndpoint, accessToken string) (*resty.Response, error) {
-	adminEndpoint = utils.AppendSlashToString(adminEndpoint)
-	query := "export/applications?appName=" + name + utils.SearchAndTag + "appOwner=" + owner
-
-	if exportAppWithKeys {
-		query += "&withKeys=true"
-	}
-
-	url := adminEndpoint + query
-	utils.Logln(utils.LogPrefixInfo+"ExportApp: URL:", url)
-	headers := make(map[string]string)
-	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
-	headers[utils.HeaderAccept] = utils.HeaderValueApplicationZip
-
-	resp, err := utils.InvokeGETRequest(url, headers)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
-}
-
 //init using Cobra
 func init() {
 	RootCmd.AddCommand(ExportAppCmd)
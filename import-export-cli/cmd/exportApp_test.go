@@ -19,15 +19,14 @@
 package cmd
 
 import (
-	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 
-	"github.com/go-resty/resty"
-	"github.com/renstrom/dedent"
+	"github.com/stretchr/testify/assert"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
@@ -42,31 +41,34 @@ func TestExportApp(t *testing.T) {
 				r.Header.Get(utils.HeaderContentType))
 		}
 
+		w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationZip)
 		w.WriteHeader(http.StatusOK)
-		w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
-		w.Header().Set(utils.HeaderContentEncoding, utils.HeaderValueGZIP)
-		w.Header().Set(utils.HeaderTransferEncoding, utils.HeaderValueChunked)
-
-		body := dedent.Dedent(`
-		`)
-
-		w.Write([]byte(body))
+		w.Write([]byte("zip"))
 	}))
 	defer server.Close()
+	exportDirectory, err := ioutil.TempDir("", "")
+	assert.Nil(t, err, "should be able to create temp directory")
+	defer os.RemoveAll(exportDirectory)
 
-	resp, err := getExportAppResponse("testApp", "admin", server.URL, "")
+	err = ExportApplicationToZip("testApp", "PRIMARY/admin", server.URL, "", false, exportDirectory)
 	assert.Nil(t, err, "Error should be nil")
-	assert.Equal(t, 200, resp.StatusCode(), "Should return correct status code")
+	content, err := ioutil.ReadFile(filepath.Join(exportDirectory, "PRIMARY#admin_testApp.zip"))
+	assert.Nil(t, err, "Exported Application should be written to a zip file")
+	assert.Equal(t, "zip", string(content))
 }
 
-func TestWriteApplicationToZip(t *testing.T) {
-	name := "sampleApp"
-	owner := "admin"
-	//environment := "dev"
-	response := new(resty.Response)
-
+func TestExportAppToZipError(t *testing.T) {
+	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("not found"))
+	}))
+	defer server.Close()
 	exportDirectory, err := ioutil.TempDir("", "")
 	assert.Nil(t, err, "should be able to create temp directory")
 	defer os.RemoveAll(exportDirectory)
-	WriteApplicationToZip(name, owner, exportDirectory, response)
+
+	err = ExportApplicationToZip("testApp", "admin", server.URL, "", false, exportDirectory)
+	downloadErr, ok := err.(*utils.DownloadError)
+	assert.True(t, ok, "Error should be a download error")
+	assert.Equal(t, http.StatusNotFound, downloadErr.StatusCode)
 }
@@ -45,6 +45,16 @@ var (
 	importAPIUpdate              bool
 	importAPIParamsFile          string
 	importAPISkipCleanup         bool
+	importAPIOASFile             string
+	importAPIName                string
+	importAPIVersion             string
+	importAPIContext             string
+	importAPISetValues           []string
+	importAPISetFiles            []string
+	importAPIEnvFiles            []string
+	importAPINoBundle            bool
+	importAPILint                bool
+	importAPILintRules           []string
 )
 
 const (
@@ -58,28 +68,57 @@ const (
 const importAPICmdExamples = utils.ProjectName + ` ` + importAPICmdLiteral + ` -f qa/TwitterAPI.zip -e dev
 ` + utils.ProjectName + ` ` + importAPICmdLiteral + ` -f staging/FacebookAPI.zip -e production
 ` + utils.ProjectName + ` ` + importAPICmdLiteral + ` -f ~/myapi -e production --update
-` + utils.ProjectName + ` ` + importAPICmdLiteral + ` -f ~/myapi -e production --update
-NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory`
+` + utils.ProjectName + ` ` + importAPICmdLiteral + ` --oas petstore.yaml -e dev
+` + utils.ProjectName + ` ` + importAPICmdLiteral + ` --oas petstore.yaml --name Petstore --version 1.0.0 --context /petstore -e dev
+` + utils.ProjectName + ` ` + importAPICmdLiteral + ` -f ~/myapi -e dev --set context=/myapi-pr42 --set endpointConfig.production_endpoints.url=http://pr42.dev.example.com
+` + utils.ProjectName + ` ` + importAPICmdLiteral + ` -f ~/myapi -e dev --set uriTemplates[0].throttlingTier=Gold --set-file description=./description.txt
+` + utils.ProjectName + ` ` + importAPICmdLiteral + ` -f ~/myapi -e production --env-file prod.env
+` + utils.ProjectName + ` ` + importAPICmdLiteral + ` -f ~/myapi -e production --lint --lint-rules governance.yaml
+NOTE: The flag (--environment (-e)) and one of the flags (--file (-f) or --oas) are mandatory`
 
 // ImportAPICmd represents the importAPI command
 var ImportAPICmd = &cobra.Command{
-	Use: importAPICmdLiteral + " --file <PATH_TO_API> --environment " +
+	Use: importAPICmdLiteral + " (--file <PATH_TO_API> | --oas <PATH_TO_OPENAPI_DEFINITION>) --environment " +
 		"<ENVIRONMENT>",
 	Short:   importAPICmdShortDesc,
 	Long:    importAPICmdLongDesc,
 	Example: importAPICmdExamples,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + importAPICmdLiteral + " called")
+		if (importAPIFile == "") == (importAPIOASFile == "") {
+			utils.HandleErrorAndExit("Either --file (-f) or --oas should be provided", nil)
+		}
+		loadEnvFiles(importAPIEnvFiles)
 		cred, err := getCredentials(importEnvironment)
 		if err != nil {
 			utils.HandleErrorAndExit("Error getting credentials", err)
 		}
+		overrides, err := impl.ParseOverrides(importAPISetValues, importAPISetFiles)
+		if err != nil {
+			utils.HandleErrorAndExit("Error reading overrides", err)
+		}
+		lintRules, err := importAPILintRuleSet()
+		if err != nil {
+			utils.HandleErrorAndExit("Error loading lint rules", err)
+		}
 		accessOAuthToken, err := credentials.GetOAuthAccessToken(cred, importEnvironment)
 		if err != nil {
 			utils.HandleErrorAndExit("Error while getting an access token for importing API", err)
 		}
-		err = impl.ImportAPIToEnv(accessOAuthToken, importEnvironment, importAPIFile, importAPIParamsFile, importAPIUpdate,
-			importAPICmdPreserveProvider, importAPISkipCleanup)
+		options := impl.ImportAPIOptions{
+			Overrides:        overrides,
+			Update:           importAPIUpdate,
+			PreserveProvider: importAPICmdPreserveProvider,
+			SkipCleanup:      importAPISkipCleanup,
+			NoBundle:         importAPINoBundle,
+			LintRules:        lintRules,
+		}
+		if importAPIOASFile != "" {
+			err = impl.ImportAPIFromOASToEnv(accessOAuthToken, importEnvironment, importAPIOASFile, importAPIName,
+				importAPIVersion, importAPIContext, importAPIParamsFile, options)
+		} else {
+			err = impl.ImportAPIToEnv(accessOAuthToken, importEnvironment, importAPIFile, importAPIParamsFile, options)
+		}
 		if err != nil {
 			utils.HandleErrorAndExit("Error importing API", err)
 			return
@@ -87,6 +126,18 @@ var ImportAPICmd = &cobra.Command{
 	},
 }
 
+// importAPILintRuleSet returns the rules to lint the API with before importing it, or nil when linting is disabled
+func importAPILintRuleSet() (*impl.LintRuleSet, error) {
+	if !importAPILint && !utils.LintBeforeImport {
+		return nil, nil
+	}
+	ruleFiles := importAPILintRules
+	if len(ruleFiles) == 0 {
+		ruleFiles = utils.LintRules
+	}
+	return loadLintRules(ruleFiles)
+}
+
 // mergeAPI merges environmentParams to the API given in apiDirectory
 // for now only Endpoints are merged
 func mergeAPI(apiDirectory string, environmentParams *params.Environment) error {
@@ -494,7 +545,26 @@ func init() {
 		"Provide a API Manager params file")
 	ImportAPICmd.Flags().BoolVarP(&importAPISkipCleanup, "skipCleanup", "", false, "Leave "+
 		"all temporary files created during import process")
+	ImportAPICmd.Flags().StringVarP(&importAPIOASFile, "oas", "", "", "Provide an OpenAPI specification "+
+		"file to import the API without an API project")
+	ImportAPICmd.Flags().StringVarP(&importAPIName, "name", "", "", "Name of the API when importing "+
+		"from an OpenAPI specification (overrides the title)")
+	ImportAPICmd.Flags().StringVarP(&importAPIVersion, "version", "", "", "Version of the API when importing "+
+		"from an OpenAPI specification (overrides the version)")
+	ImportAPICmd.Flags().StringVarP(&importAPIContext, "context", "", "", "Context of the API when importing "+
+		"from an OpenAPI specification (overrides the basepath)")
+	ImportAPICmd.Flags().StringArrayVarP(&importAPISetValues, "set", "", []string{}, "Set a field of "+
+		"api.yaml after applying the params file (path.to.field=value)")
+	ImportAPICmd.Flags().StringArrayVarP(&importAPISetFiles, "set-file", "", []string{}, "Set a field of "+
+		"api.yaml to the content of a file (path.to.field=file)")
+	ImportAPICmd.Flags().StringArrayVarP(&importAPIEnvFiles, "env-file", "", []string{}, "Load environment "+
+		"variables used in the API project from a dotenv file")
+	ImportAPICmd.Flags().BoolVarP(&importAPINoBundle, "no-bundle", "", false, "Keep external references of "+
+		"the swagger definition instead of inlining them")
+	ImportAPICmd.Flags().BoolVarP(&importAPILint, "lint", "", false, "Lint the API before importing it and "+
+		"stop if a rule with the severity error fails (enabled for all imports by lint_before_import)")
+	ImportAPICmd.Flags().StringArrayVarP(&importAPILintRules, "lint-rules", "", []string{}, "Rule files to lint "+
+		"the API with on top of the default rules, instead of lint_rules of the main config")
 	// Mark required flags
 	_ = ImportAPICmd.MarkFlagRequired("environment")
-	_ = ImportAPICmd.MarkFlagRequired("file")
 }
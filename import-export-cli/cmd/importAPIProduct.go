@@ -33,6 +33,10 @@ var (
 	importAPIProductUpdate              bool
 	importAPIsUpdate                    bool
 	importAPIProductSkipCleanup         bool
+	importAPIProductSetValues           []string
+	importAPIProductSetFiles            []string
+	importAPIProductParamsFile          string
+	importAPIProductEnvFiles            []string
 )
 
 const (
@@ -46,6 +50,8 @@ const importAPIProductCmdExamples = utils.ProjectName + ` ` + importCmdLiteral +
 ` + utils.ProjectName + ` ` + importCmdLiteral + ` ` + importAPIProductCmdLiteral + ` -f staging/CreditAPIProduct.zip -e production --update-api-product
 ` + utils.ProjectName + ` ` + importCmdLiteral + ` ` + importAPIProductCmdLiteral + ` -f ~/myapiproduct -e production
 ` + utils.ProjectName + ` ` + importCmdLiteral + ` ` + importAPIProductCmdLiteral + ` -f ~/myapiproduct -e production --update-api-product --update-apis
+` + utils.ProjectName + ` ` + importCmdLiteral + ` ` + importAPIProductCmdLiteral + ` -f ~/myapiproduct -e dev --set context=/myapiproduct-pr42
+` + utils.ProjectName + ` ` + importCmdLiteral + ` ` + importAPIProductCmdLiteral + ` -f ~/myapiproduct -e production --params prod/api_product_params.yaml
 NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory`
 
 // ImportAPIProductCmd represents the importAPIProduct command
@@ -57,17 +63,29 @@ var ImportAPIProductCmd = &cobra.Command{
 	Example: importAPIProductCmdExamples,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + importAPIProductCmdLiteral + " called")
+		loadEnvFiles(importAPIProductEnvFiles)
 
 		cred, err := getCredentials(importAPIProductEnvironment)
 		if err != nil {
 			utils.HandleErrorAndExit("Error getting credentials", err)
 		}
+		overrides, err := impl.ParseOverrides(importAPIProductSetValues, importAPIProductSetFiles)
+		if err != nil {
+			utils.HandleErrorAndExit("Error reading overrides", err)
+		}
 		accessOAuthToken, err := credentials.GetOAuthAccessToken(cred, importAPIProductEnvironment)
 		if err != nil {
 			utils.HandleErrorAndExit("Error while getting an access token for importing API Product", err)
 		}
-		err = impl.ImportAPIProductToEnv(accessOAuthToken, importAPIProductEnvironment, importAPIProductFile, importAPIs, importAPIsUpdate,
-				importAPIProductUpdate, importAPIProductCmdPreserveProvider, importAPIProductSkipCleanup)
+		err = impl.ImportAPIProductToEnv(accessOAuthToken, importAPIProductEnvironment, importAPIProductFile,
+			importAPIProductParamsFile, impl.ImportAPIProductOptions{
+				Overrides:        overrides,
+				ImportAPIs:       importAPIs,
+				UpdateAPIs:       importAPIsUpdate,
+				Update:           importAPIProductUpdate,
+				PreserveProvider: importAPIProductCmdPreserveProvider,
+				SkipCleanup:      importAPIProductSkipCleanup,
+			})
 		if err != nil {
 			utils.HandleErrorAndExit("Error importing API Product", err)
 			return
@@ -92,6 +110,14 @@ func init() {
 		"associated with the API Product")
 	ImportAPIProductCmd.Flags().BoolVarP(&importAPIProductSkipCleanup, "skipCleanup", "", false, "Leave "+
 		"all temporary files created during import process")
+	ImportAPIProductCmd.Flags().StringArrayVarP(&importAPIProductSetValues, "set", "", []string{}, "Set a field of "+
+		"api.yaml of the API Product (path.to.field=value)")
+	ImportAPIProductCmd.Flags().StringArrayVarP(&importAPIProductSetFiles, "set-file", "", []string{}, "Set a field "+
+		"of api.yaml of the API Product to the content of a file (path.to.field=file)")
+	ImportAPIProductCmd.Flags().StringVarP(&importAPIProductParamsFile, "params", "", utils.ParamFileAPIProduct,
+		"Provide an API Product params file")
+	ImportAPIProductCmd.Flags().StringArrayVarP(&importAPIProductEnvFiles, "env-file", "", []string{}, "Load "+
+		"environment variables used in the API Product project from a dotenv file")
 	// Mark required flags
 	_ = ImportAPIProductCmd.MarkFlagRequired("environment")
 	_ = ImportAPIProductCmd.MarkFlagRequired("file")
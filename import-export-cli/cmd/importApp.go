@@ -34,6 +34,8 @@ var preserveOwner bool
 var skipSubscriptions bool
 var importAppSkipKeys bool
 var importAppUpdateApplication bool
+var importAppParamsFile string
+var importAppEnvFiles []string
 
 // ImportApp command related usage info
 const importAppCmdLiteral = "import-app"
@@ -44,6 +46,7 @@ const importAppCmdLongDesc = "Import an Application to an environment"
 const importAppCmdExamples = utils.ProjectName + ` ` + importAppCmdLiteral + ` -f qa/apps/sampleApp.zip -e dev
 ` + utils.ProjectName + ` ` + importAppCmdLiteral + ` -f staging/apps/sampleApp.zip -e prod -o testUser
 ` + utils.ProjectName + ` ` + importAppCmdLiteral + ` -f qa/apps/sampleApp.zip --preserveOwner --skipSubscriptions -e prod
+` + utils.ProjectName + ` ` + importAppCmdLiteral + ` -f qa/apps/sampleApp.zip --params qa/apps/app_params.yaml -e prod
 NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory`
 
 // importAppCmd represents the importApp command
@@ -55,6 +58,7 @@ var ImportAppCmd = &cobra.Command{
 	Example: importAppCmdExamples,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + importAppCmdLiteral + " called")
+		loadEnvFiles(importAppEnvFiles)
 		cred, err := getCredentials(importAppEnvironment)
 		if err != nil {
 			utils.HandleErrorAndExit("Error getting credentials", err)
@@ -69,7 +73,7 @@ func executeImportAppCmd(credential credentials.Credential) {
 		utils.HandleErrorAndExit("Error getting OAuth Tokens", err)
 	}
 	resp, err := impl.ImportApplicationToEnv(accessToken, importAppEnvironment, importAppFile, importAppOwner,
-		importAppUpdateApplication, preserveOwner, skipSubscriptions, importAppSkipKeys)
+		importAppParamsFile, importAppUpdateApplication, preserveOwner, skipSubscriptions, importAppSkipKeys)
 	if err != nil {
 		utils.HandleErrorAndExit("Error importing Application", err)
 	}
@@ -110,6 +114,10 @@ func init() {
 		"Skip importing keys of the Application")
 	ImportAppCmd.Flags().BoolVarP(&importAppUpdateApplication, "update", "", false,
 		"Update the Application if it is already imported")
+	ImportAppCmd.Flags().StringVarP(&importAppParamsFile, "params", "", utils.ParamFileApp,
+		"Provide an Application params file")
+	ImportAppCmd.Flags().StringArrayVarP(&importAppEnvFiles, "env-file", "", []string{}, "Load environment "+
+		"variables used in the Application params file from a dotenv file")
 	_ = ImportAppCmd.MarkFlagRequired("file")
 	_ = ImportAppCmd.MarkFlagRequired("environment")
 }
@@ -20,23 +20,22 @@ package cmd
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/template"
 	"unicode"
 
+	"golang.org/x/crypto/ssh/terminal"
+
 	"github.com/wso2/product-apim-tooling/import-export-cli/box"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 
 	yaml2 "gopkg.in/yaml.v2"
 
-	"github.com/go-openapi/loads"
-	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
-
-	"github.com/ghodss/yaml"
-
 	"github.com/spf13/cobra"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
@@ -48,12 +47,25 @@ var (
 	initCmdApiDefinitionPath string
 	initCmdInitialState      string
 	initCmdForced            bool
+	initCmdNoBundle          bool
+	initCmdWSDLPath          string
+	initCmdSoapToRest        bool
+	initCmdGraphQLPath       string
+	initCmdEndpoint          string
+	initCmdAsyncAPIPath      string
+	initCmdTemplate          string
+	initCmdTemplateVars      []string
 )
 
 const initCmdExample = `apictl init myapi --oas petstore.yaml
 apictl init Petstore --oas https://petstore.swagger.io/v2/swagger.json
 apictl init Petstore --oas https://petstore.swagger.io/v2/swagger.json --initial-state=PUBLISHED
-apictl init MyAwesomeAPI --oas ./swagger.yaml -d definition.yaml`
+apictl init MyAwesomeAPI --oas ./swagger.yaml -d definition.yaml
+apictl init PhoneVerify --wsdl ./phoneverify.wsdl
+apictl init PhoneVerify --wsdl http://ws.cdyne.com/phoneverify/phoneverify.asmx?wsdl --soap-to-rest
+apictl init StarWarsAPI --graphql schema.graphql --endpoint https://swapi.example.com/graphql
+apictl init Notifications --asyncapi ./asyncapi.yaml
+apictl init Orders --oas ./orders.yaml --template rest-api --var Team=payments --var Owner=jane@example.com`
 
 // directories to be created
 var dirs = []string{
@@ -82,27 +94,6 @@ func createDirectories(name string) error {
 	return nil
 }
 
-// loadDefaultSpecFromDisk loads api definition stored in HOME/.wso2apictl/default_api.yaml
-func loadDefaultSpecFromDisk() (*v2.APIDefinition, error) {
-	defaultData, err := ioutil.ReadFile(utils.DefaultAPISpecFilePath)
-	if err != nil {
-		return nil, err
-	}
-	def := &v2.APIDefinition{}
-	err = yaml.Unmarshal(defaultData, &def)
-	if err != nil {
-		return nil, err
-	}
-	return def, nil
-}
-
-// loads swagger from swaggerDoc
-// swagger2.0/OpenAPI3.0 specs are supported
-func loadSwagger(swaggerDoc string) (*loads.Document, error) {
-	utils.Logln(utils.LogPrefixInfo + "Loading swagger from " + swaggerDoc)
-	return loads.Spec(swaggerDoc)
-}
-
 // hasJSONPrefix returns true if the provided buffer appears to start with
 // a JSON open brace.
 func hasJSONPrefix(buf []byte) bool {
@@ -136,11 +127,69 @@ func scaffoldParams(file string) error {
 	return nil
 }
 
+// renderInitTemplate renders the template given with --template for the project named projectName. Variables not
+// given with --var are prompted when the input is a terminal
+func renderInitTemplate(projectName string) (map[string][]byte, error) {
+	t, err := impl.ResolveInitTemplate(initCmdTemplate)
+	if err != nil {
+		return nil, err
+	}
+	utils.Logln(utils.LogPrefixInfo + "Using template " + t.Path)
+
+	given := make(map[string]string)
+	for _, v := range initCmdTemplateVars {
+		pair := strings.SplitN(v, "=", 2)
+		if len(pair) != 2 || pair[0] == "" {
+			return nil, fmt.Errorf("invalid template variable %q, expected key=value", v)
+		}
+		given[pair[0]] = pair[1]
+	}
+
+	var prompt func(variable *impl.TemplateVariable) (string, error)
+	if terminal.IsTerminal(int(os.Stdin.Fd())) {
+		prompt = func(variable *impl.TemplateVariable) (string, error) {
+			text := variable.Name
+			if variable.Description != "" {
+				text += " (" + variable.Description + ")"
+			}
+			return utils.ReadInputString(text, utils.Default{Value: variable.Default, IsDefault: variable.Default != ""},
+				`\S`, true)
+		}
+	}
+	vars, err := t.ResolveVariables(given, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := t.Render(projectName, vars)
+	if err != nil {
+		return nil, err
+	}
+	// a definition given with a flag takes the place of the one in the template
+	if initCmdSwaggerPath != "" || initCmdWSDLPath != "" || initCmdGraphQLPath != "" || initCmdAsyncAPIPath != "" {
+		delete(files, "Meta-information/swagger.yaml")
+	}
+	return files, nil
+}
+
 // executeInitCmd will run init command
 func executeInitCmd() error {
 	var dir string
 	swaggerSavePath := filepath.Join(initCmdOutputDir, filepath.FromSlash("Meta-information/swagger.yaml"))
 
+	// render the template before creating the project so that a failure does not leave a partial project
+	var templateFiles map[string][]byte
+	if initCmdTemplate != "" {
+		projectPath, err := filepath.Abs(initCmdOutputDir)
+		if err != nil {
+			return err
+		}
+		templateFiles, err = renderInitTemplate(filepath.Base(projectPath))
+		if err != nil {
+			return err
+		}
+	}
+
 	if initCmdOutputDir != "" {
 		err := os.MkdirAll(initCmdOutputDir, os.ModePerm)
 		if err != nil {
@@ -160,11 +209,20 @@ func executeInitCmd() error {
 	}
 	fmt.Println("Initializing a new WSO2 API Manager project in", dir)
 
-	def, err := loadDefaultSpecFromDisk()
+	def, err := impl.LoadDefaultSpecFromDisk()
 	if err != nil {
 		return err
 	}
 
+	// the API definition of the template is used on top of the default one
+	if apiTemplate, ok := templateFiles[impl.TemplateAPIDefinitionPath]; ok {
+		delete(templateFiles, impl.TemplateAPIDefinitionPath)
+		def, err = impl.MergeAPIDefinition(def, apiTemplate)
+		if err != nil {
+			return fmt.Errorf("invalid API definition in template: %v", err)
+		}
+	}
+
 	// initCmdInitialState has already validated before creating the 'dir'
 	if initCmdInitialState != "" {
 		def.Status = initCmdInitialState
@@ -176,27 +234,39 @@ func executeInitCmd() error {
 	}
 
 	// use swagger to auto generate
-	if initCmdSwaggerPath != "" {
-		// load swagger from path
-		doc, err := loadSwagger(initCmdSwaggerPath)
+	if initCmdWSDLPath != "" {
+		// populate from the WSDL, which writes the WSDL, the swagger and the SOAP to REST sequences to the project
+		err = impl.InitAPIProjectFromWSDL(def, initCmdOutputDir, initCmdWSDLPath, initCmdSoapToRest)
 		if err != nil {
 			return err
 		}
-		// We use swagger2 loader. It works fine for now
-		// Since we don't use 3.0 specific details its ok
-		// otherwise please use v2.openAPI3 loaders
-		err = v2.Swagger2Populate(def, doc)
+	} else if initCmdGraphQLPath != "" {
+		schema, err := impl.PopulateAPIFromGraphQL(def, filepath.Base(dir), initCmdGraphQLPath, initCmdEndpoint)
 		if err != nil {
 			return err
 		}
 
-		if def.EndpointConfig != nil {
-			def.ProductionUrl = ""
-			def.SandboxUrl = ""
+		schemaSavePath := filepath.Join(initCmdOutputDir, "Meta-information", impl.GraphQLSchemaFileName)
+		utils.Logln(utils.LogPrefixInfo + "Writing " + schemaSavePath)
+		err = ioutil.WriteFile(schemaSavePath, schema, os.ModePerm)
+		if err != nil {
+			return err
+		}
+	} else if initCmdAsyncAPIPath != "" {
+		asyncAPI, err := impl.PopulateAPIFromAsyncAPI(def, initCmdAsyncAPIPath)
+		if err != nil {
+			return err
 		}
 
-		// convert and save swagger as yaml
-		yamlSwagger, err := utils.JsonToYaml(doc.Raw())
+		asyncAPISavePath := filepath.Join(initCmdOutputDir, "Meta-information", impl.AsyncAPIFileName)
+		utils.Logln(utils.LogPrefixInfo + "Writing " + asyncAPISavePath)
+		err = ioutil.WriteFile(asyncAPISavePath, asyncAPI, os.ModePerm)
+		if err != nil {
+			return err
+		}
+	} else if initCmdSwaggerPath != "" {
+		// load swagger from path, swagger 2.0 and OpenAPI 3 definitions are populated with their own loaders
+		yamlSwagger, err := impl.PopulateAPIFromOpenAPI(def, initCmdSwaggerPath, initCmdNoBundle)
 		if err != nil {
 			return err
 		}
@@ -225,44 +295,18 @@ func executeInitCmd() error {
 			return err
 		}
 
-		apiDef := &v2.APIDefinition{}
-
 		// substitute env variables
 		utils.Logln(utils.LogPrefixInfo + "Substituting environment variables")
 		data, err := utils.EnvSubstitute(string(content))
 		if err != nil {
 			return err
 		}
-		content = []byte(data)
-
-		// read from yaml definition
-		err = yaml2.Unmarshal(content, &apiDef)
-		if err != nil {
-			return err
-		}
-
-		// marshal original def
-		originalDefBytes, err := json.Marshal(def)
-		if err != nil {
-			return err
-		}
-		// marshal new def
-		newDefBytes, err := json.Marshal(apiDef)
-		if err != nil {
-			return err
-		}
 
-		// merge two definitions
-		finalDefBytes, err := utils.MergeJSON(originalDefBytes, newDefBytes)
-		if err != nil {
-			return err
-		}
-		tmpDef := &v2.APIDefinition{}
-		err = json.Unmarshal(finalDefBytes, &tmpDef)
+		// merge the definition on top of the populated one
+		def, err = impl.MergeAPIDefinition(def, []byte(data))
 		if err != nil {
 			return err
 		}
-		def = tmpDef
 	}
 
 	apiData, err := yaml2.Marshal(def)
@@ -293,15 +337,29 @@ func executeInitCmd() error {
 		return err
 	}
 
+	// files of the template replace the scaffolded ones
+	if templateFiles != nil {
+		err = impl.WriteTemplateFiles(initCmdOutputDir, templateFiles)
+		if err != nil {
+			return err
+		}
+	}
+
 	fmt.Println("Project initialized")
 	fmt.Println("Open README file to learn more")
 	return nil
 }
 
 var InitCommand = &cobra.Command{
-	Use:     "init [project path]",
-	Short:   "Initialize a new project in given path",
-	Long:    "Initialize a new project in given path. If a OpenAPI specification provided API will be populated with details from it",
+	Use:   "init [project path]",
+	Short: "Initialize a new project in given path",
+	Long: "Initialize a new project in given path. If a Swagger 2.0 or OpenAPI 3 specification provided API will be " +
+		"populated with details from it. If a WSDL is provided a SOAP API, or a SOAP to REST API with --soap-to-rest, " +
+		"will be populated with the operations and the endpoint of the service. If a GraphQL schema is provided a " +
+		"GraphQL API will be populated with its queries, mutations and subscriptions. If an AsyncAPI document is " +
+		"provided a WebSocket, WebSub or SSE API will be populated with its channels and servers. If a template " +
+		"is provided with --template, its files are rendered with Go templates using the variables given with " +
+		"--var, or prompted for, and replace the scaffolded files of the project",
 	Example: initCmdExample,
 	Args:    cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
@@ -322,6 +380,28 @@ var InitCommand = &cobra.Command{
 			fmt.Println("Running command in forced mode")
 		}
 
+		definitions := 0
+		for _, definition := range []string{initCmdSwaggerPath, initCmdWSDLPath, initCmdGraphQLPath,
+			initCmdAsyncAPIPath} {
+			if definition != "" {
+				definitions++
+			}
+		}
+		if definitions > 1 {
+			utils.HandleErrorAndExit("Only one of --oas, --wsdl, --graphql and --asyncapi can be used", nil)
+		}
+		if initCmdSoapToRest && initCmdWSDLPath == "" {
+			utils.HandleErrorAndExit("--soap-to-rest requires a WSDL provided with --wsdl", nil)
+		}
+		if initCmdEndpoint != "" {
+			if initCmdGraphQLPath == "" {
+				utils.HandleErrorAndExit("--endpoint requires a GraphQL schema provided with --graphql", nil)
+			}
+			if u, err := url.Parse(initCmdEndpoint); err != nil || u.Scheme == "" || u.Host == "" {
+				utils.HandleErrorAndExit("Invalid endpoint URL: "+initCmdEndpoint, nil)
+			}
+		}
+
 		// check the validity of initial-state before initializing
 		if initCmdInitialState != "" {
 			validState := false
@@ -355,4 +435,20 @@ func init() {
 	InitCommand.Flags().StringVar(&initCmdInitialState, "initial-state", "", fmt.Sprintf("Provide the initial state "+
 		"of the API; Valid states: %v", utils.ValidInitialStates))
 	InitCommand.Flags().BoolVarP(&initCmdForced, "force", "f", false, "Force create project")
+	InitCommand.Flags().BoolVarP(&initCmdNoBundle, "no-bundle", "", false, "Keep external references of the "+
+		"OpenAPI specification instead of inlining them")
+	InitCommand.Flags().StringVarP(&initCmdWSDLPath, "wsdl", "", "", "Provide a WSDL file or URL of a SOAP "+
+		"service for the API")
+	InitCommand.Flags().BoolVarP(&initCmdSoapToRest, "soap-to-rest", "", false, "Expose the SOAP service as a "+
+		"REST API with a resource and mediation sequences for each operation")
+	InitCommand.Flags().StringVarP(&initCmdGraphQLPath, "graphql", "", "", "Provide a GraphQL schema (SDL) file "+
+		"or URL for the API")
+	InitCommand.Flags().StringVarP(&initCmdEndpoint, "endpoint", "", "", "Production endpoint URL of the "+
+		"GraphQL API")
+	InitCommand.Flags().StringVarP(&initCmdAsyncAPIPath, "asyncapi", "", "", "Provide an AsyncAPI 2.x document "+
+		"file or URL for a WebSocket, WebSub or SSE API")
+	InitCommand.Flags().StringVarP(&initCmdTemplate, "template", "", "", "Name of a template registered in "+
+		"the templates directory of the config dir, or path of a template directory, to create the project from")
+	InitCommand.Flags().StringArrayVarP(&initCmdTemplateVars, "var", "", []string{}, "Value of a template "+
+		"variable in key=value format")
 }
@@ -0,0 +1,110 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/box"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var lintCmdProject string
+var lintCmdRules []string
+var lintCmdFormat string
+
+// lintCmd related info
+const lintCmdLiteral = "lint"
+const lintCmdShortDesc = "Lint an API project against governance rules"
+
+const lintCmdLongDesc = `Lint the api.yaml and the swagger of an API project against governance rules. Rules are ` +
+	`written in YAML with a JSONPath selecting the values to check, a function to check them with and a severity. ` +
+	`Rule files given with --rules are applied on top of the default rules of apictl and replace the rules with the ` +
+	`same id. Reports are printed as text, JSON or SARIF. Exits with status 1 if a rule with the severity error fails`
+
+const lintCmdExamples = utils.ProjectName + ` ` + lintCmdLiteral + ` -f ./PizzaShackAPI
+` + utils.ProjectName + ` ` + lintCmdLiteral + ` -f ./PizzaShackAPI --rules governance.yaml
+` + utils.ProjectName + ` ` + lintCmdLiteral + ` -f ./PizzaShackAPI --format sarif > apictl.sarif`
+
+// lintCmd represents the lint command
+var lintCmd = &cobra.Command{
+	Use:     lintCmdLiteral + " (--file <api-project>)",
+	Short:   lintCmdShortDesc,
+	Long:    lintCmdLongDesc,
+	Example: lintCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + lintCmdLiteral + " called")
+		ruleSet, err := loadLintRules(lintCmdRules)
+		if err != nil {
+			utils.HandleErrorAndExit("Error loading lint rules", err)
+		}
+		report, err := impl.LintAPIProject(lintCmdProject, ruleSet)
+		if err != nil {
+			utils.HandleErrorAndExit("Error linting the API project", err)
+		}
+		out, err := impl.FormatLintReport(report, lintCmdFormat)
+		if err != nil {
+			utils.HandleErrorAndExit("Error printing the lint report", err)
+		}
+		fmt.Println(string(out))
+		if report.Errors > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// loadLintRules reads the default rules of apictl and the rule files on top of them
+func loadLintRules(ruleFiles []string) (*impl.LintRuleSet, error) {
+	content, ok := box.Get("/lint/default_rules.yaml")
+	if !ok {
+		return nil, errors.New("default lint rules are not found")
+	}
+	ruleSet, err := impl.LoadLintRules(content, "default rules")
+	if err != nil {
+		return nil, err
+	}
+	for _, ruleFile := range ruleFiles {
+		utils.Logln(utils.LogPrefixInfo + "Loading lint rules from " + ruleFile)
+		content, err := ioutil.ReadFile(ruleFile)
+		if err != nil {
+			return nil, err
+		}
+		rules, err := impl.LoadLintRules(content, ruleFile)
+		if err != nil {
+			return nil, err
+		}
+		ruleSet.Merge(rules)
+	}
+	return ruleSet, ruleSet.Compile()
+}
+
+func init() {
+	RootCmd.AddCommand(lintCmd)
+	lintCmd.Flags().StringVarP(&lintCmdProject, "file", "f", "", "Path of the API project directory")
+	lintCmd.Flags().StringArrayVarP(&lintCmdRules, "rules", "r", []string{}, "Rule files applied on top of "+
+		"the default rules")
+	lintCmd.Flags().StringVarP(&lintCmdFormat, "format", "", impl.LintFormatText, "Output format (text, json or "+
+		"sarif)")
+	_ = lintCmd.MarkFlagRequired("file")
+}
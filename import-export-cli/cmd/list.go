@@ -36,7 +36,8 @@ List all the environments`
 const listCmdExamples = utils.ProjectName + ` ` + listCmdLiteral + ` ` + EnvsCmdLiteral + `
 ` + utils.ProjectName + ` ` + listCmdLiteral + ` ` + apisCmdLiteral + ` -e dev
 ` + utils.ProjectName + ` ` + listCmdLiteral + ` ` + apiProductsCmdLiteral + ` -e dev
-` + utils.ProjectName + ` ` + listCmdLiteral + ` ` + appsCmdLiteral + ` -e dev`
+` + utils.ProjectName + ` ` + listCmdLiteral + ` ` + appsCmdLiteral + ` -e dev
+` + utils.ProjectName + ` ` + listCmdLiteral + ` ` + snapshotsCmdLiteral + ` -e dev`
 
 // ListCmd represents the list command
 var ListCmd = &cobra.Command{
@@ -0,0 +1,102 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var mockCmdProject string
+var mockCmdHost string
+var mockCmdPort int
+var mockCmdSandboxEnvironment string
+var mockCmdParamsFile string
+
+// mockCmd related info
+const mockCmdLiteral = "mock"
+const mockCmdShortDesc = "Run a mock backend of an API project"
+
+const mockCmdLongDesc = `Start a local HTTP server serving every path and verb of the swagger of an API project ` +
+	`under its x-wso2-basePath. Responses are taken from the examples of the swagger or synthesised from the ` +
+	`schemas, and requests are validated against the parameters and the body schemas. With --sandbox-env the ` +
+	`sandbox endpoint of the environment in the API params file is pointed at the mock server`
+
+const mockCmdExamples = utils.ProjectName + ` ` + mockCmdLiteral + ` -f ./PizzaShackAPI
+` + utils.ProjectName + ` ` + mockCmdLiteral + ` -f ./PizzaShackAPI --port 9090 --sandbox-env dev
+NOTE: The flag (--file (-f)) is mandatory`
+
+// mockCmd represents the mock command
+var mockCmd = &cobra.Command{
+	Use:     mockCmdLiteral + " (--file <path-to-api-project>)",
+	Short:   mockCmdShortDesc,
+	Long:    mockCmdLongDesc,
+	Example: mockCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + mockCmdLiteral + " called")
+		server, err := impl.NewMockServer(mockCmdProject)
+		if err != nil {
+			utils.HandleErrorAndExit("Error loading the swagger of the API project", err)
+		}
+		server.Log = os.Stdout
+
+		host := mockCmdHost
+		if host == "" || host == "0.0.0.0" {
+			host = "localhost"
+		}
+		mockURL := "http://" + net.JoinHostPort(host, strconv.Itoa(mockCmdPort)) + server.BasePath
+		if mockCmdSandboxEnvironment != "" {
+			paramsFile, err := impl.PointSandboxEndpointToMock(mockCmdProject, mockCmdParamsFile,
+				mockCmdSandboxEnvironment, mockURL)
+			if err != nil {
+				utils.HandleErrorAndExit("Error setting the sandbox endpoint", err)
+			}
+			fmt.Printf("Sandbox endpoint of %s in %s set to %s\n", mockCmdSandboxEnvironment, paramsFile, mockURL)
+		}
+
+		for _, operation := range server.Operations() {
+			fmt.Println("  " + operation)
+		}
+		fmt.Println("Mock server listening on " + mockURL)
+		err = http.ListenAndServe(net.JoinHostPort(mockCmdHost, strconv.Itoa(mockCmdPort)), server)
+		if err != nil {
+			utils.HandleErrorAndExit("Error running the mock server", err)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(mockCmd)
+
+	mockCmd.Flags().StringVarP(&mockCmdProject, "file", "f", "", "Path of the API project")
+	mockCmd.Flags().StringVarP(&mockCmdHost, "host", "", "localhost", "Host to listen on")
+	mockCmd.Flags().IntVarP(&mockCmdPort, "port", "", 8080, "Port to listen on")
+	mockCmd.Flags().StringVarP(&mockCmdSandboxEnvironment, "sandbox-env", "", "", "Point the sandbox endpoint "+
+		"of this environment in the API params file at the mock server")
+	mockCmd.Flags().StringVarP(&mockCmdParamsFile, "params", "", utils.ParamFileAPI,
+		"Provide an API Manager params file")
+	_ = mockCmd.MarkFlagRequired("file")
+}
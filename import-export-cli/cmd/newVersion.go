@@ -0,0 +1,144 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var newVersionCmdAPIName string
+var newVersionCmdAPIVersion string
+var newVersionCmdAPIProvider string
+var newVersionCmdEnvironment string
+var newVersionCmdNewVersion string
+var newVersionCmdDefault bool
+var newVersionCmdPublish bool
+var newVersionCmdDeprecateOld bool
+var newVersionCmdProject string
+
+// newVersionCmd related info
+const newVersionCmdLiteral = "new-version"
+const newVersionCmdShortDesc = "Create a new version of an API"
+
+const newVersionCmdLongDesc = `Create a new version of an API in the environment specified by the flag ` +
+	`--environment, -e using the copy API operation of the Publisher. The new version can be published with ` +
+	`--publish, and the old version can then be deprecated with --deprecate-old. If an API project is given with ` +
+	`--file, -f the version in the API definition, the versioned context and the version of the swagger of the ` +
+	`project are changed instead`
+
+const newVersionCmdExamples = utils.ProjectName + ` ` + newVersionCmdLiteral + ` -n PizzaShackAPI -v 1.0.0 --new-version 2.0.0 -e dev
+` + utils.ProjectName + ` ` + newVersionCmdLiteral + ` -n PizzaShackAPI -v 1.0.0 -r admin --new-version 2.0.0 -e dev --default --publish --deprecate-old
+` + utils.ProjectName + ` ` + newVersionCmdLiteral + ` -f ./PizzaShackAPI --new-version 2.0.0 --default
+NOTE: --new-version is mandatory along with either --file (-f) or --name (-n), --version (-v) and --environment (-e)`
+
+// newVersionCmd represents the new-version command
+var newVersionCmd = &cobra.Command{
+	Use: newVersionCmdLiteral + " (--name <name-of-the-api> --version <version-of-the-api> --environment " +
+		"<environment-of-the-api> | --file <path-to-api-project>) --new-version <new-version-of-the-api>",
+	Short:   newVersionCmdShortDesc,
+	Long:    newVersionCmdLongDesc,
+	Example: newVersionCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + newVersionCmdLiteral + " called")
+		if newVersionCmdProject != "" {
+			if newVersionCmdAPIName != "" || newVersionCmdAPIVersion != "" || newVersionCmdEnvironment != "" ||
+				newVersionCmdPublish || newVersionCmdDeprecateOld {
+				utils.HandleErrorAndExit("--file can not be used with --name, --version, --environment, --publish "+
+					"and --deprecate-old", nil)
+			}
+			executeNewAPIProjectVersionCmd()
+			return
+		}
+
+		if newVersionCmdAPIName == "" || newVersionCmdAPIVersion == "" || newVersionCmdEnvironment == "" {
+			utils.HandleErrorAndExit("--name, --version and --environment are required unless an API project is "+
+				"given with --file", nil)
+		}
+		if newVersionCmdDeprecateOld && !newVersionCmdPublish {
+			utils.HandleErrorAndExit("--deprecate-old requires --publish as the old version is deprecated after "+
+				"the new version is published", nil)
+		}
+		cred, err := getCredentials(newVersionCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeNewAPIVersionCmd(cred)
+	},
+}
+
+// executeNewAPIVersionCmd creates the new version of the API in the environment
+func executeNewAPIVersionCmd(credential credentials.Credential) {
+	accessOAuthToken, err := credentials.GetOAuthAccessToken(credential, newVersionCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while getting an access token for creating a new API version", err)
+	}
+	api, err := impl.NewAPIVersionToEnv(accessOAuthToken, newVersionCmdEnvironment, newVersionCmdAPIName,
+		newVersionCmdAPIVersion, newVersionCmdAPIProvider, newVersionCmdNewVersion, newVersionCmdDefault,
+		newVersionCmdPublish, newVersionCmdDeprecateOld)
+	if api != nil {
+		fmt.Printf("Version %s of %s created with the context %s\n", api.Version, api.Name, api.Context)
+	}
+	if err != nil {
+		utils.HandleErrorAndExit("Error creating new API version", err)
+	}
+	if newVersionCmdPublish {
+		fmt.Printf("Version %s of %s published\n", api.Version, api.Name)
+	}
+	if newVersionCmdDeprecateOld {
+		fmt.Printf("Version %s of %s deprecated\n", newVersionCmdAPIVersion, newVersionCmdAPIName)
+	}
+}
+
+// executeNewAPIProjectVersionCmd changes the version of the API project
+func executeNewAPIProjectVersionCmd() {
+	oldVersion, err := impl.NewAPIProjectVersion(newVersionCmdProject, newVersionCmdNewVersion, newVersionCmdDefault)
+	if err != nil {
+		utils.HandleErrorAndExit("Error creating new API version", err)
+	}
+	fmt.Printf("API project %s changed from version %s to %s\n", newVersionCmdProject, oldVersion,
+		newVersionCmdNewVersion)
+}
+
+func init() {
+	RootCmd.AddCommand(newVersionCmd)
+	newVersionCmd.Flags().StringVarP(&newVersionCmdAPIName, "name", "n", "",
+		"Name of the API to create a new version of")
+	newVersionCmd.Flags().StringVarP(&newVersionCmdAPIVersion, "version", "v", "",
+		"Version of the API to create a new version of")
+	newVersionCmd.Flags().StringVarP(&newVersionCmdAPIProvider, "provider", "r", "",
+		"Provider of the API")
+	newVersionCmd.Flags().StringVarP(&newVersionCmdEnvironment, "environment", "e", "",
+		"Environment of the API")
+	newVersionCmd.Flags().StringVarP(&newVersionCmdNewVersion, "new-version", "", "",
+		"The new version of the API")
+	newVersionCmd.Flags().BoolVarP(&newVersionCmdDefault, "default", "", false,
+		"Make the new version the default version of the API")
+	newVersionCmd.Flags().BoolVarP(&newVersionCmdPublish, "publish", "", false,
+		"Publish the new version of the API")
+	newVersionCmd.Flags().BoolVarP(&newVersionCmdDeprecateOld, "deprecate-old", "", false,
+		"Deprecate the old version of the API after the new version is published")
+	newVersionCmd.Flags().StringVarP(&newVersionCmdProject, "file", "f", "",
+		"Path of an API project to change to the new version instead of an API in an environment")
+	_ = newVersionCmd.MarkFlagRequired("new-version")
+}
@@ -0,0 +1,67 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var paramsRenderCmdFile string
+var paramsRenderCmdEnvironment string
+
+// paramsRenderCmd related info
+const paramsRenderCmdLiteral = "render"
+const paramsRenderCmdShortDesc = "Display an environment of an API params file"
+
+const paramsRenderCmdLongDesc = `Display the environment specified by the flag --environment, -e of an API params file after ` +
+	`the defaults section and the environments it extends are merged into it`
+
+const paramsRenderCmdExamples = utils.ProjectName + ` ` + paramsCmdLiteral + ` ` + paramsRenderCmdLiteral + ` -e prod
+` + utils.ProjectName + ` ` + paramsCmdLiteral + ` ` + paramsRenderCmdLiteral + ` -f ~/PizzaShackAPI/api_params.yaml -e staging
+NOTE: The flag (--environment (-e)) is mandatory`
+
+// paramsRenderCmd represents the params render command
+var paramsRenderCmd = &cobra.Command{
+	Use:     paramsRenderCmdLiteral,
+	Short:   paramsRenderCmdShortDesc,
+	Long:    paramsRenderCmdLongDesc,
+	Example: paramsRenderCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + paramsRenderCmdLiteral + " called")
+		content, err := params.RenderApiParamsEnv(paramsRenderCmdFile, paramsRenderCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error rendering "+paramsRenderCmdFile, err)
+		}
+		fmt.Print(string(content))
+	},
+}
+
+func init() {
+	ParamsCmd.AddCommand(paramsRenderCmd)
+
+	paramsRenderCmd.Flags().StringVarP(&paramsRenderCmdFile, "file", "f", utils.ParamFileAPI,
+		"Path of the API params file")
+	paramsRenderCmd.Flags().StringVarP(&paramsRenderCmdEnvironment, "environment", "e", "",
+		"Environment to render")
+	_ = paramsRenderCmd.MarkFlagRequired("environment")
+}
@@ -0,0 +1,72 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var paramsValidateCmdFile string
+var paramsValidateCmdProject string
+
+// paramsValidateCmd related info
+const paramsValidateCmdLiteral = "validate"
+const paramsValidateCmdShortDesc = "Validate an API params file"
+
+const paramsValidateCmdLongDesc = `Validate an API params file without importing an API. Unknown fields are reported ` +
+	`with their position in the file along with malformed endpoint URLs, invalid endpoint security settings and ` +
+	`certificates which do not exist`
+
+const paramsValidateCmdExamples = utils.ProjectName + ` ` + paramsCmdLiteral + ` ` + paramsValidateCmdLiteral + `
+` + utils.ProjectName + ` ` + paramsCmdLiteral + ` ` + paramsValidateCmdLiteral + ` -f ~/PizzaShackAPI/api_params.yaml
+` + utils.ProjectName + ` ` + paramsCmdLiteral + ` ` + paramsValidateCmdLiteral + ` -f prod/api_params.yaml --project ~/PizzaShackAPI`
+
+// paramsValidateCmd represents the params validate command
+var paramsValidateCmd = &cobra.Command{
+	Use:     paramsValidateCmdLiteral,
+	Short:   paramsValidateCmdShortDesc,
+	Long:    paramsValidateCmdLongDesc,
+	Example: paramsValidateCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + paramsValidateCmdLiteral + " called")
+		projectDir := paramsValidateCmdProject
+		if projectDir == "" {
+			projectDir = filepath.Dir(paramsValidateCmdFile)
+		}
+		err := params.ValidateApiParamsFile(paramsValidateCmdFile, projectDir)
+		if err != nil {
+			utils.HandleErrorAndExit("Invalid API params file", err)
+		}
+		fmt.Println(paramsValidateCmdFile + " is valid")
+	},
+}
+
+func init() {
+	ParamsCmd.AddCommand(paramsValidateCmd)
+
+	paramsValidateCmd.Flags().StringVarP(&paramsValidateCmdFile, "file", "f", utils.ParamFileAPI,
+		"Path of the API params file")
+	paramsValidateCmd.Flags().StringVarP(&paramsValidateCmdProject, "project", "", "",
+		"API project to resolve certificates, defaults to the directory of the API params file")
+}
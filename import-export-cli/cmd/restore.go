@@ -0,0 +1,83 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var restoreEnvironment string
+var restoreUpdate bool
+var restoreSkipKeys bool
+
+// Restore command related usage info
+const restoreCmdLiteral = "restore"
+const restoreCmdShortDesc = "Restore a backup bundle to an environment"
+
+const restoreCmdLongDesc = `Import a bundle created by the backup command to an environment. APIs are imported first,
+then API Products and finally Applications with their subscriptions. Checksums of the bundle are verified
+before anything is imported`
+
+const restoreCmdExamples = utils.ProjectName + ` ` + restoreCmdLiteral + ` production_tenant-default_20200504101532.zip -e dr
+` + utils.ProjectName + ` ` + restoreCmdLiteral + ` production_tenant-default_20200504101532.zip -e dr --update
+NOTE: The flag (--environment (-e)) is mandatory`
+
+// RestoreCmd represents the restore command
+var RestoreCmd = &cobra.Command{
+	Use:     restoreCmdLiteral + " <PATH_TO_BUNDLE> (--environment <environment-to-be-restored>)",
+	Short:   restoreCmdShortDesc,
+	Long:    restoreCmdLongDesc,
+	Example: restoreCmdExamples,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + restoreCmdLiteral + " called")
+		cred, err := getCredentials(restoreEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		accessToken, err := credentials.GetOAuthAccessToken(cred, restoreEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while getting an access token for the restore", err)
+		}
+		manifest, err := impl.RestoreToEnv(accessToken, restoreEnvironment, args[0], restoreUpdate, restoreSkipKeys)
+		if err != nil {
+			utils.HandleErrorAndExit("Error restoring the backup", err)
+		}
+		fmt.Println("\nAPIs: " + strconv.Itoa(manifest.APIs.Count) + ", API Products: " +
+			strconv.Itoa(manifest.APIProducts.Count) + ", Applications: " + strconv.Itoa(manifest.Applications.Count))
+		fmt.Println("Successfully restored the backup to " + restoreEnvironment)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(RestoreCmd)
+	RestoreCmd.Flags().StringVarP(&restoreEnvironment, "environment", "e", "",
+		"Environment to which the backup should be restored")
+	RestoreCmd.Flags().BoolVarP(&restoreUpdate, "update", "", false,
+		"Update the artifacts which already exist in the environment")
+	RestoreCmd.Flags().BoolVarP(&restoreSkipKeys, "skipKeys", "", false,
+		"Skip importing keys of the applications")
+	_ = RestoreCmd.MarkFlagRequired("environment")
+}
@@ -0,0 +1,47 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Rollback command related usage info
+const rollbackCmdLiteral = "rollback"
+const rollbackCmdShortDesc = "Rollback an API to a previous snapshot"
+const rollbackCmdLongDesc = `Re-import a snapshot taken before an API was overwritten using import-api --update`
+
+const rollbackCmdExamples = utils.ProjectName + ` ` + rollbackCmdLiteral + ` ` + rollbackAPICmdLiteral + ` -n TwitterAPI -v 1.0.0 -e dev
+` + utils.ProjectName + ` ` + rollbackCmdLiteral + ` ` + rollbackAPICmdLiteral + ` -n TwitterAPI -v 1.0.0 -e dev --to 20200504-101532.120`
+
+// RollbackCmd represents the rollback command
+var RollbackCmd = &cobra.Command{
+	Use:     rollbackCmdLiteral,
+	Short:   rollbackCmdShortDesc,
+	Long:    rollbackCmdLongDesc,
+	Example: rollbackCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + rollbackCmdLiteral + " called")
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(RollbackCmd)
+}
@@ -0,0 +1,87 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var rollbackAPIName string
+var rollbackAPIVersion string
+var rollbackAPIEnvironment string
+var rollbackAPISnapshot string
+
+// RollbackAPI command related usage info
+const rollbackAPICmdLiteral = "api"
+const rollbackAPICmdShortDesc = "Rollback an API to a previous snapshot"
+const rollbackAPICmdLongDesc = `Re-import a snapshot of an API in the environment specified by the flag --environment, -e.
+The latest snapshot is used unless a snapshot is given with --to. Snapshots are taken automatically before
+an existing API is overwritten by import-api --update and can be listed using "` + utils.ProjectName + ` ` +
+	listCmdLiteral + ` ` + snapshotsCmdLiteral + `"`
+
+const rollbackAPICmdExamples = utils.ProjectName + ` ` + rollbackCmdLiteral + ` ` + rollbackAPICmdLiteral + ` -n TwitterAPI -v 1.0.0 -e dev
+` + utils.ProjectName + ` ` + rollbackCmdLiteral + ` ` + rollbackAPICmdLiteral + ` -n TwitterAPI -v 1.0.0 -e dev --to 20200504-101532.120
+NOTE: The 3 flags (--name (-n), --version (-v), and --environment (-e)) are mandatory.`
+
+// RollbackAPICmd represents the rollback api command
+var RollbackAPICmd = &cobra.Command{
+	Use: rollbackAPICmdLiteral + " (--name <name-of-the-api> --version <version-of-the-api> --environment " +
+		"<environment-of-the-api>) [--to <snapshot>]",
+	Short:   rollbackAPICmdShortDesc,
+	Long:    rollbackAPICmdLongDesc,
+	Example: rollbackAPICmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + rollbackCmdLiteral + " " + rollbackAPICmdLiteral + " called")
+		cred, err := getCredentials(rollbackAPIEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		accessOAuthToken, err := credentials.GetOAuthAccessToken(cred, rollbackAPIEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while getting an access token for rolling back API", err)
+		}
+		snapshot, err := impl.RollbackAPIToEnv(accessOAuthToken, rollbackAPIEnvironment, rollbackAPIName,
+			rollbackAPIVersion, rollbackAPISnapshot)
+		if err != nil {
+			utils.HandleErrorAndExit("Error rolling back API", err)
+		}
+		fmt.Println(rollbackAPIName + " " + rollbackAPIVersion + " rolled back to snapshot " + snapshot.ID)
+	},
+}
+
+func init() {
+	RollbackCmd.AddCommand(RollbackAPICmd)
+	RollbackAPICmd.Flags().StringVarP(&rollbackAPIName, "name", "n", "",
+		"Name of the API to be rolled back")
+	RollbackAPICmd.Flags().StringVarP(&rollbackAPIVersion, "version", "v", "",
+		"Version of the API to be rolled back")
+	RollbackAPICmd.Flags().StringVarP(&rollbackAPIEnvironment, "environment", "e", "",
+		"Environment of the API to be rolled back")
+	RollbackAPICmd.Flags().StringVarP(&rollbackAPISnapshot, "to", "", "",
+		"Snapshot to roll back to (defaults to the latest snapshot)")
+	// Mark required flags
+	_ = RollbackAPICmd.MarkFlagRequired("name")
+	_ = RollbackAPICmd.MarkFlagRequired("version")
+	_ = RollbackAPICmd.MarkFlagRequired("environment")
+}
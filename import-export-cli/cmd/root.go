@@ -126,11 +126,23 @@ func createConfigFiles() {
 	utils.CreateDirIfNotExist(filepath.Join(utils.DefaultExportDirPath, utils.ExportedApiProductsDirName))
 	utils.CreateDirIfNotExist(filepath.Join(utils.DefaultExportDirPath, utils.ExportedAppsDirName))
 	utils.CreateDirIfNotExist(filepath.Join(utils.DefaultExportDirPath, utils.ExportedMigrationArtifactsDirName))
+	utils.CreateDirIfNotExist(filepath.Join(utils.DefaultExportDirPath, utils.ExportedBackupsDirName))
+	utils.CreateDirIfNotExist(utils.DefaultSnapshotsDirPath)
+	utils.CreateDirIfNotExist(utils.DefaultTemplatesDirPath)
 
 	if !utils.IsFileExist(utils.MainConfigFilePath) {
 		var mainConfig = new(utils.MainConfig)
-		mainConfig.Config = utils.Config{utils.DefaultHttpRequestTimeout,
-			utils.DefaultExportDirPath, k8sUtils.DefaultKubernetesMode, utils.DefaultTokenType}
+		mainConfig.Config = utils.Config{
+			HttpRequestTimeout:    utils.DefaultHttpRequestTimeout,
+			ExportDirectory:       utils.DefaultExportDirPath,
+			KubernetesMode:        k8sUtils.DefaultKubernetesMode,
+			TokenType:             utils.DefaultTokenType,
+			SnapshotRetention:     utils.DefaultSnapshotRetention,
+			HttpRetryCount:        utils.DefaultHttpRetryCount,
+			HttpRetryWaitTime:     utils.DefaultHttpRetryWaitTime,
+			HttpRetryMaxWaitTime:  utils.DefaultHttpRetryMaxWaitTime,
+			CertExpiryWarningDays: utils.DefaultCertExpiryWarningDays,
+		}
 		utils.WriteConfigFile(mainConfig, utils.MainConfigFilePath)
 	}
 
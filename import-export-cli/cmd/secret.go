@@ -0,0 +1,79 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var secretCmdKeyFile string
+
+// Secret command related usage Info
+const secretCmdLiteral = "secret"
+const secretCmdShortDesc = "Encrypt and decrypt secret values"
+
+const secretCmdLongDesc = `Encrypt secret values to be used in ` + utils.ParamFileAPI + ` and project files, and ` +
+	`decrypt them. Encrypted values (` + utils.SecretPrefix + `...) are decrypted while importing, using the key in ` +
+	`the file given by --key-file or the environment variables ` + utils.SecretKeyEnvName + ` or ` +
+	utils.SecretKeyFileEnvName + `. A key can be generated with "openssl rand -base64 32"`
+
+const secretCmdExamples = utils.ProjectName + ` ` + secretCmdLiteral + ` ` + secretEncryptCmdLiteral + ` --key-file ~/.apictl.key admin123
+` + utils.ProjectName + ` ` + secretCmdLiteral + ` ` + secretDecryptCmdLiteral + ` enc:v1:9Zt2...`
+
+// SecretCmd represents the secret command
+var SecretCmd = &cobra.Command{
+	Use:     secretCmdLiteral,
+	Short:   secretCmdShortDesc,
+	Long:    secretCmdLongDesc,
+	Example: secretCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + secretCmdLiteral + " called")
+
+	},
+}
+
+// readSecretInput returns the first argument, or the standard input if no arguments are given
+func readSecretInput(args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	content, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		return "", err
+	}
+	value := strings.TrimRight(string(content), "\r\n")
+	if value == "" {
+		return "", errors.New("no value given in arguments or standard input")
+	}
+	return value, nil
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(SecretCmd)
+	SecretCmd.PersistentFlags().StringVarP(&secretCmdKeyFile, "key-file", "", "",
+		"File containing the base64 encoded secret key, defaults to "+utils.SecretKeyEnvName+" or "+
+			utils.SecretKeyFileEnvName)
+}
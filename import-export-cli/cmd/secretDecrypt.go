@@ -0,0 +1,64 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// secretDecryptCmd related info
+const secretDecryptCmdLiteral = "decrypt"
+const secretDecryptCmdShortDesc = "Decrypt a secret value"
+
+const secretDecryptCmdLongDesc = `Decrypt a value (` + utils.SecretPrefix + `...) given as an argument or in the standard input`
+
+const secretDecryptCmdExamples = utils.ProjectName + ` ` + secretCmdLiteral + ` ` + secretDecryptCmdLiteral + ` --key-file ~/.apictl.key enc:v1:9Zt2...`
+
+// secretDecryptCmd represents the secret decrypt command
+var secretDecryptCmd = &cobra.Command{
+	Use:     secretDecryptCmdLiteral + " [value]",
+	Short:   secretDecryptCmdShortDesc,
+	Long:    secretDecryptCmdLongDesc,
+	Example: secretDecryptCmdExamples,
+	Args:    cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + secretDecryptCmdLiteral + " called")
+		value, err := readSecretInput(args)
+		if err != nil {
+			utils.HandleErrorAndExit("Error decrypting value", err)
+		}
+		key, err := utils.LoadSecretKey(secretCmdKeyFile)
+		if err != nil {
+			utils.HandleErrorAndExit("Error loading secret key", err)
+		}
+		result, err := utils.DecryptSecret(key, strings.TrimSpace(value))
+		if err != nil {
+			utils.HandleErrorAndExit("Error decrypting value", err)
+		}
+		fmt.Println(result)
+	},
+}
+
+func init() {
+	SecretCmd.AddCommand(secretDecryptCmd)
+}
@@ -0,0 +1,64 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// secretEncryptCmd related info
+const secretEncryptCmdLiteral = "encrypt"
+const secretEncryptCmdShortDesc = "Encrypt a secret value"
+
+const secretEncryptCmdLongDesc = `Encrypt a value given as an argument or in the standard input. The encrypted value can be used in place of the value in ` + utils.ParamFileAPI + ` and project files`
+
+const secretEncryptCmdExamples = utils.ProjectName + ` ` + secretCmdLiteral + ` ` + secretEncryptCmdLiteral + ` --key-file ~/.apictl.key admin123
+` + utils.ProjectName + ` ` + secretCmdLiteral + ` ` + secretEncryptCmdLiteral + ` < password.txt`
+
+// secretEncryptCmd represents the secret encrypt command
+var secretEncryptCmd = &cobra.Command{
+	Use:     secretEncryptCmdLiteral + " [value]",
+	Short:   secretEncryptCmdShortDesc,
+	Long:    secretEncryptCmdLongDesc,
+	Example: secretEncryptCmdExamples,
+	Args:    cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + secretEncryptCmdLiteral + " called")
+		value, err := readSecretInput(args)
+		if err != nil {
+			utils.HandleErrorAndExit("Error encrypting value", err)
+		}
+		key, err := utils.LoadSecretKey(secretCmdKeyFile)
+		if err != nil {
+			utils.HandleErrorAndExit("Error loading secret key", err)
+		}
+		result, err := utils.EncryptSecret(key, value)
+		if err != nil {
+			utils.HandleErrorAndExit("Error encrypting value", err)
+		}
+		fmt.Println(result)
+	},
+}
+
+func init() {
+	SecretCmd.AddCommand(secretEncryptCmd)
+}
@@ -0,0 +1,158 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	snapshotIdHeader      = "ID"
+	snapshotNameHeader    = "NAME"
+	snapshotVersionHeader = "VERSION"
+	snapshotCreatedHeader = "CREATED"
+
+	defaultSnapshotTableFormat = "table {{.Id}}\t{{.Name}}\t{{.Version}}\t{{.Created}}"
+)
+
+var listSnapshotsCmdEnvironment string
+var listSnapshotsCmdAPIName string
+var listSnapshotsCmdAPIVersion string
+var listSnapshotsCmdFormat string
+
+// snapshotsCmd related info
+const snapshotsCmdLiteral = "snapshots"
+const snapshotsCmdShortDesc = "Display a list of API snapshots of an environment"
+
+const snapshotsCmdLongDesc = `Display a list of API snapshots taken before APIs were overwritten in the environment specified by the flag --environment, -e`
+
+const snapshotsCmdExamples = utils.ProjectName + ` ` + listCmdLiteral + ` ` + snapshotsCmdLiteral + ` -e dev
+` + utils.ProjectName + ` ` + listCmdLiteral + ` ` + snapshotsCmdLiteral + ` -e dev -n TwitterAPI -v 1.0.0
+NOTE: The flag (--environment (-e)) is mandatory`
+
+// snapshotsCmd represents the snapshots command
+var snapshotsCmd = &cobra.Command{
+	Use:     snapshotsCmdLiteral,
+	Short:   snapshotsCmdShortDesc,
+	Long:    snapshotsCmdLongDesc,
+	Example: snapshotsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + snapshotsCmdLiteral + " called")
+		snapshots, err := impl.ListAPISnapshots(listSnapshotsCmdEnvironment, listSnapshotsCmdAPIName,
+			listSnapshotsCmdAPIVersion)
+		if err != nil {
+			utils.HandleErrorAndExit("Error listing snapshots", err)
+		}
+		printSnapshots(snapshots, listSnapshotsCmdFormat)
+	},
+}
+
+// snapshot holds information about an API snapshot for outputting
+type snapshot struct {
+	id      string
+	name    string
+	version string
+	created time.Time
+}
+
+// creates a new snapshot from impl.APISnapshot
+func newSnapshotDefinitionFromAPISnapshot(s impl.APISnapshot) *snapshot {
+	return &snapshot{s.ID, s.Name, s.Version, s.Created}
+}
+
+// Id of snapshot
+func (s snapshot) Id() string {
+	return s.id
+}
+
+// Name of the API
+func (s snapshot) Name() string {
+	return s.name
+}
+
+// Version of the API
+func (s snapshot) Version() string {
+	return s.version
+}
+
+// Created time of snapshot
+func (s snapshot) Created() string {
+	return s.created.Local().Format(time.RFC1123)
+}
+
+// MarshalJSON marshals snapshot using custom marshaller which uses methods instead of fields
+func (s *snapshot) MarshalJSON() ([]byte, error) {
+	return formatter.MarshalJSON(s)
+}
+
+// printSnapshots
+func printSnapshots(snapshots []impl.APISnapshot, format string) {
+	if format == "" {
+		format = defaultSnapshotTableFormat
+	}
+	// create snapshot context with standard output
+	snapshotContext := formatter.NewContext(os.Stdout, format)
+
+	// create a new renderer function which iterate collection
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, s := range snapshots {
+			if err := t.Execute(w, newSnapshotDefinitionFromAPISnapshot(s)); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+
+	// headers for table
+	snapshotTableHeaders := map[string]string{
+		"Id":      snapshotIdHeader,
+		"Name":    snapshotNameHeader,
+		"Version": snapshotVersionHeader,
+		"Created": snapshotCreatedHeader,
+	}
+
+	// execute context
+	if err := snapshotContext.Write(renderer, snapshotTableHeaders); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
+
+func init() {
+	ListCmd.AddCommand(snapshotsCmd)
+
+	snapshotsCmd.Flags().StringVarP(&listSnapshotsCmdEnvironment, "environment", "e",
+		"", "Environment of the snapshots")
+	snapshotsCmd.Flags().StringVarP(&listSnapshotsCmdAPIName, "name", "n",
+		"", "Name of the API")
+	snapshotsCmd.Flags().StringVarP(&listSnapshotsCmdAPIVersion, "version", "v",
+		"", "Version of the API")
+	snapshotsCmd.Flags().StringVarP(&listSnapshotsCmdFormat, "format", "", "", "Pretty-print snapshots "+
+		"using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
+	_ = snapshotsCmd.MarkFlagRequired("environment")
+}
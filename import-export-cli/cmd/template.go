@@ -0,0 +1,50 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Template command related usage Info
+const templateCmdLiteral = "template"
+const templateCmdShortDesc = "Work with API project templates"
+
+const templateCmdLongDesc = `Work with the API project templates used by init, which are registered as ` +
+	`directories in the templates directory of the config dir`
+
+const templateCmdExamples = utils.ProjectName + ` ` + templateCmdLiteral + ` ` + templateListCmdLiteral
+
+// TemplateCmd represents the template command
+var TemplateCmd = &cobra.Command{
+	Use:     templateCmdLiteral,
+	Short:   templateCmdShortDesc,
+	Long:    templateCmdLongDesc,
+	Example: templateCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + templateCmdLiteral + " called")
+
+	},
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(TemplateCmd)
+}
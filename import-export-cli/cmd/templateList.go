@@ -0,0 +1,138 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	templateNameHeader        = "NAME"
+	templateDescriptionHeader = "DESCRIPTION"
+	templateVariablesHeader   = "VARIABLES"
+
+	defaultTemplateTableFormat = "table {{.Name}}\t{{.Description}}\t{{.Variables}}"
+)
+
+var templateListCmdFormat string
+
+// templateListCmd related info
+const templateListCmdLiteral = "list"
+const templateListCmdShortDesc = "List API project templates"
+
+const templateListCmdLongDesc = `List the API project templates registered in the templates directory of the ` +
+	`config dir (` + utils.DefaultTemplatesDirName + `), along with the variables declared in their ` +
+	utils.TemplateManifestFileName + ` files`
+
+const templateListCmdExamples = utils.ProjectName + ` ` + templateCmdLiteral + ` ` + templateListCmdLiteral + `
+` + utils.ProjectName + ` ` + templateCmdLiteral + ` ` + templateListCmdLiteral + ` --format "{{ jsonPretty . }}"`
+
+// templateListCmd represents the template list command
+var templateListCmd = &cobra.Command{
+	Use:     templateListCmdLiteral,
+	Short:   templateListCmdShortDesc,
+	Long:    templateListCmdLongDesc,
+	Example: templateListCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + templateCmdLiteral + " " + templateListCmdLiteral + " called")
+		templates, err := impl.ListInitTemplates(utils.DefaultTemplatesDirPath)
+		if err != nil {
+			utils.HandleErrorAndExit("Error listing templates", err)
+		}
+		printTemplates(templates, templateListCmdFormat)
+	},
+}
+
+// initTemplate holds information about a project template for outputting
+type initTemplate struct {
+	template *impl.InitTemplate
+}
+
+// Name of the template
+func (t initTemplate) Name() string {
+	return t.template.Name
+}
+
+// Description of the template
+func (t initTemplate) Description() string {
+	return t.template.Description
+}
+
+// Variables declared by the template
+func (t initTemplate) Variables() string {
+	names := make([]string, 0, len(t.template.Variables))
+	for _, variable := range t.template.Variables {
+		names = append(names, variable.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+// Path of the template directory
+func (t initTemplate) Path() string {
+	return t.template.Path
+}
+
+// MarshalJSON marshals initTemplate using custom marshaller which uses methods instead of fields
+func (t *initTemplate) MarshalJSON() ([]byte, error) {
+	return formatter.MarshalJSON(t)
+}
+
+// printTemplates prints the templates using format
+func printTemplates(templates []*impl.InitTemplate, format string) {
+	if format == "" {
+		format = defaultTemplateTableFormat
+	}
+	templateContext := formatter.NewContext(os.Stdout, format)
+
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, initTmpl := range templates {
+			if err := t.Execute(w, &initTemplate{initTmpl}); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+
+	templateTableHeaders := map[string]string{
+		"Name":        templateNameHeader,
+		"Description": templateDescriptionHeader,
+		"Variables":   templateVariablesHeader,
+	}
+
+	if err := templateContext.Write(renderer, templateTableHeaders); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
+
+func init() {
+	TemplateCmd.AddCommand(templateListCmd)
+
+	templateListCmd.Flags().StringVarP(&templateListCmdFormat, "format", "", "", "Pretty-print templates "+
+		"using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
+}
@@ -0,0 +1,96 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var validateCmdProject string
+var validateCmdFormat string
+
+// validateCmd related info
+const validateCmdLiteral = "validate"
+const validateCmdShortDesc = "Validate an API project"
+
+const validateCmdLongDesc = `Validate an API project without connecting to API Manager. Resources of the API are ` +
+	`checked against the swagger, scopes of the resources against the scopes of the API and sequences and documents ` +
+	`against the files of the project. Contexts which clash with paths reserved by API Manager, invalid lifecycle ` +
+	`states and malformed endpoint configurations are reported as well. Exits with status 1 if the project is invalid`
+
+const validateCmdExamples = utils.ProjectName + ` ` + validateCmdLiteral + ` -f ./PizzaShackAPI
+` + utils.ProjectName + ` ` + validateCmdLiteral + ` -f ./PizzaShackAPI --format json`
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:     validateCmdLiteral + " (--file <api-project>)",
+	Short:   validateCmdShortDesc,
+	Long:    validateCmdLongDesc,
+	Example: validateCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + validateCmdLiteral + " called")
+		if validateCmdFormat != "text" && validateCmdFormat != "json" {
+			utils.HandleErrorAndExit("Invalid format "+validateCmdFormat+", should be either text or json", nil)
+		}
+		result, err := impl.ValidateAPIProject(validateCmdProject)
+		if err != nil {
+			utils.HandleErrorAndExit("Error validating the API project", err)
+		}
+		printValidationResult(result)
+		if !result.Valid {
+			os.Exit(1)
+		}
+	},
+}
+
+// printValidationResult prints the issues of the result in the format given by the user
+func printValidationResult(result *impl.ProjectValidationResult) {
+	if validateCmdFormat == "json" {
+		if result.Issues == nil {
+			result.Issues = []impl.ProjectIssue{}
+		}
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			utils.HandleErrorAndExit("Error printing the validation result", err)
+		}
+		fmt.Println(string(out))
+		return
+	}
+	if result.Valid {
+		fmt.Println(result.Project + " is valid")
+		return
+	}
+	for _, issue := range result.Issues {
+		fmt.Printf("%s: [%s] %s\n", issue.File, issue.Check, issue.Message)
+	}
+	fmt.Printf("%d issue(s) found in %s\n", len(result.Issues), result.Project)
+}
+
+func init() {
+	RootCmd.AddCommand(validateCmd)
+	validateCmd.Flags().StringVarP(&validateCmdProject, "file", "f", "", "Path of the API project directory")
+	validateCmd.Flags().StringVarP(&validateCmdFormat, "format", "", "text", "Output format (text or json)")
+	_ = validateCmd.MarkFlagRequired("file")
+}
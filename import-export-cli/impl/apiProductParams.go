@@ -0,0 +1,92 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/Jeffail/gabs"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// injectParamsToAPIProduct merges the parameters of importEnvironment in api_product_params.yaml located in
+// paramsPath to the API Product in apiProductDirectory
+func injectParamsToAPIProduct(apiProductDirectory, paramsPath, importEnvironment string) error {
+	utils.Logln(utils.LogPrefixInfo+"Loading parameters from", paramsPath)
+	apiProductParams, err := params.LoadAPIProductParamsFromFile(paramsPath)
+	if err != nil {
+		return err
+	}
+	envParams := apiProductParams.GetEnv(importEnvironment)
+	if envParams == nil {
+		fmt.Println("Using default values as the environment is not present in " + utils.ParamFileAPIProduct +
+			" file")
+		return nil
+	}
+	return mergeAPIProduct(apiProductDirectory, envParams)
+}
+
+// mergeAPIProduct merges environmentParams to the API Product definition in Meta-information
+func mergeAPIProduct(apiProductDirectory string, environmentParams *params.APIProductEnvironment) error {
+	apiProductPath := filepath.Join(apiProductDirectory, "Meta-information", "api")
+	utils.Logln(utils.LogPrefixInfo + "Reading API Product definition: ")
+	fp, jsonContent, err := resolveYamlOrJson(apiProductPath)
+	if err != nil {
+		return err
+	}
+	utils.Logln(utils.LogPrefixInfo+"Loaded definition from:", fp)
+	apiProduct, err := gabs.ParseJSON(jsonContent)
+	if err != nil {
+		return err
+	}
+
+	utils.Logln(utils.LogPrefixInfo + "Merging API Product")
+	// replace original GatewayEnvironments only if they are present in the params file
+	if environmentParams.GatewayEnvironments != nil {
+		if _, err := apiProduct.SetP(environmentParams.GatewayEnvironments, "environments"); err != nil {
+			return err
+		}
+	}
+
+	// API Products share tiers, visibility and metadata with APIs
+	metadata := &params.Environment{
+		Visibility:           environmentParams.Visibility,
+		VisibleRoles:         environmentParams.VisibleRoles,
+		BusinessInformation:  environmentParams.BusinessInformation,
+		AdditionalProperties: environmentParams.AdditionalProperties,
+	}
+	if environmentParams.Policies != nil {
+		metadata.Policies = &params.Policies{Subscription: environmentParams.Policies.Subscription}
+	}
+	err = mergeAPIMetadata(metadata, apiProduct)
+	if err != nil {
+		return err
+	}
+
+	apiProductPath = filepath.Join(apiProductDirectory, "Meta-information", "api.yaml")
+	utils.Logln(utils.LogPrefixInfo+"Writing merged API Product to:", apiProductPath)
+	content, err := utils.JsonToYaml(apiProduct.Bytes())
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(apiProductPath, content, 0644)
+}
@@ -0,0 +1,83 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// createAPIProductTestProject copies the definition of the test API Product to a temporary project
+func createAPIProductTestProject(t *testing.T) string {
+	projectDir, err := ioutil.TempDir("", "product")
+	assert.Nil(t, err)
+	metaDir := filepath.Join(projectDir, "Meta-information")
+	assert.Nil(t, os.MkdirAll(metaDir, os.ModePerm))
+	content, err := ioutil.ReadFile(filepath.Join(utils.GetRelativeTestDataPathFromImpl(), "MyProduct-1.0.0",
+		"Meta-information", "api.yaml"))
+	assert.Nil(t, err)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(metaDir, "api.yaml"), content, 0644))
+	return projectDir
+}
+
+func TestInjectParamsToAPIProduct(t *testing.T) {
+	projectDir := createAPIProductTestProject(t)
+	defer os.RemoveAll(projectDir)
+	_ = os.Setenv("PRODUCT_GATEWAY", "Edge")
+
+	err := injectParamsToAPIProduct(projectDir, "../specs/params/testdata/api_product_params.yml", "prod")
+	assert.Nil(t, err, "Error should be nil")
+	apiProduct := readMergedAPI(t, projectDir)
+
+	assert.Equal(t, []interface{}{"Production and Sandbox", "Edge"}, apiProduct.S("environments").Data())
+	tiers, _ := apiProduct.S("availableTiers").Children()
+	assert.Equal(t, 2, len(tiers))
+	assert.Equal(t, "Gold", tiers[0].S("name").Data())
+	assert.NotNil(t, tiers[0].S("displayName").Data(), "Details of existing tiers should be kept")
+	assert.Equal(t, "Unlimited", tiers[1].S("name").Data())
+	assert.Equal(t, "restricted", apiProduct.S("visibility").Data())
+	assert.Equal(t, "internal/subscriber", apiProduct.S("visibleRoles").Data())
+	assert.Equal(t, "Jane Roe", apiProduct.S("businessOwner").Data())
+	assert.Equal(t, "eu-west", apiProduct.S("additionalProperties", "region").Data())
+}
+
+func TestInjectParamsToAPIProductWithMissingEnvironment(t *testing.T) {
+	projectDir := createAPIProductTestProject(t)
+	defer os.RemoveAll(projectDir)
+	before, err := ioutil.ReadFile(filepath.Join(projectDir, "Meta-information", "api.yaml"))
+	assert.Nil(t, err)
+
+	err = injectParamsToAPIProduct(projectDir, "../specs/params/testdata/api_product_params.yml", "dev")
+	assert.Nil(t, err, "Error should be nil")
+	after, err := ioutil.ReadFile(filepath.Join(projectDir, "Meta-information", "api.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, before, after, "API Product should not be changed")
+}
+
+func TestImportAPIProductWithMissingParamsFile(t *testing.T) {
+	name := filepath.Join(utils.GetRelativeTestDataPathFromImpl(), "MyProduct-1.0.0")
+	err := ImportAPIProduct("access_token", "https://localhost:9443", "test_env", name, "missing_params.yaml",
+		ImportAPIProductOptions{PreserveProvider: true})
+	assert.NotNil(t, err, "Should return an error when the params file does not exist")
+}
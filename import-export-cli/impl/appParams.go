@@ -0,0 +1,232 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// key types of the application keys in an exported application
+const (
+	appKeyTypeProduction = "PRODUCTION"
+	appKeyTypeSandbox    = "SANDBOX"
+)
+
+// injectParamsToApp applies the environment parameters in paramsPath to the application archive in zipFilePath.
+// The archive is extracted to workspace and the path of the updated archive is returned. The original archive is
+// returned when the environment is not present in the params file
+func injectParamsToApp(zipFilePath, paramsPath, environment, workspace string) (string, error) {
+	utils.Logln(utils.LogPrefixInfo+"Loading parameters from", paramsPath)
+	appParams, err := params.LoadAppParamsFromFile(paramsPath)
+	if err != nil {
+		return "", err
+	}
+	envParams := appParams.GetEnv(environment)
+	if envParams == nil {
+		fmt.Println("Using default values as the environment is not present in " + utils.ParamFileApp + " file")
+		return zipFilePath, nil
+	}
+
+	utils.Logln(utils.LogPrefixInfo+"Extracting", zipFilePath, "to", workspace)
+	appDirectory, err := extractArchive(zipFilePath, workspace)
+	if err != nil {
+		return "", err
+	}
+	if err = mergeApp(appDirectory, envParams); err != nil {
+		return "", err
+	}
+
+	appZipPath := filepath.Join(workspace, filepath.Base(zipFilePath))
+	utils.Logln(utils.LogPrefixInfo+"Creating Application artifact", appZipPath)
+	if err = utils.Zip(appDirectory, appZipPath); err != nil {
+		return "", err
+	}
+	return appZipPath, nil
+}
+
+// resolveAppDefinitionPath returns the application definition in appDirectory, which is named after the
+// directory. If it is not found, the only JSON or YAML file in appDirectory is returned
+func resolveAppDefinitionPath(appDirectory string) (string, error) {
+	for _, ext := range []string{".json", ".yaml", ".yml"} {
+		fp := filepath.Join(appDirectory, filepath.Base(appDirectory)+ext)
+		if utils.IsFileExist(fp) {
+			return fp, nil
+		}
+	}
+	files, err := ioutil.ReadDir(appDirectory)
+	if err != nil {
+		return "", err
+	}
+	var definitions []string
+	for _, file := range files {
+		ext := strings.ToLower(filepath.Ext(file.Name()))
+		if !file.IsDir() && (ext == ".json" || ext == ".yaml" || ext == ".yml") {
+			definitions = append(definitions, filepath.Join(appDirectory, file.Name()))
+		}
+	}
+	if len(definitions) != 1 {
+		return "", fmt.Errorf("could not find the Application definition in %s", appDirectory)
+	}
+	return definitions[0], nil
+}
+
+// mergeApp merges environmentParams to the application definition in appDirectory
+func mergeApp(appDirectory string, environmentParams *params.AppEnvironment) error {
+	appPath, err := resolveAppDefinitionPath(appDirectory)
+	if err != nil {
+		return err
+	}
+	utils.Logln(utils.LogPrefixInfo+"Loading Application definition from:", appPath)
+	content, err := ioutil.ReadFile(appPath)
+	if err != nil {
+		return err
+	}
+	isJSON := strings.ToLower(filepath.Ext(appPath)) == ".json"
+	if !isJSON {
+		if content, err = utils.YamlToJson(content); err != nil {
+			return err
+		}
+	}
+	app, err := gabs.ParseJSON(content)
+	if err != nil {
+		return err
+	}
+
+	utils.Logln(utils.LogPrefixInfo + "Merging Application")
+	if environmentParams.ThrottlingPolicy != "" {
+		if _, err := app.Set(environmentParams.ThrottlingPolicy, "tier"); err != nil {
+			return err
+		}
+	}
+	if environmentParams.TokenType != "" {
+		if _, err := app.Set(environmentParams.TokenType, "tokenType"); err != nil {
+			return err
+		}
+	}
+	for key, value := range environmentParams.Attributes {
+		if _, err := app.Set(value, "applicationAttributes", key); err != nil {
+			return err
+		}
+	}
+	if environmentParams.Keys != nil {
+		if err := mergeAppKeys(environmentParams.Keys.Production, appKeyTypeProduction, app); err != nil {
+			return err
+		}
+		if err := mergeAppKeys(environmentParams.Keys.Sandbox, appKeyTypeSandbox, app); err != nil {
+			return err
+		}
+	}
+	for _, subscription := range environmentParams.Subscriptions {
+		if err := remapSubscription(subscription, app); err != nil {
+			return err
+		}
+	}
+
+	content = app.BytesIndent("", "  ")
+	if !isJSON {
+		if content, err = utils.JsonToYaml(content); err != nil {
+			return err
+		}
+	}
+	utils.Logln(utils.LogPrefixInfo+"Writing merged Application to:", appPath)
+	return ioutil.WriteFile(appPath, content, 0644)
+}
+
+// mergeAppKeys sets the callback URL and grant types of the keys of keyType
+func mergeAppKeys(keyParams *params.AppKeyParams, keyType string, app *gabs.Container) error {
+	if keyParams == nil {
+		return nil
+	}
+	keys, _ := app.S("keys").Children()
+	found := false
+	for _, key := range keys {
+		if !strings.EqualFold(fmt.Sprint(key.S("keyType").Data()), keyType) {
+			continue
+		}
+		found = true
+		if keyParams.CallbackUrl != nil {
+			if _, err := key.Set(*keyParams.CallbackUrl, "callbackUrl"); err != nil {
+				return err
+			}
+		}
+		if keyParams.GrantTypes != nil {
+			// grant types are exported as a space separated string, keep lists as they are
+			var grantTypes interface{} = strings.Join(keyParams.GrantTypes, " ")
+			if _, isList := key.S("grantTypes").Data().([]interface{}); isList {
+				grantTypes = keyParams.GrantTypes
+			}
+			if _, err := key.Set(grantTypes, "grantTypes"); err != nil {
+				return err
+			}
+		}
+	}
+	if !found {
+		fmt.Println("Skipping " + strings.ToLower(keyType) + " keys in " + utils.ParamFileApp +
+			" as the Application does not have " + strings.ToLower(keyType) + " keys")
+	}
+	return nil
+}
+
+// remapSubscription changes the API version, provider or tier of the subscriptions matching subscription
+// @return error if the application is not subscribed to the API
+func remapSubscription(subscription params.SubscriptionParams, app *gabs.Container) error {
+	subscribedAPIs, _ := app.S("subscribedAPIs").Children()
+	found := false
+	for _, subscribedAPI := range subscribedAPIs {
+		apiID := subscribedAPI.S("apiId")
+		if apiID.S("apiName").Data() != subscription.Name ||
+			(subscription.Version != "" && apiID.S("version").Data() != subscription.Version) ||
+			(subscription.Provider != "" && apiID.S("providerName").Data() != subscription.Provider) {
+			continue
+		}
+		found = true
+		if subscription.TargetVersion != "" {
+			if _, err := subscribedAPI.Set(subscription.TargetVersion, "apiId", "version"); err != nil {
+				return err
+			}
+		}
+		if subscription.TargetProvider != "" {
+			if _, err := subscribedAPI.Set(subscription.TargetProvider, "apiId", "providerName"); err != nil {
+				return err
+			}
+		}
+		if subscription.ThrottlingPolicy != "" {
+			// the tier is exported as an object with the name of the tier
+			tierPath := []string{"tier"}
+			if _, isObject := subscribedAPI.S("tier").Data().(map[string]interface{}); isObject {
+				tierPath = append(tierPath, "name")
+			}
+			if _, err := subscribedAPI.Set(subscription.ThrottlingPolicy, tierPath...); err != nil {
+				return err
+			}
+		}
+	}
+	if !found {
+		return fmt.Errorf("subscription to %s in %s is not found in the Application",
+			strings.TrimSpace(subscription.Name+" "+subscription.Version), utils.ParamFileApp)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Jeffail/gabs"
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const testAppDefinition = `{
+  "name": "SampleApp",
+  "owner": "admin",
+  "tier": "Unlimited",
+  "tokenType": "OAUTH",
+  "applicationAttributes": {
+    "External Reference Id": ""
+  },
+  "keys": [
+    {
+      "keyType": "PRODUCTION",
+      "callbackUrl": "https://dev.example.com/callback",
+      "grantTypes": "refresh_token client_credentials"
+    },
+    {
+      "keyType": "SANDBOX",
+      "callbackUrl": "https://dev.example.com/callback",
+      "grantTypes": "client_credentials"
+    }
+  ],
+  "subscribedAPIs": [
+    {
+      "apiId": {"providerName": "admin", "apiName": "PizzaShackAPI", "version": "1.0.0"},
+      "tier": {"name": "Unlimited"}
+    },
+    {
+      "apiId": {"providerName": "admin", "apiName": "OrderAPI", "version": "1.0.0"},
+      "tier": {"name": "Unlimited"}
+    }
+  ]
+}`
+
+// createTestAppArchive creates an exported application archive and returns the path of it
+func createTestAppArchive(t *testing.T, dir string) string {
+	appDir := filepath.Join(dir, "SampleApp")
+	assert.Nil(t, os.MkdirAll(appDir, os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(appDir, "SampleApp.json"), []byte(testAppDefinition), 0644))
+	zipPath := filepath.Join(dir, "admin_SampleApp.zip")
+	assert.Nil(t, utils.Zip(appDir, zipPath))
+	return zipPath
+}
+
+func TestInjectParamsToApp(t *testing.T) {
+	dir, err := ioutil.TempDir("", "app")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	zipPath := createTestAppArchive(t, dir)
+	workspace := filepath.Join(dir, "workspace")
+	assert.Nil(t, os.Mkdir(workspace, os.ModePerm))
+
+	_ = os.Setenv("APP_CALLBACK_URL", "https://prod.example.com/callback")
+	importZipPath, err := injectParamsToApp(zipPath, "../specs/params/testdata/app_params.yml", "prod", workspace)
+	assert.Nil(t, err, "Error should be nil")
+	assert.NotEqual(t, zipPath, importZipPath, "Original archive should not be modified")
+
+	_, err = utils.Unzip(importZipPath, filepath.Join(dir, "imported"))
+	assert.Nil(t, err)
+	app, err := gabs.ParseJSONFile(filepath.Join(dir, "imported", "SampleApp", "SampleApp.json"))
+	assert.Nil(t, err)
+
+	assert.Equal(t, "50PerMin", app.S("tier").Data())
+	assert.Equal(t, "JWT", app.S("tokenType").Data())
+	assert.Equal(t, "prod-42", app.Path("applicationAttributes.External Reference Id").Data())
+
+	production := app.S("keys").Index(0)
+	assert.Equal(t, "https://prod.example.com/callback", production.S("callbackUrl").Data())
+	assert.Equal(t, "client_credentials password", production.S("grantTypes").Data())
+	sandbox := app.S("keys").Index(1)
+	assert.Equal(t, "https://dev.example.com/callback", sandbox.S("callbackUrl").Data(),
+		"Sandbox keys should not be changed")
+
+	pizzaShack := app.S("subscribedAPIs").Index(0)
+	assert.Equal(t, "2.0.0", pizzaShack.Path("apiId.version").Data())
+	assert.Equal(t, "Gold", pizzaShack.Path("tier.name").Data())
+	order := app.S("subscribedAPIs").Index(1)
+	assert.Equal(t, "1.0.0", order.Path("apiId.version").Data(), "Other subscriptions should not be changed")
+}
+
+func TestInjectParamsToExportedApp(t *testing.T) {
+	dir, err := ioutil.TempDir("", "app")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	// exported by apictl export-app, with keys of two key managers
+	zipPath := filepath.Join(dir, "admin_SampleApp.zip")
+	assert.Nil(t, utils.Zip(utils.GetRelativeTestDataPathFromImpl()+"admin_SampleApp", zipPath))
+	workspace := filepath.Join(dir, "workspace")
+	assert.Nil(t, os.Mkdir(workspace, os.ModePerm))
+
+	_ = os.Setenv("APP_CALLBACK_URL", "https://prod.example.com/callback")
+	importZipPath, err := injectParamsToApp(zipPath, "../specs/params/testdata/app_params.yml", "prod", workspace)
+	assert.Nil(t, err, "Error should be nil")
+
+	_, err = utils.Unzip(importZipPath, filepath.Join(dir, "imported"))
+	assert.Nil(t, err)
+	app, err := gabs.ParseJSONFile(filepath.Join(dir, "imported", "admin_SampleApp", "admin_SampleApp.json"))
+	assert.Nil(t, err)
+
+	keys, err := app.S("keys").Children()
+	assert.Nil(t, err)
+	if assert.Len(t, keys, 3) {
+		for _, i := range []int{0, 2} {
+			assert.Equal(t, "https://prod.example.com/callback", keys[i].S("callbackUrl").Data(),
+				"Production keys of each key manager should be changed")
+			assert.Equal(t, "client_credentials password", keys[i].S("grantTypes").Data())
+		}
+		assert.Equal(t, "Keycloak", keys[2].S("keyManager").Data())
+		assert.Nil(t, keys[1].S("callbackUrl").Data(), "Sandbox keys should not be changed")
+		assert.Equal(t, "refresh_token password client_credentials", keys[1].S("grantTypes").Data())
+		assert.Equal(t, "Lt0lS7zGyIoWsXJaTM4fNhoBbfka", keys[0].S("consumerKey").Data())
+	}
+
+	pizzaShack := app.S("subscribedAPIs").Index(0)
+	assert.Equal(t, "2.0.0", pizzaShack.Path("apiId.version").Data())
+	assert.Equal(t, "Gold", pizzaShack.Path("tier.name").Data())
+	assert.Equal(t, "UNBLOCKED", pizzaShack.S("subStatus").Data())
+}
+
+func TestInjectParamsToAppMissingEnvironment(t *testing.T) {
+	dir, err := ioutil.TempDir("", "app")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	zipPath := createTestAppArchive(t, dir)
+
+	importZipPath, err := injectParamsToApp(zipPath, "../specs/params/testdata/app_params.yml", "dev", dir)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, zipPath, importZipPath, "Original archive should be imported")
+}
+
+func TestInjectParamsToAppUnknownSubscription(t *testing.T) {
+	dir, err := ioutil.TempDir("", "app")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	zipPath := createTestAppArchive(t, dir)
+	paramsPath := filepath.Join(dir, utils.ParamFileApp)
+	assert.Nil(t, ioutil.WriteFile(paramsPath, []byte(`environments:
+  - name: prod
+    subscriptions:
+      - name: PaymentAPI
+        throttlingPolicy: Gold
+`), 0644))
+	workspace := filepath.Join(dir, "workspace")
+	assert.Nil(t, os.Mkdir(workspace, os.ModePerm))
+
+	_, err = injectParamsToApp(zipPath, paramsPath, "prod", workspace)
+	assert.NotNil(t, err, "Subscriptions which are not in the Application should return an error")
+}
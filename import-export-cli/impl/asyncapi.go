@@ -0,0 +1,56 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"bytes"
+	"fmt"
+	"unicode"
+
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// AsyncAPIFileName is the name of the AsyncAPI document inside Meta-information of the project
+const AsyncAPIFileName = "asyncapi.yaml"
+
+// PopulateAPIFromAsyncAPI fills def as a WS, WEBSUB or SSE API using the AsyncAPI 2.x document in asyncAPIPath, which
+// is either a file path or an URL.
+// Returns the document as YAML so it can be stored inside the project. Documents written in YAML are returned unchanged
+func PopulateAPIFromAsyncAPI(def *v2.APIDefinition, asyncAPIPath string) ([]byte, error) {
+	utils.Logln(utils.LogPrefixInfo + "Loading AsyncAPI document from " + asyncAPIPath)
+	content, err := readDocument(asyncAPIPath)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := v2.ParseAsyncAPI(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid AsyncAPI document %s: %v", asyncAPIPath, err)
+	}
+	err = v2.AsyncAPIPopulate(def, doc)
+	if err != nil {
+		return nil, err
+	}
+	utils.Logln(utils.LogPrefixInfo + "Detected " + def.Type + " API")
+
+	if !bytes.HasPrefix(bytes.TrimLeftFunc(content, unicode.IsSpace), []byte("{")) {
+		return content, nil
+	}
+	return utils.JsonToYaml(content)
+}
@@ -0,0 +1,58 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestPopulateAPIFromAsyncAPI(t *testing.T) {
+	specPath := "../specs/v2/testdata/asyncapi/chat_ws.yaml"
+	def := &v2.APIDefinition{Type: "HTTP", ProductionUrl: "http://localhost:8080"}
+	doc, err := PopulateAPIFromAsyncAPI(def, specPath)
+	assert.Nil(t, err)
+	original, _ := ioutil.ReadFile(specPath)
+	assert.Equal(t, original, doc, "YAML documents should be stored as they are")
+
+	assert.Equal(t, v2.APITypeWS, def.Type)
+	assert.Equal(t, "ChatRooms", def.ID.APIName)
+	assert.Empty(t, def.ProductionUrl)
+	assert.Contains(t, *def.EndpointConfig, `"endpoint_type":"ws"`)
+	assert.Len(t, def.URITemplates, 2)
+}
+
+func TestPopulateAPIFromAsyncAPIWithJSON(t *testing.T) {
+	def := &v2.APIDefinition{}
+	doc, err := PopulateAPIFromAsyncAPI(def, "../specs/v2/testdata/asyncapi/orders_websub.json")
+	assert.Nil(t, err)
+	assert.Equal(t, v2.APITypeWebSub, def.Type)
+
+	// the document is converted to YAML
+	jsonDoc, err := utils.YamlToJson(doc)
+	assert.Nil(t, err)
+	parsed, err := v2.ParseAsyncAPI(jsonDoc)
+	assert.Nil(t, err)
+	assert.Equal(t, "Orders", parsed.Info.Title)
+	assert.Contains(t, string(doc), "asyncapi: 2.1.0")
+}
@@ -0,0 +1,408 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// BackupBundleVersion is the version of the bundle layout written by Backup
+const BackupBundleVersion = "1"
+
+// backupManifestFileName is the name of the manifest inside the bundle
+const backupManifestFileName = "manifest.yaml"
+
+// BackupArtifact holds information about an artifact stored in a backup bundle
+type BackupArtifact struct {
+	Name     string `yaml:"name"`
+	Version  string `yaml:"version,omitempty"`
+	Provider string `yaml:"provider,omitempty"`
+	Owner    string `yaml:"owner,omitempty"`
+	File     string `yaml:"file"`
+	Checksum string `yaml:"checksum"`
+}
+
+// BackupArtifacts holds artifacts of a single type stored in a backup bundle
+type BackupArtifacts struct {
+	Count     int              `yaml:"count"`
+	Artifacts []BackupArtifact `yaml:"artifacts"`
+}
+
+// BackupManifest describes the content of a backup bundle
+type BackupManifest struct {
+	BundleVersion string          `yaml:"bundleVersion"`
+	CreatedTime   string          `yaml:"createdTime"`
+	Environment   string          `yaml:"environment"`
+	Tenant        string          `yaml:"tenant"`
+	WithKeys      bool            `yaml:"withKeys"`
+	APIs          BackupArtifacts `yaml:"apis"`
+	APIProducts   BackupArtifacts `yaml:"apiProducts"`
+	Applications  BackupArtifacts `yaml:"applications"`
+}
+
+// BackupEndpoints holds the REST API endpoints of an environment used when taking a backup
+type BackupEndpoints struct {
+	Admin           string
+	APIList         string
+	APIProductList  string
+	ApplicationList string
+}
+
+// getBackupEndpointsOfEnv returns the endpoints of environment used when taking a backup
+func getBackupEndpointsOfEnv(environment string) BackupEndpoints {
+	return BackupEndpoints{
+		Admin:           utils.GetAdminEndpointOfEnv(environment, utils.MainConfigFilePath),
+		APIList:         utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath),
+		APIProductList:  utils.GetApiProductListEndpointOfEnv(environment, utils.MainConfigFilePath),
+		ApplicationList: utils.GetAdminApplicationListEndpointOfEnv(environment, utils.MainConfigFilePath),
+	}
+}
+
+// withTenantDomain adds the tenantDomain query to listEndpoint so that artifacts of the tenant are listed.
+// listEndpoint is returned as it is when tenant is empty
+func withTenantDomain(listEndpoint, tenant string) string {
+	if tenant == "" {
+		return listEndpoint
+	}
+	return listEndpoint + "?tenantDomain=" + tenant
+}
+
+// listAllArtifacts pages through listEndpoint until all artifacts are read.
+// newPage is called for every page and should return the list response to decode into and a function returning
+// the total count and the number of items decoded
+func listAllArtifacts(accessToken, listEndpoint string, newPage func() (interface{}, func() (int32, int))) error {
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+
+	connector := "?"
+	if strings.Contains(listEndpoint, "?") {
+		connector = "&"
+	}
+	offset := 0
+	for {
+		url := listEndpoint + connector + "limit=" + strconv.Itoa(utils.MaxAPIsToExportOnce) + "&offset=" +
+			strconv.Itoa(offset)
+		utils.Logln(utils.LogPrefixInfo+"URL:", url)
+		resp, err := utils.InvokeGETRequest(url, headers)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode() != http.StatusOK {
+			return fmt.Errorf("error listing artifacts from %s: %s", listEndpoint, resp.Status())
+		}
+
+		page, counts := newPage()
+		err = json.Unmarshal(resp.Body(), page)
+		if err != nil {
+			return err
+		}
+		total, read := counts()
+		offset += read
+		if read == 0 || offset >= int(total) {
+			return nil
+		}
+	}
+}
+
+// exportBackupArtifact creates dir and writes an artifact to dir/file using export.
+// Returns the checksum of the file
+func exportBackupArtifact(dir, file string, export func(destination string) error) (string, error) {
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return "", err
+	}
+	err = export(filepath.Join(dir, file))
+	if err != nil {
+		return "", err
+	}
+	return fileChecksum(filepath.Join(dir, file))
+}
+
+// fileChecksum returns the hex encoded sha256 checksum of the file in path
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// BackupToEnv function is used with backup command
+func BackupToEnv(accessToken, environment, tenant, format string, withKeys bool) (string, *BackupManifest, error) {
+	backupDirectory := filepath.Join(utils.ExportDirectory, utils.ExportedBackupsDirName)
+	return Backup(accessToken, getBackupEndpointsOfEnv(environment), environment, tenant, format, backupDirectory,
+		withKeys)
+}
+
+// Backup exports all APIs, API Products and Applications of the environment into a single bundle.
+// The bundle is written to <backupDirectory>/<environment>/<tenant>
+// @return path to the bundle
+// @return manifest of the bundle
+// @return error
+func Backup(accessToken string, endpoints BackupEndpoints, environment, tenant, format, backupDirectory string,
+	withKeys bool) (string, *BackupManifest, error) {
+	created := time.Now().UTC()
+	manifest := &BackupManifest{
+		BundleVersion: BackupBundleVersion,
+		CreatedTime:   created.Format(time.RFC3339),
+		Environment:   environment,
+		Tenant:        tenant,
+		WithKeys:      withKeys,
+	}
+
+	tenantDirName := utils.GetMigrationExportTenantDirName(tenant)
+	tmpDir, err := ioutil.TempDir("", "apim")
+	if err != nil {
+		return "", nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+	bundleName := environment + "_" + tenantDirName + "_" + created.Format("20060102150405")
+	bundleDir := filepath.Join(tmpDir, bundleName)
+
+	// APIs
+	var apis []utils.API
+	err = listAllArtifacts(accessToken, withTenantDomain(endpoints.APIList, tenant), func() (interface{}, func() (int32, int)) {
+		page := &utils.APIListResponse{}
+		return page, func() (int32, int) {
+			apis = append(apis, page.List...)
+			return page.Count, len(page.List)
+		}
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	for _, api := range apis {
+		fmt.Println("Exporting API " + api.Name + " " + api.Version)
+		file := api.Name + "_" + api.Version + ".zip"
+		checksum, err := exportBackupArtifact(filepath.Join(bundleDir, utils.ExportedApisDirName), file,
+			func(destination string) error {
+				return ExportAPIToFile(api.Name, api.Version, api.Provider, format, endpoints.Admin, accessToken,
+					true, destination)
+			})
+		if err != nil {
+			return "", nil, fmt.Errorf("error exporting API %s %s: %v", api.Name, api.Version, err)
+		}
+		manifest.APIs.Artifacts = append(manifest.APIs.Artifacts, BackupArtifact{Name: api.Name,
+			Version: api.Version, Provider: api.Provider, File: utils.ExportedApisDirName + "/" + file,
+			Checksum: checksum})
+	}
+	manifest.APIs.Count = len(manifest.APIs.Artifacts)
+
+	// API Products
+	var apiProducts []utils.APIProduct
+	err = listAllArtifacts(accessToken, withTenantDomain(endpoints.APIProductList, tenant), func() (interface{}, func() (int32, int)) {
+		page := &utils.APIProductListResponse{}
+		return page, func() (int32, int) {
+			apiProducts = append(apiProducts, page.List...)
+			return page.Count, len(page.List)
+		}
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	for _, apiProduct := range apiProducts {
+		fmt.Println("Exporting API Product " + apiProduct.Name)
+		file := apiProduct.Name + "_" + utils.DefaultApiProductVersion + ".zip"
+		checksum, err := exportBackupArtifact(filepath.Join(bundleDir, utils.ExportedApiProductsDirName), file,
+			func(destination string) error {
+				return ExportAPIProductToFile(apiProduct.Name, utils.DefaultApiProductVersion, apiProduct.Provider,
+					format, endpoints.Admin, accessToken, destination)
+			})
+		if err != nil {
+			return "", nil, fmt.Errorf("error exporting API Product %s: %v", apiProduct.Name, err)
+		}
+		manifest.APIProducts.Artifacts = append(manifest.APIProducts.Artifacts, BackupArtifact{Name: apiProduct.Name,
+			Version: utils.DefaultApiProductVersion, Provider: apiProduct.Provider,
+			File: utils.ExportedApiProductsDirName + "/" + file, Checksum: checksum})
+	}
+	manifest.APIProducts.Count = len(manifest.APIProducts.Artifacts)
+
+	// Applications
+	var apps []utils.Application
+	err = listAllArtifacts(accessToken, withTenantDomain(endpoints.ApplicationList, tenant), func() (interface{}, func() (int32, int)) {
+		page := &utils.ApplicationListResponse{}
+		return page, func() (int32, int) {
+			apps = append(apps, page.List...)
+			return page.Count, len(page.List)
+		}
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	for _, app := range apps {
+		fmt.Println("Exporting Application " + app.Name + " of " + app.Owner)
+		// '/' in secondary user store owners is not a valid file name character
+		file := strings.ReplaceAll(app.Owner, "/", "#") + "_" + app.Name + ".zip"
+		checksum, err := exportBackupArtifact(filepath.Join(bundleDir, utils.ExportedAppsDirName), file,
+			func(destination string) error {
+				return ExportApplicationToFile(app.Name, app.Owner, endpoints.Admin, accessToken, withKeys,
+					destination)
+			})
+		if err != nil {
+			return "", nil, fmt.Errorf("error exporting Application %s of %s: %v", app.Name, app.Owner, err)
+		}
+		manifest.Applications.Artifacts = append(manifest.Applications.Artifacts, BackupArtifact{Name: app.Name,
+			Owner: app.Owner, File: utils.ExportedAppsDirName + "/" + file, Checksum: checksum})
+	}
+	manifest.Applications.Count = len(manifest.Applications.Artifacts)
+
+	err = os.MkdirAll(bundleDir, os.ModePerm)
+	if err != nil {
+		return "", nil, err
+	}
+	manifestContent, err := yaml.Marshal(manifest)
+	if err != nil {
+		return "", nil, err
+	}
+	err = ioutil.WriteFile(filepath.Join(bundleDir, backupManifestFileName), manifestContent, 0644)
+	if err != nil {
+		return "", nil, err
+	}
+
+	outputDir := filepath.Join(backupDirectory, environment, tenantDirName)
+	err = os.MkdirAll(outputDir, os.ModePerm)
+	if err != nil {
+		return "", nil, err
+	}
+	bundlePath := filepath.Join(outputDir, bundleName+".zip")
+	utils.Logln(utils.LogPrefixInfo+"Creating backup bundle", bundlePath)
+	err = utils.Zip(bundleDir, bundlePath)
+	if err != nil {
+		return "", nil, err
+	}
+	return bundlePath, manifest, nil
+}
+
+// readBackupManifest reads the manifest of the extracted bundle in bundleDir and verifies the artifacts against it
+func readBackupManifest(bundleDir string) (*BackupManifest, error) {
+	content, err := ioutil.ReadFile(filepath.Join(bundleDir, backupManifestFileName))
+	if err != nil {
+		return nil, err
+	}
+	manifest := &BackupManifest{}
+	err = yaml.Unmarshal(content, manifest)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.BundleVersion != BackupBundleVersion {
+		return nil, fmt.Errorf("unsupported backup bundle version %q", manifest.BundleVersion)
+	}
+
+	for _, artifacts := range []BackupArtifacts{manifest.APIs, manifest.APIProducts, manifest.Applications} {
+		if artifacts.Count != len(artifacts.Artifacts) {
+			return nil, errors.New("backup bundle is corrupted: artifact count mismatch")
+		}
+		for _, artifact := range artifacts.Artifacts {
+			checksum, err := fileChecksum(filepath.Join(bundleDir, filepath.FromSlash(artifact.File)))
+			if err != nil {
+				return nil, err
+			}
+			if checksum != artifact.Checksum {
+				return nil, fmt.Errorf("backup bundle is corrupted: checksum mismatch for %s", artifact.File)
+			}
+		}
+	}
+	return manifest, nil
+}
+
+// RestoreToEnv function is used with restore command
+func RestoreToEnv(accessToken, environment, bundlePath string, update, skipKeys bool) (*BackupManifest, error) {
+	adminEndpoint := utils.GetAdminEndpointOfEnv(environment, utils.MainConfigFilePath)
+	return Restore(accessToken, adminEndpoint, environment, bundlePath, update, skipKeys)
+}
+
+// Restore imports the artifacts of a backup bundle in dependency order: APIs, API Products and then Applications
+// with their subscriptions. All checksums are verified before anything is imported
+// @return manifest of the restored bundle
+// @return error
+func Restore(accessToken, adminEndpoint, environment, bundlePath string, update, skipKeys bool) (*BackupManifest,
+	error) {
+	tmpDir, err := ioutil.TempDir("", "apim")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	bundleDir, err := extractArchive(bundlePath, tmpDir)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := readBackupManifest(bundleDir)
+	if err != nil {
+		return nil, err
+	}
+	utils.Logln(utils.LogPrefixInfo+"Restoring backup of", manifest.Environment, "taken at", manifest.CreatedTime)
+
+	for _, api := range manifest.APIs.Artifacts {
+		fmt.Println("Importing API " + api.Name + " " + api.Version)
+		err = ImportAPI(accessToken, adminEndpoint, environment, filepath.Join(bundleDir, filepath.FromSlash(api.File)),
+			"", ImportAPIOptions{Update: update, PreserveProvider: true})
+		if err != nil {
+			return nil, fmt.Errorf("error importing API %s %s: %v", api.Name, api.Version, err)
+		}
+	}
+
+	for _, apiProduct := range manifest.APIProducts.Artifacts {
+		fmt.Println("Importing API Product " + apiProduct.Name)
+		// dependent APIs are already restored
+		err = ImportAPIProduct(accessToken, adminEndpoint, environment,
+			filepath.Join(bundleDir, filepath.FromSlash(apiProduct.File)), "",
+			ImportAPIProductOptions{Update: update, PreserveProvider: true})
+		if err != nil {
+			return nil, fmt.Errorf("error importing API Product %s: %v", apiProduct.Name, err)
+		}
+	}
+
+	for _, app := range manifest.Applications.Artifacts {
+		fmt.Println("Importing Application " + app.Name + " of " + app.Owner)
+		resp, err := ImportApplication(accessToken, adminEndpoint, environment, filepath.Join(bundleDir,
+			filepath.FromSlash(app.File)), app.Owner, "", update, true, false, skipKeys || !manifest.WithKeys)
+		if err == nil && resp == nil {
+			err = errors.New("no response received")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error importing Application %s of %s: %v", app.Name, app.Owner, err)
+		}
+		if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK &&
+			resp.StatusCode != http.StatusMultiStatus {
+			return nil, fmt.Errorf("error importing Application %s of %s: %s", app.Name, app.Owner, resp.Status)
+		}
+	}
+	return manifest, nil
+}
@@ -0,0 +1,144 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func getBackupTestServer(t *testing.T, apiArchive, appArchive string, imported map[string]int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/apis":
+			w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+			_, _ = w.Write([]byte(`{"count":1,"list":[{"id":"1","name":"PizzaShackAPI","version":"1.0.0",
+				"provider":"admin"}]}`))
+		case "/api-products":
+			w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+			_, _ = w.Write([]byte(`{"count":0,"list":[]}`))
+		case "/applications":
+			w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+			_, _ = w.Write([]byte(`{"count":1,"list":[{"applicationId":"1","name":"SampleApp","owner":"admin"}]}`))
+		case "/admin/export/api":
+			assert.Equal(t, "PizzaShackAPI", r.URL.Query().Get("name"))
+			http.ServeFile(w, r, apiArchive)
+		case "/admin/export/applications":
+			assert.Equal(t, "SampleApp", r.URL.Query().Get("appName"))
+			http.ServeFile(w, r, appArchive)
+		case "/admin/import/api", "/admin/import/applications":
+			if r.Method != http.MethodPost {
+				t.Errorf("Expected '%s', got '%s' instead\n", http.MethodPost, r.Method)
+			}
+			imported[r.URL.Path]++
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	workspace, err := ioutil.TempDir("", "backup")
+	assert.Nil(t, err)
+	defer os.RemoveAll(workspace)
+	apiArchive := filepath.Join(workspace, "PizzaShackAPI_1.0.0.zip")
+	assert.Nil(t, utils.Zip(utils.GetRelativeTestDataPathFromImpl()+"PizzaShackAPI-1.0.0", apiArchive))
+
+	imported := map[string]int{}
+	server := getBackupTestServer(t, apiArchive, utils.GetRelativeTestDataPathFromImpl()+"sampleApp.zip", imported)
+	defer server.Close()
+	endpoints := BackupEndpoints{
+		Admin:           server.URL + "/admin",
+		APIList:         server.URL + "/apis",
+		APIProductList:  server.URL + "/api-products",
+		ApplicationList: server.URL + "/applications",
+	}
+
+	bundlePath, manifest, err := Backup("access_token", endpoints, "dev", "", "", workspace, false)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, filepath.Join(workspace, "dev", utils.DefaultResourceTenantDomain), filepath.Dir(bundlePath))
+	assert.FileExists(t, bundlePath)
+	assert.Equal(t, BackupBundleVersion, manifest.BundleVersion)
+	assert.Equal(t, 1, manifest.APIs.Count)
+	assert.Equal(t, 0, manifest.APIProducts.Count)
+	assert.Equal(t, 1, manifest.Applications.Count)
+	assert.Equal(t, "apps/admin_SampleApp.zip", manifest.Applications.Artifacts[0].File)
+
+	restored, err := Restore("access_token", server.URL+"/admin", "dr", bundlePath, false, false)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, manifest.APIs.Artifacts, restored.APIs.Artifacts)
+	assert.Equal(t, 1, imported["/admin/import/api"], "API should be imported")
+	assert.Equal(t, 1, imported["/admin/import/applications"], "Application should be imported")
+}
+
+func TestBackupListsArtifactsOfTenant(t *testing.T) {
+	workspace, err := ioutil.TempDir("", "backup")
+	assert.Nil(t, err)
+	defer os.RemoveAll(workspace)
+
+	listed := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		listed[r.URL.Path] = r.URL.Query().Get("tenantDomain")
+		w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+		_, _ = w.Write([]byte(`{"count":0,"list":[]}`))
+	}))
+	defer server.Close()
+	endpoints := BackupEndpoints{
+		Admin:           server.URL + "/admin",
+		APIList:         server.URL + "/apis",
+		APIProductList:  server.URL + "/api-products",
+		ApplicationList: server.URL + "/applications",
+	}
+
+	_, _, err = Backup("access_token", endpoints, "dev", "wso2.com", "", workspace, false)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, map[string]string{"/apis": "wso2.com", "/api-products": "wso2.com", "/applications": "wso2.com"},
+		listed, "Artifacts of the tenant should be listed")
+}
+
+func TestReadBackupManifestChecksumMismatch(t *testing.T) {
+	bundleDir, err := ioutil.TempDir("", "backup")
+	assert.Nil(t, err)
+	defer os.RemoveAll(bundleDir)
+	assert.Nil(t, os.MkdirAll(filepath.Join(bundleDir, "apis"), os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(bundleDir, "apis", "API_1.0.0.zip"), []byte("zip"), 0644))
+	manifest := `bundleVersion: "1"
+apis:
+  count: 1
+  artifacts:
+  - name: API
+    version: 1.0.0
+    file: apis/API_1.0.0.zip
+    checksum: 0000
+`
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(bundleDir, "manifest.yaml"), []byte(manifest), 0644))
+
+	_, err = readBackupManifest(bundleDir)
+	assert.NotNil(t, err, "Checksum mismatch should return an error")
+	assert.Contains(t, err.Error(), "checksum mismatch")
+}
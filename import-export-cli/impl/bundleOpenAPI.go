@@ -0,0 +1,439 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// schemaKeywords are the keywords whose values are schemas
+var schemaKeywords = map[string]bool{"schema": true, "items": true, "additionalProperties": true, "not": true}
+
+// schemaListKeywords are the keywords whose values are maps or lists of schemas
+var schemaListKeywords = map[string]bool{"properties": true, "allOf": true, "anyOf": true, "oneOf": true}
+
+// openAPIBundler inlines external references of an OpenAPI document
+type openAPIBundler struct {
+	// root is the location of the document being bundled
+	root string
+	// oas3 is true if the document being bundled is an OpenAPI 3 document
+	oas3 bool
+	// documents are the loaded documents by location
+	documents map[string]interface{}
+	// inlined are the JSON pointers in the bundled document where each external reference was placed
+	inlined map[string]string
+	// components are the external references placed in the components of the bundled document by their section
+	components map[string]map[string]interface{}
+	// names are the component names which are already used
+	names map[string]bool
+}
+
+// BundleOpenAPI resolves the external $refs of the OpenAPI document content located in location, which is either a
+// file or an URL, and inlines them into a single document. Internal references are preserved.
+// Referred schemas, parameters, responses, request bodies, headers and examples are added to the components
+// (definitions, parameters and responses of Swagger 2.0) and referred with internal references, which also handles
+// circular references. Other external references are inlined where they are used.
+// Returns content unchanged when it does not have external references, otherwise the bundled document as YAML
+func BundleOpenAPI(content []byte, location string) ([]byte, error) {
+	doc, err := parseOpenAPIDocument(content)
+	if err != nil {
+		return nil, err
+	}
+	if !hasExternalRefs(doc) {
+		return content, nil
+	}
+	root, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s is not an OpenAPI definition", location)
+	}
+
+	if !isURL(location) {
+		location = filepath.Clean(location)
+	}
+	utils.Logln(utils.LogPrefixInfo + "Bundling external references of " + location)
+	_, oas3 := root["openapi"]
+	bundler := &openAPIBundler{root: location, oas3: oas3, documents: map[string]interface{}{location: doc},
+		inlined: make(map[string]string), components: make(map[string]map[string]interface{}),
+		names: make(map[string]bool)}
+	for _, section := range bundler.sections() {
+		for name := range bundler.existingComponents(root, section) {
+			bundler.names[section+"/"+name] = true
+		}
+	}
+	bundled, err := bundler.walk(doc, location, "")
+	if err != nil {
+		return nil, err
+	}
+	bundler.addComponents(bundled.(map[string]interface{}))
+	jsonContent, err := json.Marshal(bundled)
+	if err != nil {
+		return nil, err
+	}
+	return utils.JsonToYaml(jsonContent)
+}
+
+// parseOpenAPIDocument parses a YAML or JSON document
+func parseOpenAPIDocument(content []byte) (interface{}, error) {
+	jsonContent, err := utils.YamlToJson(content)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	err = json.Unmarshal(jsonContent, &doc)
+	return doc, err
+}
+
+// hasExternalRefs returns true if node has a $ref which does not refer the same document
+func hasExternalRefs(node interface{}) bool {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok && !strings.HasPrefix(ref, "#") {
+			return true
+		}
+		for _, child := range v {
+			if hasExternalRefs(child) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			if hasExternalRefs(child) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// walk returns a copy of node, found in the document in location, with external references inlined. pointer is the
+// JSON pointer of node in the bundled document
+func (b *openAPIBundler) walk(node interface{}, location, pointer string) (interface{}, error) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok {
+			return b.resolveRef(ref, location, pointer)
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		result := make(map[string]interface{}, len(v))
+		for _, key := range keys {
+			child, err := b.walk(v[key], location, pointer+"/"+escapeJSONPointer(key))
+			if err != nil {
+				return nil, err
+			}
+			result[key] = child
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for index, item := range v {
+			child, err := b.walk(item, location, pointer+"/"+strconv.Itoa(index))
+			if err != nil {
+				return nil, err
+			}
+			result[index] = child
+		}
+		return result, nil
+	default:
+		return v, nil
+	}
+}
+
+// resolveRef returns the value to be placed at pointer for the reference ref found in the document in location
+func (b *openAPIBundler) resolveRef(ref, location, pointer string) (interface{}, error) {
+	refLocation, fragment := ref, ""
+	if index := strings.Index(ref, "#"); index >= 0 {
+		refLocation, fragment = ref[:index], ref[index+1:]
+	}
+	if refLocation == "" {
+		refLocation = location
+	} else {
+		refLocation = resolveRefLocation(location, refLocation)
+	}
+	if refLocation == b.root {
+		// internal references of the document being bundled are kept as they are
+		return map[string]interface{}{"$ref": "#" + fragment}, nil
+	}
+
+	key := refLocation + "#" + fragment
+	if inlinedPointer, ok := b.inlined[key]; ok {
+		return map[string]interface{}{"$ref": "#" + inlinedPointer}, nil
+	}
+	doc, err := b.load(refLocation)
+	if err != nil {
+		return nil, err
+	}
+	value, err := resolveJSONPointer(doc, fragment)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve %s: %v", ref, err)
+	}
+
+	section := b.componentSection(pointer)
+	if section == "" {
+		// register before walking so references to the value from inside it become internal references
+		b.inlined[key] = pointer
+		return b.walk(value, refLocation, pointer)
+	}
+	name := b.componentName(section, refLocation, fragment)
+	componentPointer := "/" + section + "/" + escapeJSONPointer(name)
+	b.inlined[key] = componentPointer
+	component, err := b.walk(value, refLocation, componentPointer)
+	if err != nil {
+		return nil, err
+	}
+	if b.components[section] == nil {
+		b.components[section] = make(map[string]interface{})
+	}
+	b.components[section][name] = component
+	return map[string]interface{}{"$ref": "#" + componentPointer}, nil
+}
+
+// sections returns the component sections of the document
+func (b *openAPIBundler) sections() []string {
+	if b.oas3 {
+		return []string{"components/schemas", "components/parameters", "components/responses",
+			"components/requestBodies", "components/headers", "components/examples"}
+	}
+	return []string{"definitions", "parameters", "responses"}
+}
+
+// existingComponents returns the components in section of the document root
+func (b *openAPIBundler) existingComponents(root map[string]interface{}, section string) map[string]interface{} {
+	var node interface{} = root
+	for _, token := range strings.Split(section, "/") {
+		parent, ok := node.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		node = parent[token]
+	}
+	components, _ := node.(map[string]interface{})
+	return components
+}
+
+// componentSection returns the component section for a reference used at pointer, or an empty string if the
+// referred value should be inlined at pointer
+func (b *openAPIBundler) componentSection(pointer string) string {
+	tokens := strings.Split(pointer, "/")
+	for _, section := range b.sections() {
+		// references which are components themselves are inlined
+		if strings.HasPrefix(pointer, "/"+section+"/") && len(tokens) == strings.Count(section, "/")+3 {
+			return ""
+		}
+	}
+
+	last, parent := tokens[len(tokens)-1], ""
+	if len(tokens) > 1 {
+		parent = tokens[len(tokens)-2]
+	}
+	schemas, parameters, responses := "definitions", "parameters", "responses"
+	if b.oas3 {
+		schemas, parameters, responses = "components/schemas", "components/parameters", "components/responses"
+	}
+	switch {
+	case schemaKeywords[last] || schemaListKeywords[parent] || strings.HasPrefix(pointer, "/"+schemas+"/"):
+		return schemas
+	case parent == "parameters":
+		return parameters
+	case parent == "responses":
+		return responses
+	case b.oas3 && last == "requestBody":
+		return "components/requestBodies"
+	case b.oas3 && parent == "headers":
+		return "components/headers"
+	case b.oas3 && parent == "examples":
+		return "components/examples"
+	}
+	return ""
+}
+
+// componentName returns an unused name in section for the value referred by fragment of the document in location
+func (b *openAPIBundler) componentName(section, location, fragment string) string {
+	name := ""
+	if tokens := strings.Split(strings.TrimSuffix(fragment, "/"), "/"); fragment != "" && fragment != "/" {
+		name = strings.ReplaceAll(strings.ReplaceAll(tokens[len(tokens)-1], "~1", "/"), "~0", "~")
+	}
+	if name == "" {
+		name = strings.TrimSuffix(path.Base(filepath.ToSlash(location)), path.Ext(location))
+	}
+	unique := name
+	for index := 1; b.names[section+"/"+unique]; index++ {
+		unique = name + strconv.Itoa(index)
+	}
+	b.names[section+"/"+unique] = true
+	return unique
+}
+
+// addComponents adds the components placed while bundling to the bundled document root
+func (b *openAPIBundler) addComponents(root map[string]interface{}) {
+	for section, components := range b.components {
+		node := root
+		for _, token := range strings.Split(section, "/") {
+			child, ok := node[token].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				node[token] = child
+			}
+			node = child
+		}
+		for name, component := range components {
+			node[name] = component
+		}
+	}
+}
+
+// load returns the document in location, reading it when it is not loaded yet
+func (b *openAPIBundler) load(location string) (interface{}, error) {
+	if doc, ok := b.documents[location]; ok {
+		return doc, nil
+	}
+	utils.Logln(utils.LogPrefixInfo + "Resolving reference " + location)
+	content, err := readDocument(location)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parseOpenAPIDocument(content)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %v", location, err)
+	}
+	b.documents[location] = doc
+	return doc, nil
+}
+
+// isURL returns true if location is a http or https URL
+func isURL(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
+
+// readDocument reads the document in location, which is either a file path or an URL. It is used to read OpenAPI,
+// WSDL, GraphQL and AsyncAPI documents and the documents they reference
+func readDocument(location string) ([]byte, error) {
+	if isURL(location) {
+		utils.Logln(utils.LogPrefixInfo + "Downloading " + location)
+		return utils.ReadFromUrl(location)
+	}
+	return ioutil.ReadFile(location)
+}
+
+// resolveRefLocation resolves ref relative to the document in base
+func resolveRefLocation(base, ref string) string {
+	if isURL(ref) {
+		return ref
+	}
+	if isURL(base) {
+		baseURL, err := url.Parse(base)
+		if err != nil {
+			return ref
+		}
+		refURL, err := url.Parse(ref)
+		if err != nil {
+			return ref
+		}
+		return baseURL.ResolveReference(refURL).String()
+	}
+	p := filepath.FromSlash(ref)
+	if filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(filepath.Dir(base), p)
+}
+
+// escapeJSONPointer escapes a key to be used as a token of a JSON pointer
+func escapeJSONPointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// resolveJSONPointer returns the value of doc referred by the JSON pointer fragment
+func resolveJSONPointer(doc interface{}, fragment string) (interface{}, error) {
+	if unescaped, err := url.PathUnescape(fragment); err == nil {
+		fragment = unescaped
+	}
+	if fragment == "" || fragment == "/" {
+		return doc, nil
+	}
+	if !strings.HasPrefix(fragment, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %s", fragment)
+	}
+	value := doc
+	for _, token := range strings.Split(fragment[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch v := value.(type) {
+		case map[string]interface{}:
+			child, ok := v[token]
+			if !ok {
+				return nil, fmt.Errorf("%s not found", token)
+			}
+			value = child
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, fmt.Errorf("invalid index %s", token)
+			}
+			value = v[index]
+		default:
+			return nil, fmt.Errorf("%s not found", token)
+		}
+	}
+	return value, nil
+}
+
+// bundleAPIDefinition inlines external references of the swagger definition of the API project in apiDirectory.
+// The project is not changed if it does not have a swagger definition
+func bundleAPIDefinition(apiDirectory string) error {
+	for _, name := range []string{"swagger.yaml", "swagger.json"} {
+		swaggerPath := filepath.Join(apiDirectory, "Meta-information", name)
+		content, err := ioutil.ReadFile(swaggerPath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		bundled, err := BundleOpenAPI(content, swaggerPath)
+		if err != nil {
+			return err
+		}
+		if string(bundled) == string(content) {
+			return nil
+		}
+		if filepath.Ext(name) == ".json" {
+			bundled, err = utils.YamlToJson(bundled)
+			if err != nil {
+				return err
+			}
+		}
+		utils.Logln(utils.LogPrefixInfo+"Writing bundled definition to", swaggerPath)
+		return ioutil.WriteFile(swaggerPath, bundled, 0644)
+	}
+	return nil
+}
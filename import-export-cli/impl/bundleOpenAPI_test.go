@@ -0,0 +1,212 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Jeffail/gabs"
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const bundleTestRoot = `openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              schema:
+                $ref: './schemas/pet.yaml'
+  /pets/{id}:
+    get:
+      responses:
+        '200':
+          description: pet
+          content:
+            application/json:
+              schema:
+                $ref: 'schemas/pet.yaml'
+        default:
+          $ref: '#/components/responses/Error'
+components:
+  responses:
+    Error:
+      description: error
+`
+
+const bundleTestPet = `type: object
+properties:
+  name:
+    type: string
+  category:
+    $ref: 'category.yaml#/Category'
+  children:
+    type: array
+    items:
+      $ref: '#'
+`
+
+const bundleTestCategory = `Category:
+  type: object
+  properties:
+    parent:
+      $ref: '#/Category'
+`
+
+// writeBundleTestFiles writes files by their slash separated path relative to dir
+func writeBundleTestFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		assert.Nil(t, os.MkdirAll(filepath.Dir(p), os.ModePerm))
+		assert.Nil(t, ioutil.WriteFile(p, []byte(content), 0644))
+	}
+}
+
+// parseBundled parses a bundled YAML document
+func parseBundled(t *testing.T, content []byte) *gabs.Container {
+	jsonContent, err := utils.YamlToJson(content)
+	assert.Nil(t, err)
+	doc, err := gabs.ParseJSON(jsonContent)
+	assert.Nil(t, err)
+	return doc
+}
+
+func TestBundleOpenAPI(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bundle")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	writeBundleTestFiles(t, dir, map[string]string{"swagger.yaml": bundleTestRoot,
+		"schemas/pet.yaml": bundleTestPet, "schemas/category.yaml": bundleTestCategory})
+
+	bundled, err := BundleOpenAPI([]byte(bundleTestRoot), filepath.Join(dir, "swagger.yaml"))
+	assert.Nil(t, err, "Error should be nil")
+	doc := parseBundled(t, bundled)
+
+	schema := doc.Search("paths", "/pets", "get", "responses", "200", "content", "application/json", "schema")
+	assert.Equal(t, "#/components/schemas/pet", schema.Path("$ref").Data(),
+		"External schema should be added to the components")
+	other := doc.Search("paths", "/pets/{id}", "get", "responses")
+	assert.Equal(t, "#/components/schemas/pet", other.Search("200", "content", "application/json", "schema",
+		"$ref").Data(), "Repeated reference should refer the same component")
+	assert.Equal(t, "#/components/responses/Error", other.Search("default", "$ref").Data(),
+		"Internal reference should be preserved")
+	assert.Equal(t, "error", doc.Search("components", "responses", "Error", "description").Data())
+
+	pet := doc.Search("components", "schemas", "pet")
+	assert.Equal(t, "object", pet.Path("type").Data())
+	assert.Equal(t, "#/components/schemas/Category", pet.Search("properties", "category", "$ref").Data(),
+		"Nested reference should be added to the components")
+	assert.Equal(t, "#/components/schemas/pet", pet.Search("properties", "children", "items", "$ref").Data(),
+		"Reference to the root of an external document should refer its component")
+	assert.Equal(t, "#/components/schemas/Category", doc.Search("components", "schemas", "Category", "properties",
+		"parent", "$ref").Data(), "Circular reference should refer its component")
+}
+
+func TestBundleOpenAPISwagger2(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bundle")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	root := `swagger: '2.0'
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: 'common.yaml#/parameters/limit'
+      responses:
+        '200':
+          description: pets
+          schema:
+            $ref: 'common.yaml#/definitions/pet'
+definitions:
+  pet:
+    type: string
+`
+	common := "parameters:\n  limit:\n    name: limit\n    in: query\n    type: integer\n" +
+		"definitions:\n  pet:\n    type: object\n"
+	writeBundleTestFiles(t, dir, map[string]string{"swagger.yaml": root, "common.yaml": common})
+
+	bundled, err := BundleOpenAPI([]byte(root), filepath.Join(dir, "swagger.yaml"))
+	assert.Nil(t, err, "Error should be nil")
+	doc := parseBundled(t, bundled)
+	get := doc.Search("paths", "/pets", "get")
+	assert.Equal(t, "#/parameters/limit", get.Search("parameters").Index(0).Search("$ref").Data())
+	assert.Equal(t, "limit", doc.Search("parameters", "limit", "name").Data())
+	assert.Equal(t, "#/definitions/pet1", get.Search("responses", "200", "schema", "$ref").Data(),
+		"Component name should not clash with existing definitions")
+	assert.Equal(t, "object", doc.Search("definitions", "pet1", "type").Data())
+	assert.Equal(t, "string", doc.Search("definitions", "pet", "type").Data())
+}
+
+func TestBundleOpenAPIWithoutExternalRefs(t *testing.T) {
+	content := []byte("openapi: 3.0.0\npaths: {}\ncomponents:\n  schemas:\n    Pet:\n" +
+		"      $ref: '#/components/schemas/Dog'\n")
+	bundled, err := BundleOpenAPI(content, "swagger.yaml")
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, content, bundled, "Document without external references should not change")
+}
+
+func TestBundleOpenAPIFromURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/specs/pet.yaml":
+			_, _ = w.Write([]byte("type: object\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	content := []byte("openapi: 3.0.0\ncomponents:\n  schemas:\n    Pet:\n      $ref: 'pet.yaml'\n" +
+		"    Missing:\n      $ref: '" + server.URL + "/missing.yaml'\n")
+	_, err := BundleOpenAPI(content, server.URL+"/specs/swagger.yaml")
+	assert.NotNil(t, err, "Missing reference should fail")
+
+	content = []byte("openapi: 3.0.0\ncomponents:\n  schemas:\n    Pet:\n      $ref: 'pet.yaml'\n")
+	bundled, err := BundleOpenAPI(content, server.URL+"/specs/swagger.yaml")
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, "object", parseBundled(t, bundled).Path("components.schemas.Pet.type").Data())
+}
+
+func TestBundleAPIDefinition(t *testing.T) {
+	projectDir := createParamsTestProject(t)
+	defer os.RemoveAll(projectDir)
+	writeBundleTestFiles(t, projectDir, map[string]string{"Meta-information/swagger.yaml": bundleTestRoot,
+		"Meta-information/schemas/pet.yaml":      bundleTestPet,
+		"Meta-information/schemas/category.yaml": bundleTestCategory})
+
+	assert.Nil(t, bundleAPIDefinition(projectDir), "Error should be nil")
+	content, err := ioutil.ReadFile(filepath.Join(projectDir, "Meta-information", "swagger.yaml"))
+	assert.Nil(t, err)
+	assert.NotContains(t, string(content), "pet.yaml", "External references should be inlined")
+}
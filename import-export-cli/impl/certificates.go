@@ -0,0 +1,243 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	// CertStatusValid is the status of a certificate which can be imported
+	CertStatusValid = "valid"
+	// CertStatusExpiring is the status of a certificate which expires within the warning window
+	CertStatusExpiring = "expiring"
+	// CertStatusInvalid is the status of a certificate which can not be imported
+	CertStatusInvalid = "invalid"
+)
+
+// CertificateInfo contains the details of an endpoint certificate of an API project
+type CertificateInfo struct {
+	// Alias of the certificate, generated when not given in the params file
+	Alias string `json:"alias"`
+	// Host the certificate is used for
+	Host string `json:"host"`
+	// Path of the certificate file
+	Path string `json:"path"`
+	// Subject of the leaf certificate
+	Subject string `json:"subject"`
+	// Issuer of the leaf certificate
+	Issuer string `json:"issuer"`
+	// NotAfter is the expiry time of the leaf certificate
+	NotAfter time.Time `json:"notAfter"`
+	// Status is one of valid, expiring or invalid
+	Status string `json:"status"`
+	// Message describes why the certificate is expiring or invalid
+	Message string `json:"message"`
+}
+
+// validateCertificate reads the certificate of cert in importPath and checks whether it can be imported. The alias
+// of cert is generated if it is empty and the leaf certificate is set as the content of cert.
+// It returns the details of the certificate, and an error if it is invalid
+func validateCertificate(importPath string, cert *params.Cert, now time.Time) (*CertificateInfo, error) {
+	info := &CertificateInfo{Alias: cert.Alias, Host: cert.Host, Path: cert.Path, Status: CertStatusInvalid}
+	fail := func(err error) (*CertificateInfo, error) {
+		info.Message = err.Error()
+		return info, err
+	}
+
+	p, err := resolveCertPath(importPath, cert.Path)
+	if err != nil {
+		return fail(err)
+	}
+	pemData, err := ioutil.ReadFile(p)
+	if err != nil {
+		return fail(err)
+	}
+	certificates, err := parseCertificates(pemData)
+	if err != nil {
+		return fail(err)
+	}
+	leaf, err := findLeafCertificate(certificates)
+	if err != nil {
+		return fail(err)
+	}
+	info.Subject = leaf.Subject.String()
+	info.Issuer = leaf.Issuer.String()
+	info.NotAfter = leaf.NotAfter
+
+	if strings.TrimSpace(cert.Host) == "" {
+		return fail(errors.New("host of the certificate is empty"))
+	}
+	hostname := certHostname(cert.Host)
+	for _, certificate := range certificates {
+		if now.After(certificate.NotAfter) {
+			return fail(fmt.Errorf("certificate %s expired on %s", certificate.Subject,
+				certificate.NotAfter.Format(time.RFC3339)))
+		}
+		if now.Before(certificate.NotBefore) {
+			return fail(fmt.Errorf("certificate %s is not valid before %s", certificate.Subject,
+				certificate.NotBefore.Format(time.RFC3339)))
+		}
+	}
+	if !leaf.IsCA {
+		if err := verifyCertificateHost(leaf, hostname); err != nil {
+			return fail(err)
+		}
+	}
+
+	if cert.Alias == "" {
+		fingerprint := sha256.Sum256(leaf.Raw)
+		cert.Alias = hostname + "-" + hex.EncodeToString(fingerprint[:])[:8]
+		info.Alias = cert.Alias
+		utils.Logln(utils.LogPrefixInfo+"Generated alias", cert.Alias, "for", cert.Path)
+	}
+	cert.Certificate = credentials.Base64Encode(string(leaf.Raw))
+
+	info.Status = CertStatusValid
+	warningWindow := time.Duration(utils.CertExpiryWarningDays) * 24 * time.Hour
+	if leaf.NotAfter.Before(now.Add(warningWindow)) {
+		info.Status = CertStatusExpiring
+		info.Message = fmt.Sprintf("expires in %d day(s)", int(leaf.NotAfter.Sub(now).Hours()/24))
+	}
+	return info, nil
+}
+
+// parseCertificates parses the PEM encoded certificates in pemData. Private keys are rejected
+func parseCertificates(pemData []byte) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, pemData = pem.Decode(pemData)
+		if block == nil {
+			break
+		}
+		if strings.Contains(block.Type, "PRIVATE KEY") {
+			return nil, errors.New("file contains a private key, only certificates should be given")
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+	if len(certificates) == 0 {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+	return certificates, nil
+}
+
+// findLeafCertificate returns the certificate which is not a CA in a chain, or the certificate if there is only one
+func findLeafCertificate(certificates []*x509.Certificate) (*x509.Certificate, error) {
+	if len(certificates) == 1 {
+		return certificates[0], nil
+	}
+	for _, certificate := range certificates {
+		if !certificate.IsCA {
+			return certificate, nil
+		}
+	}
+	return nil, errors.New("certificate chain does not contain a leaf certificate")
+}
+
+// certHostname returns the hostname of host, which is either a URL or a host with an optional port
+func certHostname(host string) string {
+	host = strings.TrimSpace(host)
+	if strings.Contains(host, "://") {
+		if u, err := url.Parse(host); err == nil {
+			return u.Hostname()
+		}
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+	return host
+}
+
+// verifyCertificateHost checks hostname against the subject alternative names of certificate, or against the
+// common name when it does not have any
+func verifyCertificateHost(certificate *x509.Certificate, hostname string) error {
+	if len(certificate.DNSNames) > 0 || len(certificate.IPAddresses) > 0 {
+		if err := certificate.VerifyHostname(hostname); err != nil {
+			return fmt.Errorf("host %s does not match the subject alternative names of the certificate", hostname)
+		}
+		return nil
+	}
+	if !matchHostname(certificate.Subject.CommonName, hostname) {
+		return fmt.Errorf("host %s does not match the common name %s of the certificate", hostname,
+			certificate.Subject.CommonName)
+	}
+	return nil
+}
+
+// matchHostname returns true if hostname matches pattern, which may have a wildcard as the left most label
+func matchHostname(pattern, hostname string) bool {
+	pattern = strings.ToLower(strings.TrimSuffix(pattern, "."))
+	hostname = strings.ToLower(strings.TrimSuffix(hostname, "."))
+	if pattern == hostname {
+		return true
+	}
+	if !strings.HasPrefix(pattern, "*.") {
+		return false
+	}
+	index := strings.Index(hostname, ".")
+	return index > 0 && hostname[index:] == pattern[1:]
+}
+
+// InspectCertificates validates the endpoint certificates given in the params file of the API project in
+// importPath for the environment envName, without importing the API.
+// It returns the details of each certificate
+func InspectCertificates(importPath, paramsPath, envName string) ([]CertificateInfo, error) {
+	resolvedParamsPath, err := resolveParamsPath(importPath, paramsPath, utils.ParamFileAPI)
+	if err != nil {
+		return nil, err
+	}
+	apiParams, err := params.LoadApiParamsFromFile(resolvedParamsPath)
+	if err != nil {
+		return nil, err
+	}
+	envParams := apiParams.GetEnv(envName)
+	if envParams == nil {
+		return nil, fmt.Errorf("environment %s is not defined in %s", envName, resolvedParamsPath)
+	}
+
+	now := time.Now()
+	certificates := make([]CertificateInfo, 0, len(envParams.Certs))
+	for index := range envParams.Certs {
+		info, _ := validateCertificate(importPath, &envParams.Certs[index], now)
+		certificates = append(certificates, *info)
+	}
+	return certificates, nil
+}
@@ -0,0 +1,201 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// testCertificate is a generated certificate and its private key
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+}
+
+// generateTestCertificate generates a certificate signed by parent, or a self signed certificate if parent is nil
+func generateTestCertificate(t *testing.T, template *x509.Certificate, parent *testCertificate) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	if template.NotBefore.IsZero() {
+		template.NotBefore = time.Now().Add(-time.Hour)
+	}
+	if template.IsCA {
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+	}
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.certificate, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	assert.Nil(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	assert.Nil(t, err)
+	return &testCertificate{certificate: certificate, key: key}
+}
+
+// writeTestCertificates writes certificates as PEM blocks to name in dir
+func writeTestCertificates(t *testing.T, dir, name string, certificates ...*testCertificate) {
+	var content []byte
+	for _, c := range certificates {
+		content = append(content, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.certificate.Raw})...)
+	}
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name), content, 0644))
+}
+
+func TestValidateCertificateWithSAN(t *testing.T) {
+	dir, err := ioutil.TempDir("", "certs")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	leaf := generateTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "backend"},
+		DNSNames: []string{"backend.example.com"}, NotAfter: time.Now().AddDate(1, 0, 0)}, nil)
+	writeTestCertificates(t, dir, "backend.crt", leaf)
+
+	cert := &params.Cert{Host: "https://backend.example.com:8443", Path: "backend.crt"}
+	info, err := validateCertificate(dir, cert, time.Now())
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, CertStatusValid, info.Status)
+	assert.Regexp(t, `^backend\.example\.com-[0-9a-f]{8}$`, cert.Alias, "Alias should be generated")
+	assert.Equal(t, cert.Alias, info.Alias)
+	assert.NotEmpty(t, cert.Certificate)
+
+	cert = &params.Cert{Host: "other.example.com", Alias: "other", Path: "backend.crt"}
+	info, err = validateCertificate(dir, cert, time.Now())
+	assert.NotNil(t, err, "Host which is not in the SAN should fail")
+	assert.Equal(t, CertStatusInvalid, info.Status)
+	assert.Contains(t, err.Error(), "subject alternative names")
+}
+
+func TestValidateCertificateWithCommonName(t *testing.T) {
+	dir, err := ioutil.TempDir("", "certs")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	leaf := generateTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "*.example.com"},
+		NotAfter: time.Now().AddDate(1, 0, 0)}, nil)
+	writeTestCertificates(t, dir, "wildcard.crt", leaf)
+
+	_, err = validateCertificate(dir, &params.Cert{Host: "api.example.com", Path: "wildcard.crt"}, time.Now())
+	assert.Nil(t, err, "Host matching the wildcard common name should pass")
+	_, err = validateCertificate(dir, &params.Cert{Host: "a.api.example.com", Path: "wildcard.crt"}, time.Now())
+	assert.NotNil(t, err, "Wildcard should only match a single label")
+	assert.Contains(t, err.Error(), "common name")
+}
+
+func TestValidateCertificateExpiry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "certs")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	defer func(days int) { utils.CertExpiryWarningDays = days }(utils.CertExpiryWarningDays)
+	utils.CertExpiryWarningDays = 30
+
+	expiring := generateTestCertificate(t, &x509.Certificate{DNSNames: []string{"localhost"},
+		NotAfter: time.Now().AddDate(0, 0, 10)}, nil)
+	writeTestCertificates(t, dir, "expiring.crt", expiring)
+	info, err := validateCertificate(dir, &params.Cert{Host: "localhost", Path: "expiring.crt"}, time.Now())
+	assert.Nil(t, err, "Expiring certificate should only be warned")
+	assert.Equal(t, CertStatusExpiring, info.Status)
+
+	expired := generateTestCertificate(t, &x509.Certificate{DNSNames: []string{"localhost"},
+		NotBefore: time.Now().AddDate(-1, 0, 0), NotAfter: time.Now().AddDate(0, 0, -1)}, nil)
+	writeTestCertificates(t, dir, "expired.crt", expired)
+	info, err = validateCertificate(dir, &params.Cert{Host: "localhost", Path: "expired.crt"}, time.Now())
+	assert.NotNil(t, err, "Expired certificate should fail")
+	assert.Contains(t, err.Error(), "expired")
+}
+
+func TestValidateCertificateChain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "certs")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	notAfter := time.Now().AddDate(1, 0, 0)
+	root := generateTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "root"}, IsCA: true,
+		NotAfter: notAfter}, nil)
+	intermediate := generateTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "intermediate"},
+		IsCA: true, NotAfter: notAfter}, root)
+	leaf := generateTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "localhost"},
+		DNSNames: []string{"localhost"}, NotAfter: notAfter}, intermediate)
+
+	writeTestCertificates(t, dir, "chain.crt", intermediate, leaf)
+	cert := &params.Cert{Host: "localhost:9443", Alias: "backend", Path: "chain.crt"}
+	info, err := validateCertificate(dir, cert, time.Now())
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, "CN=localhost", info.Subject)
+	assert.Equal(t, "backend", cert.Alias)
+	assert.Equal(t, credentials.Base64Encode(string(leaf.certificate.Raw)), cert.Certificate)
+
+	writeTestCertificates(t, dir, "cas.crt", root, intermediate)
+	_, err = validateCertificate(dir, &params.Cert{Host: "localhost", Path: "cas.crt"}, time.Now())
+	assert.NotNil(t, err, "Chain without a leaf should fail")
+	assert.Contains(t, err.Error(), "leaf")
+}
+
+func TestValidateCertificateRejectsPrivateKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "certs")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	leaf := generateTestCertificate(t, &x509.Certificate{DNSNames: []string{"localhost"},
+		NotAfter: time.Now().AddDate(1, 0, 0)}, nil)
+	keyDer, err := x509.MarshalECPrivateKey(leaf.key)
+	assert.Nil(t, err)
+	content := append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf.certificate.Raw}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})...)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "withkey.pem"), content, 0644))
+
+	_, err = validateCertificate(dir, &params.Cert{Host: "localhost", Path: "withkey.pem"}, time.Now())
+	assert.NotNil(t, err, "File with a private key should fail")
+	assert.Contains(t, err.Error(), "private key")
+}
+
+func TestInspectCertificates(t *testing.T) {
+	projectDir := createParamsTestProject(t)
+	defer os.RemoveAll(projectDir)
+	leaf := generateTestCertificate(t, &x509.Certificate{DNSNames: []string{"localhost"},
+		NotAfter: time.Now().AddDate(1, 0, 0)}, nil)
+	writeTestCertificates(t, projectDir, "backend.crt", leaf)
+	apiParams := "environments:\n  - name: prod\n    certs:\n      - host: https://localhost:9443\n" +
+		"        alias: backend\n        path: backend.crt\n      - host: localhost\n        path: missing.crt\n"
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectDir, utils.ParamFileAPI), []byte(apiParams), 0644))
+
+	certificates, err := InspectCertificates(projectDir, utils.ParamFileAPI, "prod")
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, 2, len(certificates))
+	assert.Equal(t, CertStatusValid, certificates[0].Status)
+	assert.Equal(t, "backend", certificates[0].Alias)
+	assert.Equal(t, CertStatusInvalid, certificates[1].Status)
+
+	_, err = InspectCertificates(projectDir, utils.ParamFileAPI, "dev")
+	assert.NotNil(t, err, "Undefined environment should fail")
+}
@@ -65,7 +65,11 @@ func DeleteAPI(accessToken, environment, deleteAPIName, deleteAPIVersion, delete
 func getAPIId(accessToken, environment, apiName, apiVersion, apiProvider string) (string, error) {
 	// Unified Search endpoint from the config file to search APIs
 	unifiedSearchEndpoint := utils.GetUnifiedSearchEndpointOfEnv(environment, utils.MainConfigFilePath)
+	return searchAPIId(accessToken, unifiedSearchEndpoint, apiName, apiVersion, apiProvider)
+}
 
+// searchAPIId gets the ID of an API using the unified search endpoint of the Publisher
+func searchAPIId(accessToken, unifiedSearchEndpoint, apiName, apiVersion, apiProvider string) (string, error) {
 	// Prepping headers
 	headers := make(map[string]string)
 	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
@@ -0,0 +1,108 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Jeffail/gabs"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+)
+
+// buildEndpointConfig builds the endpointConfig of an API from endpoints of type http, address, ws, load_balance or
+// failover in api_params.yaml
+func buildEndpointConfig(endpoints *params.EndpointData) (string, error) {
+	switch endpoints.Type {
+	case v2.EpHttp, v2.EpAddress, v2.EpWS:
+		config := gabs.New()
+		if _, err := config.Set(endpoints.Type, "endpoint_type"); err != nil {
+			return "", err
+		}
+		for key, endpoint := range endpointsByType(endpoints) {
+			if urls := endpointUrls(endpoint); len(urls) > 0 {
+				if _, err := config.Set(params.Endpoint{Url: &urls[0], Config: endpoint.Config},
+					key+"_endpoints"); err != nil {
+					return "", err
+				}
+			}
+		}
+		return config.String(), nil
+	case v2.EpLoadbalance:
+		options := v2.EndpointOptions{Algorithm: endpoints.Algorithm,
+			SessionManagement: endpoints.SessionManagement, SessionTimeOut: sessionTimeOut(endpoints)}
+		return v2.BuildLoadBalancedEndpoints(configuredEndpoints(endpoints.Production, false),
+			configuredEndpoints(endpoints.Sandbox, false), options), nil
+	case v2.EpFailover:
+		options := v2.EndpointOptions{SessionManagement: endpoints.SessionManagement,
+			SessionTimeOut: sessionTimeOut(endpoints)}
+		return v2.BuildFailOver(configuredEndpoints(endpoints.Production, true),
+			configuredEndpoints(endpoints.Sandbox, true), options), nil
+	default:
+		return "", fmt.Errorf("invalid endpoint type %s found in the api_params.yaml. Should be either %s, %s, %s, "+
+			"%s or %s", endpoints.Type, v2.EpHttp, v2.EpAddress, v2.EpWS, v2.EpLoadbalance, v2.EpFailover)
+	}
+}
+
+// endpointsByType returns the production and sandbox endpoints which are defined, keyed by their type
+func endpointsByType(endpoints *params.EndpointData) map[string]*params.Endpoint {
+	result := make(map[string]*params.Endpoint)
+	if endpoints.Production != nil {
+		result["production"] = endpoints.Production
+	}
+	if endpoints.Sandbox != nil {
+		result["sandbox"] = endpoints.Sandbox
+	}
+	return result
+}
+
+// configuredEndpoints returns an endpoint with the config of endpoint for each of its urls, followed by its failover
+// urls when withFailovers is set
+func configuredEndpoints(endpoint *params.Endpoint, withFailovers bool) []params.Endpoint {
+	if endpoint == nil {
+		return nil
+	}
+	urls := endpointUrls(endpoint)
+	if withFailovers {
+		urls = append(urls, endpoint.FailoverUrls...)
+	}
+	result := make([]params.Endpoint, len(urls))
+	for i := range urls {
+		result[i] = params.Endpoint{Url: &urls[i], Config: endpoint.Config}
+	}
+	return result
+}
+
+// endpointUrls returns url followed by urls of the endpoint
+func endpointUrls(endpoint *params.Endpoint) []string {
+	var urls []string
+	if endpoint.Url != nil {
+		urls = append(urls, *endpoint.Url)
+	}
+	return append(urls, endpoint.Urls...)
+}
+
+// sessionTimeOut returns the session timeout of endpoints as a string, empty if it is not set
+func sessionTimeOut(endpoints *params.EndpointData) string {
+	if endpoints.SessionTimeOut == nil {
+		return ""
+	}
+	return strconv.Itoa(*endpoints.SessionTimeOut)
+}
@@ -0,0 +1,111 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Jeffail/gabs"
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"gopkg.in/yaml.v2"
+)
+
+// mergeEndpoints merges the endpoints in YAML to the test API and returns the endpointConfig of the merged API
+func mergeEndpoints(t *testing.T, endpoints string) *gabs.Container {
+	projectDir := createParamsTestProject(t)
+	defer os.RemoveAll(projectDir)
+	env := &params.Environment{Name: "dev"}
+	assert.Nil(t, yaml.Unmarshal([]byte(endpoints), &env.Endpoints))
+
+	err := mergeAPI(projectDir, env)
+	assert.Nil(t, err, "Error should be nil")
+	endpointConfig, err := gabs.ParseJSON([]byte(readMergedAPI(t, projectDir).S("endpointConfig").Data().(string)))
+	assert.Nil(t, err)
+	return endpointConfig
+}
+
+func TestMergeAPIWithLoadBalancedEndpoints(t *testing.T) {
+	endpointConfig := mergeEndpoints(t, `
+type: load_balance
+sessionManagement: transport
+sessionTimeOut: 60
+production:
+  urls: [https://prod1.example.com, https://prod2.example.com]
+  config:
+    retryTimeOut: 10
+sandbox:
+  url: https://sandbox.example.com
+`)
+	assert.Equal(t, "load_balance", endpointConfig.S("endpoint_type").Data())
+	assert.Equal(t, v2.DefaultLoadBalanceAlgorithm, endpointConfig.S("algoClassName").Data())
+	assert.Equal(t, "transport", endpointConfig.S("sessionManagement").Data())
+	assert.Equal(t, "60", endpointConfig.S("sessionTimeOut").Data())
+	production, _ := endpointConfig.S("production_endpoints").Children()
+	assert.Equal(t, 2, len(production))
+	assert.Equal(t, "https://prod2.example.com", production[1].S("url").Data())
+	assert.Equal(t, "10", production[1].S("config", "retryTimeOut").Data())
+	sandbox, _ := endpointConfig.S("sandbox_endpoints").Children()
+	assert.Equal(t, 1, len(sandbox))
+	assert.Equal(t, "https://sandbox.example.com", sandbox[0].S("url").Data())
+}
+
+func TestMergeAPIWithFailoverEndpoints(t *testing.T) {
+	endpointConfig := mergeEndpoints(t, `
+type: failover
+production:
+  url: https://primary.example.com
+  failoverUrls: [https://backup1.example.com, https://backup2.example.com]
+`)
+	assert.Equal(t, "failover", endpointConfig.S("endpoint_type").Data())
+	assert.Equal(t, "True", endpointConfig.S("failOver").Data())
+	assert.Equal(t, "https://primary.example.com", endpointConfig.S("production_endpoints", "url").Data())
+	failovers, _ := endpointConfig.S("production_failovers").Children()
+	assert.Equal(t, 2, len(failovers))
+	assert.Equal(t, "https://backup2.example.com", failovers[1].S("url").Data())
+	assert.False(t, endpointConfig.Exists("sandbox_endpoints"), "Sandbox endpoints of the API should be replaced")
+}
+
+func TestMergeAPIWithHttpEndpoints(t *testing.T) {
+	endpointConfig := mergeEndpoints(t, `
+type: http
+production:
+  url: https://prod.example.com
+`)
+	assert.Equal(t, "http", endpointConfig.S("endpoint_type").Data())
+	assert.Equal(t, "https://prod.example.com", endpointConfig.S("production_endpoints", "url").Data())
+	assert.False(t, endpointConfig.Exists("sandbox_endpoints"), "Sandbox endpoints of the API should be replaced")
+}
+
+func TestMergeAPIWithWSEndpoints(t *testing.T) {
+	endpointConfig := mergeEndpoints(t, `
+type: ws
+production:
+  url: wss://chat.example.com/ws
+`)
+	assert.Equal(t, "ws", endpointConfig.S("endpoint_type").Data())
+	assert.Equal(t, "wss://chat.example.com/ws", endpointConfig.S("production_endpoints", "url").Data())
+}
+
+func TestBuildEndpointConfigWithInvalidType(t *testing.T) {
+	_, err := buildEndpointConfig(&params.EndpointData{Type: "weighted"})
+	assert.NotNil(t, err, "Should return an error for invalid endpoint types")
+}
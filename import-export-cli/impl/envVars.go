@@ -0,0 +1,121 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// ProjectEnvVariable is an environment variable referred in an API project
+type ProjectEnvVariable struct {
+	// Name of the environment variable
+	Name string
+	// Default value used when the variable is not set, valid if HasDefault is true
+	Default string
+	// HasDefault is true when all references of the variable have a default value
+	HasDefault bool
+	// Message given with ${VAR:?message}
+	Message string
+	// Files referring the variable, relative to the project
+	Files []string
+}
+
+// IsSet returns true if the variable is set in the environment
+func (v *ProjectEnvVariable) IsSet() bool {
+	return os.Getenv(v.Name) != ""
+}
+
+// ListProjectEnvVariables returns the environment variables referred in the API params file and the files in
+// utils.EnvReplaceFilePaths of the project in projectDir, sorted by name.
+// paramsPath is resolved as the --params flag of import-api. A missing api_params.yaml is ignored
+func ListProjectEnvVariables(projectDir, paramsPath string) ([]ProjectEnvVariable, error) {
+	variables := make(map[string]*ProjectEnvVariable)
+	addRefs := func(file string, refs []utils.EnvVariableRef) {
+		rel, err := filepath.Rel(projectDir, file)
+		if err != nil {
+			rel = file
+		}
+		for _, ref := range refs {
+			variable, ok := variables[ref.Name]
+			if !ok {
+				variable = &ProjectEnvVariable{Name: ref.Name, Default: ref.Default, HasDefault: ref.HasDefault}
+				variables[ref.Name] = variable
+			}
+			variable.HasDefault = variable.HasDefault && ref.HasDefault
+			if variable.Message == "" {
+				variable.Message = ref.Message
+			}
+			if len(variable.Files) == 0 || variable.Files[len(variable.Files)-1] != rel {
+				variable.Files = append(variable.Files, rel)
+			}
+		}
+	}
+
+	if paramsPath != "" {
+		resolvedParamsPath, err := resolveParamsPath(projectDir, paramsPath, utils.ParamFileAPI)
+		if err != nil && paramsPath != utils.ParamFileAPI {
+			return nil, err
+		}
+		if resolvedParamsPath != "" {
+			content, err := ioutil.ReadFile(resolvedParamsPath)
+			if err != nil {
+				return nil, err
+			}
+			addRefs(resolvedParamsPath, utils.FindEnvVariables(string(content), false))
+		}
+	}
+
+	for _, replacePath := range utils.EnvReplaceFilePaths {
+		err := filepath.Walk(filepath.Join(projectDir, replacePath), func(path string, info os.FileInfo,
+			err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			addRefs(path, utils.FindEnvVariables(string(content), true))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]ProjectEnvVariable, 0, len(variables))
+	for _, variable := range variables {
+		result = append(result, *variable)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
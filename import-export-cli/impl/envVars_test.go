@@ -0,0 +1,62 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestListProjectEnvVariables(t *testing.T) {
+	projectDir := createParamsTestProject(t)
+	defer os.RemoveAll(projectDir)
+	params := "environments:\n  - name: dev\n    endpoints:\n      production:\n        url: $ENV_VARS_BACKEND\n" +
+		"    security:\n      password: ${ENV_VARS_PASSWORD:?set the backend password}\n"
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectDir, utils.ParamFileAPI), []byte(params), 0644))
+	sequencePath := filepath.Join(projectDir, "Sequences", "in-sequence", "Custom", "auth.xml")
+	assert.Nil(t, os.MkdirAll(filepath.Dir(sequencePath), os.ModePerm))
+	sequence := `<property name="a" value="${ENV_VARS_BACKEND}"/><property name="b" value="${ENV_VARS_REALM:-dev}"/>`
+	assert.Nil(t, ioutil.WriteFile(sequencePath, []byte(sequence), 0644))
+	_ = os.Setenv("ENV_VARS_BACKEND", "http://dev.example.com")
+	defer os.Unsetenv("ENV_VARS_BACKEND")
+	_ = os.Unsetenv("ENV_VARS_PASSWORD")
+
+	variables, err := ListProjectEnvVariables(projectDir, utils.ParamFileAPI)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, 3, len(variables))
+
+	assert.Equal(t, "ENV_VARS_BACKEND", variables[0].Name)
+	assert.True(t, variables[0].IsSet())
+	assert.Equal(t, []string{utils.ParamFileAPI, filepath.Join("Sequences", "in-sequence", "Custom", "auth.xml")},
+		variables[0].Files)
+
+	assert.Equal(t, "ENV_VARS_PASSWORD", variables[1].Name)
+	assert.False(t, variables[1].IsSet())
+	assert.False(t, variables[1].HasDefault)
+	assert.Equal(t, "set the backend password", variables[1].Message)
+
+	assert.Equal(t, "ENV_VARS_REALM", variables[2].Name)
+	assert.True(t, variables[2].HasDefault)
+	assert.Equal(t, "dev", variables[2].Default)
+}
@@ -0,0 +1,96 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"strconv"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// getExportHeaders returns the headers used when exporting artifacts
+func getExportHeaders(accessToken string) map[string]string {
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	headers[utils.HeaderAccept] = utils.HeaderValueApplicationZip
+	return headers
+}
+
+// getExportAPIURL returns the admin REST API url used to export an API
+func getExportAPIURL(name, version, provider, format, adminEndpoint string, preserveStatus bool) string {
+	adminEndpoint = utils.AppendSlashToString(adminEndpoint)
+	query := "export/api?name=" + name + "&version=" + version + "&providerName=" + provider +
+		"&preserveStatus=" + strconv.FormatBool(preserveStatus)
+	if format != "" {
+		query += "&format=" + format
+	}
+
+	url := adminEndpoint + query
+	utils.Logln(utils.LogPrefixInfo+"ExportAPI: URL:", url)
+	return url
+}
+
+// getExportAPIProductURL returns the admin REST API url used to export an API Product
+func getExportAPIProductURL(name, version, provider, format, adminEndpoint string) string {
+	adminEndpoint = utils.AppendSlashToString(adminEndpoint)
+	query := "export/api-product?name=" + name + "&version=" + version + "&providerName=" + provider
+	if format != "" {
+		query += "&format=" + format
+	}
+
+	url := adminEndpoint + query
+	utils.Logln(utils.LogPrefixInfo+"ExportAPIProduct: URL:", url)
+	return url
+}
+
+// getExportApplicationURL returns the admin REST API url used to export an Application
+func getExportApplicationURL(name, owner, adminEndpoint string, withKeys bool) string {
+	adminEndpoint = utils.AppendSlashToString(adminEndpoint)
+	query := "export/applications?appName=" + name + utils.SearchAndTag + "appOwner=" + owner
+
+	if withKeys {
+		query += "&withKeys=true"
+	}
+
+	url := adminEndpoint + query
+	utils.Logln(utils.LogPrefixInfo+"ExportApp: URL:", url)
+	return url
+}
+
+// ExportAPIToFile streams the exported API to destination without buffering it in memory
+// @return error, *utils.DownloadError if the server returned an unsuccessful response
+func ExportAPIToFile(name, version, provider, format, adminEndpoint, accessToken string, preserveStatus bool,
+	destination string) error {
+	url := getExportAPIURL(name, version, provider, format, adminEndpoint, preserveStatus)
+	return utils.DownloadFile(url, getExportHeaders(accessToken), destination)
+}
+
+// ExportAPIProductToFile streams the exported API Product to destination without buffering it in memory
+// @return error, *utils.DownloadError if the server returned an unsuccessful response
+func ExportAPIProductToFile(name, version, provider, format, adminEndpoint, accessToken, destination string) error {
+	url := getExportAPIProductURL(name, version, provider, format, adminEndpoint)
+	return utils.DownloadFile(url, getExportHeaders(accessToken), destination)
+}
+
+// ExportApplicationToFile streams the exported Application to destination without buffering it in memory
+// @return error, *utils.DownloadError if the server returned an unsuccessful response
+func ExportApplicationToFile(name, owner, adminEndpoint, accessToken string, withKeys bool, destination string) error {
+	url := getExportApplicationURL(name, owner, adminEndpoint, withKeys)
+	return utils.DownloadFile(url, getExportHeaders(accessToken), destination)
+}
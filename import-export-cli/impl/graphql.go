@@ -0,0 +1,61 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"fmt"
+
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// GraphQLSchemaFileName is the name of the GraphQL schema inside Meta-information of the project
+const GraphQLSchemaFileName = "schema.graphql"
+
+// PopulateAPIFromGraphQL fills def as a GraphQL API named name using the SDL schema in schemaPath, which is either a
+// file path or an URL. Each query, mutation and subscription becomes an URI template. When endpoint is given, it is
+// used as the production endpoint of the API.
+// Returns the schema to be stored inside the project
+func PopulateAPIFromGraphQL(def *v2.APIDefinition, name, schemaPath, endpoint string) ([]byte, error) {
+	utils.Logln(utils.LogPrefixInfo + "Loading GraphQL schema from " + schemaPath)
+	content, err := readDocument(schemaPath)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := v2.ParseGraphQLSchema(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("invalid GraphQL schema %s: %v", schemaPath, err)
+	}
+
+	def.ID.APIName = utils.ToPascalCase(name)
+	def.Context = fmt.Sprintf("/%s/%s", def.ID.APIName, def.ID.Version)
+	def.ContextTemplate = fmt.Sprintf("/%s/{version}", def.ID.APIName)
+	v2.GraphQLPopulate(def, schema)
+
+	if endpoint != "" {
+		ep, err := v2.BuildAPIMEndpoints(&v2.Endpoints{Urls: []string{endpoint}}, &v2.Endpoints{})
+		if err != nil {
+			return nil, err
+		}
+		def.EndpointConfig = &ep
+		def.ProductionUrl = ""
+		def.SandboxUrl = ""
+	}
+	return content, nil
+}
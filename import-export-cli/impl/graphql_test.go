@@ -0,0 +1,100 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	yaml2 "gopkg.in/yaml.v2"
+)
+
+const graphQLTestSchemaPath = "../specs/v2/testdata/graphql/starwars.graphql"
+
+func TestPopulateAPIFromGraphQL(t *testing.T) {
+	def := &v2.APIDefinition{ID: v2.ID{Version: "1.0.0"}, Type: "HTTP", ProductionUrl: "http://localhost:8080"}
+	schema, err := PopulateAPIFromGraphQL(def, "star wars", graphQLTestSchemaPath, "https://swapi.example.com/graphql")
+	assert.Nil(t, err)
+	original, _ := ioutil.ReadFile(graphQLTestSchemaPath)
+	assert.Equal(t, original, schema, "schema should be stored as it is")
+
+	assert.Equal(t, "StarWars", def.ID.APIName)
+	assert.Equal(t, "/StarWars/1.0.0", def.Context)
+	assert.Equal(t, v2.APITypeGraphQL, def.Type)
+	assert.Empty(t, def.ProductionUrl)
+	assert.Contains(t, *def.EndpointConfig, `"url":"https://swapi.example.com/graphql"`)
+	assert.Len(t, def.URITemplates, 5)
+	assert.Equal(t, "createReview", def.URITemplates[3].URITemplate)
+	assert.Equal(t, v2.GraphQLMutation, def.URITemplates[3].HTTPVerb)
+}
+
+func TestPopulateAPIFromGraphQLInvalidSchema(t *testing.T) {
+	dir, err := ioutil.TempDir("", "graphql")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	schemaPath := filepath.Join(dir, "schema.graphql")
+	assert.Nil(t, ioutil.WriteFile(schemaPath, []byte("type Query { user: User }"), 0644))
+
+	_, err = PopulateAPIFromGraphQL(&v2.APIDefinition{}, "users", schemaPath, "")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "type User of Query.user is not defined")
+}
+
+func TestMergeAPIGraphQLOperationPolicies(t *testing.T) {
+	def := &v2.APIDefinition{ID: v2.ID{Version: "1.0.0"}}
+	_, err := PopulateAPIFromGraphQL(def, "StarWars", graphQLTestSchemaPath, "https://swapi.example.com")
+	assert.Nil(t, err)
+	projectDir, err := ioutil.TempDir("", "project")
+	assert.Nil(t, err)
+	defer os.RemoveAll(projectDir)
+	assert.Nil(t, os.MkdirAll(filepath.Join(projectDir, "Meta-information"), os.ModePerm))
+	content, err := yaml2.Marshal(def)
+	assert.Nil(t, err)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectDir, "Meta-information", "api.yaml"), content, 0644))
+
+	env := &params.Environment{
+		Name: "dev",
+		Policies: &params.Policies{
+			Resources: []params.ResourcePolicy{
+				{Target: "createReview", Verb: "MUTATION", ThrottlingPolicy: "10KPerMin", Scope: "review:write"},
+				{Target: "hero", Verb: "query", Scope: "review:write"},
+			},
+		},
+	}
+	err = mergeAPI(projectDir, env)
+	assert.Nil(t, err)
+	api := readMergedAPI(t, projectDir)
+
+	createReview := api.S("uriTemplates").Index(3)
+	assert.Equal(t, "10KPerMin", createReview.S("throttlingTier").Data())
+	assert.Equal(t, "10KPerMin", createReview.S("throttlingTiers").Index(0).Data())
+	assert.Equal(t, "review:write", createReview.S("scopes").Index(0).S("key").Data())
+	hero := api.S("uriTemplates").Index(0)
+	assert.Equal(t, "Unlimited", hero.S("throttlingTier").Data(), "throttling policy should be kept when not set")
+	assert.Equal(t, "review:write", hero.S("scopes").Index(0).S("name").Data())
+
+	scopes, _ := api.S("scopes").Children()
+	assert.Len(t, scopes, 1, "scope should be added to the API once")
+	assert.Equal(t, "review:write", scopes[0].S("key").Data())
+}
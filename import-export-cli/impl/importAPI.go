@@ -20,9 +20,7 @@ package impl
 
 import (
 	"bytes"
-	"crypto/tls"
 	"encoding/json"
-	"encoding/pem"
 	"errors"
 	"fmt"
 	"io"
@@ -33,6 +31,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
@@ -41,7 +40,6 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
 
 	"github.com/mitchellh/go-homedir"
-	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
 
 	"github.com/Jeffail/gabs"
@@ -88,7 +86,6 @@ func getAPIDefinition(filePath string) (*v2.APIDefinition, []byte, error) {
 }
 
 // mergeAPI merges environmentParams to the API given in apiDirectory
-// for now only Endpoints are merged
 func mergeAPI(apiDirectory string, environmentParams *params.Environment) error {
 	// read api from Meta-information
 	apiPath := filepath.Join(apiDirectory, "Meta-information", "api")
@@ -102,20 +99,30 @@ func mergeAPI(apiDirectory string, environmentParams *params.Environment) error
 	if err != nil {
 		return err
 	}
-	// extract environmentParams from file
-	apiEndpointData, err := params.ExtractAPIEndpointConfig(api.Bytes())
-	if err != nil {
-		return err
-	}
+	var mergedAPIEndpoints []byte
+	if environmentParams.Endpoints != nil && environmentParams.Endpoints.Type != "" {
+		// endpoints with a type replace the endpoint configuration of the API
+		endpointConfig, err := buildEndpointConfig(environmentParams.Endpoints)
+		if err != nil {
+			return err
+		}
+		mergedAPIEndpoints = []byte(endpointConfig)
+	} else {
+		// extract environmentParams from file
+		apiEndpointData, err := params.ExtractAPIEndpointConfig(api.Bytes())
+		if err != nil {
+			return err
+		}
 
-	configData, err := json.Marshal(environmentParams.Endpoints)
-	if err != nil {
-		return err
-	}
+		configData, err := json.Marshal(environmentParams.Endpoints)
+		if err != nil {
+			return err
+		}
 
-	mergedAPIEndpoints, err := utils.MergeJSON([]byte(apiEndpointData), configData)
-	if err != nil {
-		return err
+		mergedAPIEndpoints, err = utils.MergeJSON([]byte(apiEndpointData), configData)
+		if err != nil {
+			return err
+		}
 	}
 
 	utils.Logln(utils.LogPrefixInfo + "Merging API")
@@ -137,6 +144,12 @@ func mergeAPI(apiDirectory string, environmentParams *params.Environment) error
 		return err
 	}
 
+	// merge policies, CORS, visibility and metadata in api_params.yaml
+	err = mergeAPIMetadata(environmentParams, api)
+	if err != nil {
+		return err
+	}
+
 	apiPath = filepath.Join(apiDirectory, "Meta-information", "api.yaml")
 	utils.Logln(utils.LogPrefixInfo+"Writing merged API to:", apiPath)
 	// write this to disk
@@ -284,24 +297,30 @@ func extractArchive(src, dest string) (string, error) {
 // If not found it will look at current working directory
 // If a path is provided search ends looking up at that path
 func resolveAPIParamsPath(importPath, paramPath string) (string, error) {
+	return resolveParamsPath(importPath, paramPath, utils.ParamFileAPI)
+}
+
+// resolveParamsPath resolves the path of a params file named paramsFileName using the rules of
+// resolveAPIParamsPath
+func resolveParamsPath(importPath, paramPath, paramsFileName string) (string, error) {
 	utils.Logln(utils.LogPrefixInfo + "Scanning for parameters file")
-	if paramPath == utils.ParamFileAPI {
+	if paramPath == paramsFileName {
 		// look in importpath
 		if stat, err := os.Stat(importPath); err == nil && stat.IsDir() {
-			loc := filepath.Join(importPath, utils.ParamFileAPI)
+			loc := filepath.Join(importPath, paramsFileName)
 			utils.Logln(utils.LogPrefixInfo+"Scanning for", loc)
 			if info, err := os.Stat(loc); err == nil && !info.IsDir() {
-				// found api_params.yml in the importpath
+				// found the params file in the importpath
 				return loc, nil
 			}
 		}
 
 		// look in the basepath of importPath
 		base := filepath.Dir(importPath)
-		fp := filepath.Join(base, utils.ParamFileAPI)
+		fp := filepath.Join(base, paramsFileName)
 		utils.Logln(utils.LogPrefixInfo+"Scanning for", fp)
 		if info, err := os.Stat(fp); err == nil && !info.IsDir() {
-			// found api_params.yml in the base path
+			// found the params file in the base path
 			return fp, nil
 		}
 
@@ -311,15 +330,15 @@ func resolveAPIParamsPath(importPath, paramPath string) (string, error) {
 			return "", err
 		}
 		utils.Logln(utils.LogPrefixInfo+"Scanning for", wd)
-		fp = filepath.Join(wd, utils.ParamFileAPI)
+		fp = filepath.Join(wd, paramsFileName)
 		if info, err := os.Stat(fp); err == nil && !info.IsDir() {
-			// found api_params.yml in the cwd
+			// found the params file in the cwd
 			return fp, nil
 		}
 
 		// no luck, it means paramPath is missing
 		return "", fmt.Errorf("could not find %s. Please check %s exists in basepath of "+
-			"import location or current working directory", utils.ParamFileAPI, utils.ParamFileAPI)
+			"import location or current working directory", paramsFileName, paramsFileName)
 	} else {
 		if info, err := os.Stat(paramPath); err == nil && !info.IsDir() {
 			return paramPath, nil
@@ -432,20 +451,16 @@ func generateCertificates(importPath string, environment *params.Environment) er
 		return nil
 	}
 
+	now := time.Now()
 	for _, cert := range environment.Certs {
-		// read cert
-		p, err := resolveCertPath(importPath, cert.Path)
+		// read and validate cert
+		info, err := validateCertificate(importPath, &cert, now)
 		if err != nil {
-			return err
+			return fmt.Errorf("invalid certificate %s: %v", cert.Path, err)
 		}
-		pubPEMData, err := ioutil.ReadFile(p)
-		if err != nil {
-			return err
+		if info.Status == CertStatusExpiring {
+			fmt.Printf("Warning: certificate %s of %s %s\n", cert.Alias, cert.Host, info.Message)
 		}
-		// get cert
-		block, _ := pem.Decode(pubPEMData)
-		enc := credentials.Base64Encode(string(block.Bytes))
-		cert.Certificate = enc
 		certs = append(certs, cert)
 	}
 
@@ -497,15 +512,24 @@ func injectParamsToAPI(importPath, paramsPath, importEnvironment string) error {
 
 // getApiID returns id of the API by using apiInfo which contains name and version as info
 func getApiID(accessOAuthToken, environment, name, version string) (string, error) {
+	api, err := getAPIInfo(accessOAuthToken, environment, name, version)
+	if err != nil || api == nil {
+		return "", err
+	}
+	return api.ID, nil
+}
+
+// getAPIInfo returns the API with the given name and version in the environment, nil if it does not exist
+func getAPIInfo(accessOAuthToken, environment, name, version string) (*utils.API, error) {
 	apiQuery := fmt.Sprintf("name:%s version:%s", name, version)
 	count, apis, err := GetAPIListFromEnv(accessOAuthToken, environment, url.QueryEscape(apiQuery), "")
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if count == 0 {
-		return "", nil
+		return nil, nil
 	}
-	return apis[0].ID, nil
+	return &apis[0], nil
 }
 
 // isEmpty returns true when a given string is empty
@@ -533,6 +557,10 @@ func preProcessAPI(apiDirectory string) error {
 		if err != nil {
 			return err
 		}
+		// WebSocket APIs keep their endpoint type
+		if api.S("type").Data() == v2.APITypeWS {
+			_, _ = conf.Set(v2.EpWS, "endpoint_type")
+		}
 
 		if api.Exists("productionUrl") {
 			_, err = conf.SetP(api.Path("productionUrl").Data(), "production_endpoints.url")
@@ -606,6 +634,49 @@ func replaceEnvVariables(apiFilePath string) error {
 	return nil
 }
 
+// projectContainsSecrets returns true if the project files substituted by replaceEnvVariables or the params file
+// contain encrypted values (enc:v1:...), which are decrypted into the workspace during the import
+func projectContainsSecrets(apiFilePath, paramsPath string) (bool, error) {
+	for _, replacePath := range utils.EnvReplaceFilePaths {
+		// project files are substituted the same way as replaceEnvVariables does
+		found, err := fileContainsSecrets(filepath.Join(apiFilePath, replacePath), utils.EnvSubstituteForCurlyBraces)
+		if err != nil || found {
+			return found, err
+		}
+	}
+	if paramsPath == "" {
+		return false, nil
+	}
+	// params files are substituted the same way as they are loaded
+	return fileContainsSecrets(paramsPath, utils.EnvSubstitute)
+}
+
+// fileContainsSecrets returns true if the file, or any file in the directory, contains encrypted values after
+// substituting the environment variables in it. Files which do not exist are ignored
+func fileContainsSecrets(file string, substitute func(string) (string, error)) (bool, error) {
+	found := false
+	err := filepath.Walk(file, func(path string, info os.FileInfo, err error) error {
+		if err != nil || found || !info.Mode().IsRegular() {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		// values can be given in environment variables as well
+		substituted, err := substitute(string(content))
+		if err != nil {
+			substituted = string(content)
+		}
+		found = utils.ContainsSecrets(substituted)
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	return found, nil
+}
+
 func populateApiWithDefaults(def *v2.APIDefinition) (dirty bool) {
 	dirty = false
 	if def.ContextTemplate == "" {
@@ -711,21 +782,7 @@ func importAPI(endpoint, httpMethod, filePath, accessToken string, extraParams m
 		return err
 	}
 
-	var tr *http.Transport
-	if utils.Insecure {
-		tr = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		}
-	} else {
-		tr = &http.Transport{
-			TLSClientConfig: utils.GetTlsConfigWithCertificate(),
-		}
-	}
-
-	client := &http.Client{
-		Transport: tr,
-		Timeout:   time.Duration(utils.HttpRequestTimeout) * time.Second,
-	}
+	client := utils.NewHttpClient(time.Duration(utils.HttpRequestTimeout) * time.Second)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -756,17 +813,35 @@ func importAPI(endpoint, httpMethod, filePath, accessToken string, extraParams m
 	}
 }
 
+// ImportAPIOptions holds the options of importing an API
+type ImportAPIOptions struct {
+	// Overrides given with --set and --set-file, applied after the params
+	Overrides []Override
+	// Update the API if it already exists
+	Update bool
+	// PreserveProvider keeps the provider of the API instead of the importing user
+	PreserveProvider bool
+	// SkipCleanup leaves the workspace of the import
+	SkipCleanup bool
+	// NoBundle keeps the external references of the swagger definition instead of inlining them
+	NoBundle bool
+	// LintRules lint the API before importing it when not nil. The API is not imported if a rule with the severity
+	// error fails
+	LintRules *LintRuleSet
+	// PinnedSnapshotID is the id of a snapshot that must not be pruned when the existing API is snapshotted
+	PinnedSnapshotID string
+}
+
 // ImportAPIToEnv function is used with import-api command
-func ImportAPIToEnv(accessOAuthToken, importEnvironment, importPath, apiParamsPath string, importAPIUpdate, preserveProvider,
-	importAPISkipCleanup bool) error {
+func ImportAPIToEnv(accessOAuthToken, importEnvironment, importPath, apiParamsPath string,
+	options ImportAPIOptions) error {
 	adminEndpoint := utils.GetAdminEndpointOfEnv(importEnvironment, utils.MainConfigFilePath)
-	return ImportAPI(accessOAuthToken, adminEndpoint, importEnvironment, importPath, apiParamsPath, importAPIUpdate,
-		preserveProvider, importAPISkipCleanup)
+	return ImportAPI(accessOAuthToken, adminEndpoint, importEnvironment, importPath, apiParamsPath, options)
 }
 
 // ImportAPI function is used with import-api command
-func ImportAPI(accessOAuthToken, adminEndpoint, importEnvironment, importPath, apiParamsPath string, importAPIUpdate, preserveProvider,
-		importAPISkipCleanup bool) error {
+func ImportAPI(accessOAuthToken, adminEndpoint, importEnvironment, importPath, apiParamsPath string,
+	options ImportAPIOptions) error {
 	exportDirectory := filepath.Join(utils.ExportDirectory, utils.ExportedApisDirName)
 	resolvedApiFilePath, err := resolveImportFilePath(importPath, exportDirectory)
 	if err != nil {
@@ -780,7 +855,7 @@ func ImportAPI(accessOAuthToken, adminEndpoint, importEnvironment, importPath, a
 		return err
 	}
 	defer func() {
-		if importAPISkipCleanup {
+		if options.SkipCleanup {
 			utils.Logln(utils.LogPrefixInfo+"Leaving", tmpPath)
 			return
 		}
@@ -790,14 +865,44 @@ func ImportAPI(accessOAuthToken, adminEndpoint, importEnvironment, importPath, a
 			utils.Logln(utils.LogPrefixError + err.Error())
 		}
 	}()
-	apiFilePath := tmpPath
+
+	paramsPath, err := resolveAPIParamsPath(resolvedApiFilePath, apiParamsPath)
+	if err != nil && apiParamsPath != utils.ParamFileAPI && apiParamsPath != "" {
+		return err
+	}
+	return importAPIFromWorkspace(accessOAuthToken, adminEndpoint, importEnvironment, tmpPath, paramsPath, options)
+}
+
+// importAPIFromWorkspace processes the API project copied to apiFilePath and imports it to the API Manager.
+// paramsPath is the resolved api_params.yaml; it is ignored when empty
+func importAPIFromWorkspace(accessOAuthToken, adminEndpoint, importEnvironment, apiFilePath, paramsPath string,
+	options ImportAPIOptions) error {
+	if options.SkipCleanup {
+		// encrypted values are written decrypted to the workspace, which must not be left on the disk
+		hasSecrets, err := projectContainsSecrets(apiFilePath, paramsPath)
+		if err != nil {
+			return err
+		}
+		if hasSecrets {
+			return errors.New("--skipCleanup can not be used when the API project or the params file contain " +
+				"encrypted values, since they would be left decrypted in the temporary files")
+		}
+	}
 
 	utils.Logln(utils.LogPrefixInfo + "Substituting environment variables in API files...")
-	err = replaceEnvVariables(apiFilePath)
+	err := replaceEnvVariables(apiFilePath)
 	if err != nil {
 		return err
 	}
 
+	if !options.NoBundle {
+		utils.Logln(utils.LogPrefixInfo + "Bundling swagger definition...")
+		err = bundleAPIDefinition(apiFilePath)
+		if err != nil {
+			return err
+		}
+	}
+
 	utils.Logln(utils.LogPrefixInfo + "Pre Processing API...")
 	err = preProcessAPI(apiFilePath)
 	if err != nil {
@@ -805,10 +910,6 @@ func ImportAPI(accessOAuthToken, adminEndpoint, importEnvironment, importPath, a
 	}
 
 	utils.Logln(utils.LogPrefixInfo + "Attempting to inject parameters to the API from api_params.yaml (if exists)")
-	paramsPath, err := resolveAPIParamsPath(resolvedApiFilePath, apiParamsPath)
-	if err != nil && apiParamsPath != utils.ParamFileAPI && apiParamsPath != "" {
-		return err
-	}
 	if paramsPath != "" {
 		//Reading API params file and populate api.yaml
 		err := injectParamsToAPI(apiFilePath, paramsPath, importEnvironment)
@@ -817,6 +918,12 @@ func ImportAPI(accessOAuthToken, adminEndpoint, importEnvironment, importPath, a
 		}
 	}
 
+	// Apply --set and --set-file overrides
+	err = applyOverrides(apiFilePath, reflect.TypeOf(v2.APIDefinition{}), options.Overrides)
+	if err != nil {
+		return err
+	}
+
 	// Get API info
 	apiInfo, originalContent, err := getAPIDefinition(apiFilePath)
 	if err != nil {
@@ -860,6 +967,11 @@ func ImportAPI(accessOAuthToken, adminEndpoint, importEnvironment, importPath, a
 	if err = validateApiDefinition(apiInfo); err != nil {
 		return err
 	}
+	if options.LintRules != nil {
+		if err = lintAPIBeforeImport(apiFilePath, options.LintRules); err != nil {
+			return err
+		}
+	}
 
 	// if apiFilePath contains a directory, zip it
 	if info, err := os.Stat(apiFilePath); err == nil && info.IsDir() {
@@ -868,12 +980,10 @@ func ImportAPI(accessOAuthToken, adminEndpoint, importEnvironment, importPath, a
 			return err
 		}
 		utils.Logln(utils.LogPrefixInfo+"Creating API artifact", tmp.Name())
-		err = utils.Zip(apiFilePath, tmp.Name())
-		if err != nil {
-			return err
-		}
+		_ = tmp.Close()
+		// the artifact is removed even when zipping fails, since it contains the decrypted values
 		defer func() {
-			if importAPISkipCleanup {
+			if options.SkipCleanup {
 				utils.Logln(utils.LogPrefixInfo+"Leaving", tmp.Name())
 				return
 			}
@@ -883,24 +993,38 @@ func ImportAPI(accessOAuthToken, adminEndpoint, importEnvironment, importPath, a
 				utils.Logln(utils.LogPrefixError + err.Error())
 			}
 		}()
+		err = utils.Zip(apiFilePath, tmp.Name())
+		if err != nil {
+			return err
+		}
 		apiFilePath = tmp.Name()
 	}
 
 	updateAPI := false
-	if importAPIUpdate {
+	if options.Update {
 		// check for API existence
-		id, err := getApiID(accessOAuthToken, importEnvironment, apiInfo.ID.APIName, apiInfo.ID.Version)
+		existingAPI, err := getAPIInfo(accessOAuthToken, importEnvironment, apiInfo.ID.APIName, apiInfo.ID.Version)
 		if err != nil {
 			return err
 		}
 
-		if id == "" {
+		if existingAPI == nil {
 			utils.Logln("The specified API was not found.")
 			utils.Logln("Creating: %s %s\n", apiInfo.ID.APIName, apiInfo.ID.Version)
 		} else {
 			utils.Logln("Existing API found, attempting to update it...")
-			utils.Logln("API ID:", id)
+			utils.Logln("API ID:", existingAPI.ID)
 			updateAPI = true
+
+			// take a snapshot of the existing API so the update can be rolled back
+			if utils.SnapshotRetention > 0 {
+				snapshot, err := CreateAPISnapshot(accessOAuthToken, adminEndpoint, importEnvironment,
+					existingAPI.Name, existingAPI.Version, existingAPI.Provider, options.PinnedSnapshotID)
+				if err != nil {
+					return fmt.Errorf("error taking a snapshot of the existing API: %v", err)
+				}
+				fmt.Println("Snapshot of the existing API saved as " + snapshot.ID)
+			}
 		}
 	}
 	extraParams := map[string]string{}
@@ -908,9 +1032,9 @@ func ImportAPI(accessOAuthToken, adminEndpoint, importEnvironment, importPath, a
 	adminEndpoint += "/import/api"
 	if updateAPI {
 		adminEndpoint += "?overwrite=" + strconv.FormatBool(true) + "&preserveProvider=" +
-			strconv.FormatBool(preserveProvider)
+			strconv.FormatBool(options.PreserveProvider)
 	} else {
-		adminEndpoint += "?preserveProvider=" + strconv.FormatBool(preserveProvider)
+		adminEndpoint += "?preserveProvider=" + strconv.FormatBool(options.PreserveProvider)
 	}
 	utils.Logln(utils.LogPrefixInfo + "Import URL: " + adminEndpoint)
 
@@ -0,0 +1,243 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/Jeffail/gabs"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/ghodss/yaml"
+	"github.com/go-openapi/loads"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	yaml2 "gopkg.in/yaml.v2"
+)
+
+// LoadDefaultSpecFromDisk loads api definition stored in HOME/.wso2apictl/default_api.yaml
+func LoadDefaultSpecFromDisk() (*v2.APIDefinition, error) {
+	defaultData, err := ioutil.ReadFile(utils.DefaultAPISpecFilePath)
+	if err != nil {
+		return nil, err
+	}
+	def := &v2.APIDefinition{}
+	err = yaml.Unmarshal(defaultData, &def)
+	if err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+// isOpenAPI3 returns true if the document content has an openapi: 3.x.x version field
+func isOpenAPI3(content []byte) (bool, error) {
+	jsonContent, err := utils.YamlToJson(content)
+	if err != nil {
+		return false, err
+	}
+	doc, err := gabs.ParseJSON(jsonContent)
+	if err != nil {
+		return false, err
+	}
+	if version, ok := doc.Path("openapi").Data().(string); ok {
+		return strings.HasPrefix(version, "3"), nil
+	}
+	return false, nil
+}
+
+// PopulateAPIFromOpenAPI fills def using the OpenAPI document located in oasPath.
+// Swagger 2.0 documents are populated with v2.Swagger2Populate and OpenAPI 3 documents with v2.OpenAPI3Populate.
+// x-wso2 vendor extensions are used for basepath, CORS and endpoints.
+// The API is populated from the document with external references inlined. Unless noBundle is set, the returned
+// document is the bundled one as well.
+// Returns the document as YAML so it can be stored inside the project. OpenAPI 3 documents written in YAML without
+// external references are returned unchanged
+func PopulateAPIFromOpenAPI(def *v2.APIDefinition, oasPath string, noBundle bool) ([]byte, error) {
+	utils.Logln(utils.LogPrefixInfo + "Loading OpenAPI definition from " + oasPath)
+	original, err := readDocument(oasPath)
+	if err != nil {
+		return nil, err
+	}
+	content, err := BundleOpenAPI(original, oasPath)
+	if err != nil {
+		return nil, err
+	}
+
+	oas3, err := isOpenAPI3(content)
+	if err != nil {
+		return nil, err
+	}
+	if oas3 {
+		utils.Logln(utils.LogPrefixInfo + "Detected OpenAPI 3 definition")
+		swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(content)
+		if err != nil {
+			return nil, err
+		}
+		err = v2.OpenAPI3Populate(def, swagger)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		utils.Logln(utils.LogPrefixInfo + "Detected Swagger 2.0 definition")
+		// the bundled content is used, so external references are not resolved again
+		jsonContent, err := utils.YamlToJson(content)
+		if err != nil {
+			return nil, err
+		}
+		doc, err := loads.Analyzed(json.RawMessage(jsonContent), "")
+		if err != nil {
+			return nil, err
+		}
+		err = v2.Swagger2Populate(def, doc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if def.EndpointConfig != nil {
+		def.ProductionUrl = ""
+		def.SandboxUrl = ""
+	}
+
+	if noBundle {
+		content = original
+	}
+	if oas3 && !bytes.HasPrefix(bytes.TrimLeftFunc(content, unicode.IsSpace), []byte("{")) {
+		// store the OpenAPI 3 document as it is, without converting
+		return content, nil
+	}
+	return utils.JsonToYaml(content)
+}
+
+// overrideAPIIdentity replaces name, version and context of def when they are provided
+func overrideAPIIdentity(def *v2.APIDefinition, name, version, context string) {
+	if name != "" {
+		// a context derived from the name follows the new name, a context from x-wso2-basePath is kept
+		if def.ContextTemplate == fmt.Sprintf("/%s/{version}", def.ID.APIName) {
+			def.ContextTemplate = fmt.Sprintf("/%s/{version}", name)
+		}
+		def.ID.APIName = name
+	}
+	if version != "" {
+		def.ID.Version = version
+	}
+	if def.ContextTemplate != "" {
+		def.Context = strings.ReplaceAll(def.ContextTemplate, "{version}", def.ID.Version)
+	}
+	if context != "" {
+		// contextTemplate will be derived from the context when the API is populated with defaults
+		def.Context = context
+		def.ContextTemplate = ""
+	}
+}
+
+// createAPIProjectFromOpenAPI writes a minimal API project using def and swaggerContent in a temporary directory.
+// Returns the path to the project
+func createAPIProjectFromOpenAPI(def *v2.APIDefinition, swaggerContent []byte) (string, error) {
+	tmpDir, err := ioutil.TempDir("", "apim")
+	if err != nil {
+		return "", err
+	}
+
+	projectPath := filepath.Join(tmpDir, fmt.Sprintf("%s-%s", utils.ToPascalCase(def.ID.APIName), def.ID.Version))
+	metaInfoPath := filepath.Join(projectPath, "Meta-information")
+	utils.Logln(utils.LogPrefixInfo + "Creating API project in " + projectPath)
+	err = os.MkdirAll(metaInfoPath, os.ModePerm)
+	if err != nil {
+		_ = os.RemoveAll(tmpDir)
+		return "", err
+	}
+
+	apiData, err := yaml2.Marshal(def)
+	if err != nil {
+		_ = os.RemoveAll(tmpDir)
+		return "", err
+	}
+	err = ioutil.WriteFile(filepath.Join(metaInfoPath, "api.yaml"), apiData, 0644)
+	if err != nil {
+		_ = os.RemoveAll(tmpDir)
+		return "", err
+	}
+
+	err = ioutil.WriteFile(filepath.Join(metaInfoPath, "swagger.yaml"), swaggerContent, 0644)
+	if err != nil {
+		_ = os.RemoveAll(tmpDir)
+		return "", err
+	}
+	return projectPath, nil
+}
+
+// ImportAPIFromOASToEnv function is used with import-api command when an OpenAPI definition is given
+func ImportAPIFromOASToEnv(accessOAuthToken, importEnvironment, oasPath, name, version, context, apiParamsPath string,
+	options ImportAPIOptions) error {
+	adminEndpoint := utils.GetAdminEndpointOfEnv(importEnvironment, utils.MainConfigFilePath)
+	return ImportAPIFromOAS(accessOAuthToken, adminEndpoint, importEnvironment, oasPath, name, version, context,
+		apiParamsPath, options)
+}
+
+// ImportAPIFromOAS builds an API project from the OpenAPI definition in oasPath and the default api.yaml,
+// then imports it to the API Manager. name, version and context override the values found in the definition
+func ImportAPIFromOAS(accessOAuthToken, adminEndpoint, importEnvironment, oasPath, name, version, context,
+	apiParamsPath string, options ImportAPIOptions) error {
+	def, err := LoadDefaultSpecFromDisk()
+	if err != nil {
+		return err
+	}
+
+	swaggerContent, err := PopulateAPIFromOpenAPI(def, oasPath, options.NoBundle)
+	if err != nil {
+		return err
+	}
+	overrideAPIIdentity(def, name, version, context)
+
+	utils.Logln(utils.LogPrefixInfo + "Creating workspace")
+	tmpPath, err := createAPIProjectFromOpenAPI(def, swaggerContent)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		workspace := filepath.Dir(tmpPath)
+		if options.SkipCleanup {
+			utils.Logln(utils.LogPrefixInfo+"Leaving", workspace)
+			return
+		}
+		utils.Logln(utils.LogPrefixInfo+"Deleting", workspace)
+		err := os.RemoveAll(workspace)
+		if err != nil {
+			utils.Logln(utils.LogPrefixError + err.Error())
+		}
+	}()
+
+	// api_params.yaml is looked up next to the OpenAPI definition
+	lookupPath := oasPath
+	if absPath, err := filepath.Abs(oasPath); err == nil {
+		lookupPath = absPath
+	}
+	paramsPath, err := resolveAPIParamsPath(lookupPath, apiParamsPath)
+	if err != nil && apiParamsPath != utils.ParamFileAPI && apiParamsPath != "" {
+		return err
+	}
+	return importAPIFromWorkspace(accessOAuthToken, adminEndpoint, importEnvironment, tmpPath, paramsPath, options)
+}
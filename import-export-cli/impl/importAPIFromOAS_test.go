@@ -0,0 +1,200 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Jeffail/gabs"
+	"github.com/stretchr/testify/assert"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestPopulateAPIFromOpenAPI3(t *testing.T) {
+	def := &v2.APIDefinition{ProductionUrl: "http://localhost:8080"}
+	content, err := PopulateAPIFromOpenAPI(def, "../specs/v2/testdata/petstore_basic.yaml", false)
+	assert.Nil(t, err, "Error should be nil")
+	assert.NotEmpty(t, content, "Definition content should be returned")
+	assert.Equal(t, "/petstore/v1/1.0.0", def.Context)
+	assert.NotNil(t, def.EndpointConfig, "Endpoints should be read from x-wso2 extensions")
+	assert.Empty(t, def.ProductionUrl, "Default production url should be cleared")
+}
+
+func TestPopulateAPIFromOpenAPI3KeepsDocument(t *testing.T) {
+	original, err := ioutil.ReadFile("../specs/v2/testdata/petstore_oauth2.yaml")
+	assert.Nil(t, err)
+	def := &v2.APIDefinition{}
+	content, err := PopulateAPIFromOpenAPI(def, "../specs/v2/testdata/petstore_oauth2.yaml", false)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, original, content, "OpenAPI 3 document should not be converted")
+
+	content, err = PopulateAPIFromOpenAPI(def, utils.GetRelativeTestDataPathFromImpl()+"swaggers/swagger-3.json",
+		false)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Contains(t, string(content), "openapi: 3.0.0", "JSON document should be stored as YAML")
+}
+
+func TestPopulateAPIFromSwagger2(t *testing.T) {
+	def := &v2.APIDefinition{}
+	_, err := PopulateAPIFromOpenAPI(def, utils.GetRelativeTestDataPathFromImpl()+"swaggers/swagger-2.yaml",
+		false)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, "SimpleAPIOverview", def.ID.APIName)
+	assert.Equal(t, "/SimpleAPIOverview/v2", def.Context)
+	assert.Equal(t, "v2", def.ID.Version)
+}
+
+// populateTestSwagger populates an API from a swagger in the test data and returns it with the stored document
+func populateTestSwagger(t *testing.T, file string) (*v2.APIDefinition, *gabs.Container) {
+	def := &v2.APIDefinition{}
+	content, err := PopulateAPIFromOpenAPI(def, utils.GetRelativeTestDataPathFromImpl()+"swaggers/"+file, false)
+	if !assert.Nil(t, err, "Loads correct swagger without errors") {
+		t.FailNow()
+	}
+	jsonContent, err := utils.YamlToJson(content)
+	assert.Nil(t, err)
+	doc, err := gabs.ParseJSON(jsonContent)
+	assert.Nil(t, err)
+	return def, doc
+}
+
+func TestPopulateAPIFromSwagger2JSON(t *testing.T) {
+	def, doc := populateTestSwagger(t, "swagger-2.json")
+	assert.Equal(t, "SimpleAPIOverview", def.ID.APIName, "Name should be taken from the title")
+	assert.Equal(t, "Simple API overview", doc.Path("info.title").Data(), "Loads correct title")
+	assert.True(t, doc.Exists("paths"), "Paths should not be nil")
+}
+
+func TestPopulateAPIFromSwagger2YAML(t *testing.T) {
+	def, doc := populateTestSwagger(t, "swagger-2.yaml")
+	assert.Equal(t, "SimpleAPIOverview", def.ID.APIName, "Name should be taken from the title")
+	assert.Equal(t, "Simple API overview", doc.Path("info.title").Data(), "Loads correct title")
+	assert.True(t, doc.Exists("paths"), "Paths should not be nil")
+}
+
+func TestPopulateAPIFromOpenAPI3JSON(t *testing.T) {
+	def, doc := populateTestSwagger(t, "swagger-3.json")
+	assert.Equal(t, "SwaggerPetstore", def.ID.APIName, "Name should be taken from the title")
+	assert.Equal(t, "Swagger Petstore", doc.Path("info.title").Data(), "Loads correct title")
+	assert.NotEmpty(t, def.URITemplates, "Paths should not be nil")
+}
+
+func TestPopulateAPIFromOpenAPI3YAML(t *testing.T) {
+	def, doc := populateTestSwagger(t, "swagger-3.yaml")
+	assert.Equal(t, "SwaggerPetstore", def.ID.APIName, "Name should be taken from the title")
+	assert.Equal(t, "Swagger Petstore", doc.Path("info.title").Data(), "Loads correct title")
+	assert.NotEmpty(t, def.URITemplates, "Paths should not be nil")
+}
+
+func TestPopulateAPIFromSwagger2FromURL(t *testing.T) {
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		switch r.URL.Path {
+		case "/specs/swagger.yaml":
+			_, _ = w.Write([]byte("swagger: \"2.0\"\ninfo:\n  title: Pets\n  version: 1.0.0\npaths:\n  /pets:\n" +
+				"    get:\n      responses:\n        '200':\n          description: OK\n          schema:\n" +
+				"            $ref: 'pet.yaml'\n"))
+		case "/specs/pet.yaml":
+			_, _ = w.Write([]byte("type: object\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	def := &v2.APIDefinition{}
+	content, err := PopulateAPIFromOpenAPI(def, server.URL+"/specs/swagger.yaml", false)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, "Pets", def.ID.APIName)
+	assert.NotContains(t, string(content), "pet.yaml", "External references should be inlined")
+	assert.Equal(t, map[string]int{"/specs/swagger.yaml": 1, "/specs/pet.yaml": 1}, requests,
+		"The definition should be populated from the bundled document")
+}
+
+func TestOverrideAPIIdentity(t *testing.T) {
+	def := &v2.APIDefinition{Context: "/petstore/1.0.0", ContextTemplate: "/petstore/{version}"}
+	def.ID.APIName = "Petstore"
+	def.ID.Version = "1.0.0"
+
+	overrideAPIIdentity(def, "", "2.0.0", "")
+	assert.Equal(t, "Petstore", def.ID.APIName)
+	assert.Equal(t, "/petstore/2.0.0", def.Context, "Context should follow the new version")
+
+	overrideAPIIdentity(def, "MyPets", "", "/pets")
+	assert.Equal(t, "MyPets", def.ID.APIName)
+	assert.Equal(t, "/pets", def.Context)
+	assert.Empty(t, def.ContextTemplate)
+}
+
+func TestOverrideAPIIdentityName(t *testing.T) {
+	def := &v2.APIDefinition{Context: "/Petstore/1.0.0", ContextTemplate: "/Petstore/{version}"}
+	def.ID.APIName = "Petstore"
+	def.ID.Version = "1.0.0"
+
+	overrideAPIIdentity(def, "MyPets", "", "")
+	assert.Equal(t, "MyPets", def.ID.APIName)
+	assert.Equal(t, "/MyPets/{version}", def.ContextTemplate)
+	assert.Equal(t, "/MyPets/1.0.0", def.Context, "Context derived from the name should follow the new name")
+
+	def = &v2.APIDefinition{Context: "/petstore/1.0.0", ContextTemplate: "/petstore/{version}"}
+	def.ID.APIName = "Petstore"
+	def.ID.Version = "1.0.0"
+	overrideAPIIdentity(def, "MyPets", "", "")
+	assert.Equal(t, "/petstore/1.0.0", def.Context, "Context from the base path should be kept")
+}
+
+func TestCreateAPIProjectFromOpenAPI(t *testing.T) {
+	def := &v2.APIDefinition{}
+	def.ID.APIName = "Swagger Petstore"
+	def.ID.Version = "1.0.0"
+	projectPath, err := createAPIProjectFromOpenAPI(def, []byte("openapi: 3.0.0"))
+	assert.Nil(t, err, "Error should be nil")
+	defer os.RemoveAll(filepath.Dir(projectPath))
+
+	assert.Equal(t, "SwaggerPetstore-1.0.0", filepath.Base(projectPath))
+	assert.FileExists(t, filepath.Join(projectPath, "Meta-information", "api.yaml"))
+	assert.FileExists(t, filepath.Join(projectPath, "Meta-information", "swagger.yaml"))
+}
+
+func TestPopulateAPIFromOpenAPI3WithExternalRefs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bundle")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	root := "openapi: 3.0.0\ninfo:\n  title: Pets\n  version: 1.0.0\npaths:\n  /pets:\n    post:\n" +
+		"      requestBody:\n        content:\n          application/json:\n            schema:\n" +
+		"              $ref: './schemas/pet.yaml'\n      responses:\n        '201':\n          description: created\n"
+	writeBundleTestFiles(t, dir, map[string]string{"swagger.yaml": root, "schemas/pet.yaml": "type: object\n"})
+
+	def := &v2.APIDefinition{}
+	content, err := PopulateAPIFromOpenAPI(def, filepath.Join(dir, "swagger.yaml"), false)
+	assert.Nil(t, err, "Error should be nil")
+	assert.NotContains(t, string(content), "schemas/pet.yaml", "External references should be inlined")
+	assert.Equal(t, 1, len(def.URITemplates))
+
+	content, err = PopulateAPIFromOpenAPI(def, filepath.Join(dir, "swagger.yaml"), true)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, root, string(content), "Definition should not be bundled with no-bundle")
+}
@@ -19,7 +19,6 @@
 package impl
 
 import (
-	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -29,6 +28,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
@@ -169,21 +169,7 @@ func importAPIProduct(endpoint, httpMethod, filePath, accessToken string, extraP
 		return err
 	}
 
-	var tr *http.Transport
-	if utils.Insecure {
-		tr = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		}
-	} else {
-		tr = &http.Transport{
-			TLSClientConfig: utils.GetTlsConfigWithCertificate(),
-		}
-	}
-
-	client := &http.Client{
-		Transport: tr,
-		Timeout:   time.Duration(utils.HttpRequestTimeout) * time.Second,
-	}
+	client := utils.NewHttpClient(time.Duration(utils.HttpRequestTimeout) * time.Second)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -250,17 +236,59 @@ func preProcessDependentAPIs(apiProductFilePath, importEnvironment string) error
 	return nil
 }
 
+// apiProductContainsSecrets returns true if the API Product project, the dependent APIs and their api_params.yaml
+// files or the API Product params file contain encrypted values, which are decrypted into the workspace
+func apiProductContainsSecrets(apiProductFilePath, paramsPath string) (bool, error) {
+	found, err := projectContainsSecrets(apiProductFilePath, paramsPath)
+	if err != nil || found {
+		return found, err
+	}
+	apisDirectoryPath := filepath.Join(apiProductFilePath, "APIs")
+	items, err := ioutil.ReadDir(apisDirectoryPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, item := range items {
+		apiDirectoryPath := filepath.Join(apisDirectoryPath, item.Name())
+		found, err := projectContainsSecrets(apiDirectoryPath, filepath.Join(apiDirectoryPath, utils.ParamFileAPI))
+		if err != nil || found {
+			return found, err
+		}
+	}
+	return false, nil
+}
+
+// ImportAPIProductOptions holds the options of importing an API Product
+type ImportAPIProductOptions struct {
+	// Overrides given with --set and --set-file, applied after the params
+	Overrides []Override
+	// ImportAPIs imports the dependent APIs of the API Product
+	ImportAPIs bool
+	// UpdateAPIs imports the dependent APIs and updates the existing ones
+	UpdateAPIs bool
+	// Update the API Product if it already exists
+	Update bool
+	// PreserveProvider keeps the provider of the API Product instead of the importing user
+	PreserveProvider bool
+	// SkipCleanup leaves the workspace of the import
+	SkipCleanup bool
+}
+
 // ImportAPIProductToEnv function is used with import-api-product command
-func ImportAPIProductToEnv(accessOAuthToken, importEnvironment, importPath string, importAPIs, importAPIsUpdate,
-	importAPIProductUpdate, importAPIProductPreserveProvider, importAPIProductSkipCleanup bool) error {
+func ImportAPIProductToEnv(accessOAuthToken, importEnvironment, importPath, apiProductParamsPath string,
+	options ImportAPIProductOptions) error {
 	adminEndpoint := utils.GetAdminEndpointOfEnv(importEnvironment, utils.MainConfigFilePath)
-	return ImportAPIProduct(accessOAuthToken, adminEndpoint, importEnvironment, importPath, importAPIs, importAPIsUpdate,
-		importAPIProductUpdate, importAPIProductPreserveProvider, importAPIProductSkipCleanup)
+	return ImportAPIProduct(accessOAuthToken, adminEndpoint, importEnvironment, importPath, apiProductParamsPath,
+		options)
 }
 
-// ImportAPIProduct function is used with import-api-product command
-func ImportAPIProduct(accessOAuthToken, adminEndpoint, importEnvironment, importPath string, importAPIs, importAPIsUpdate,
-		importAPIProductUpdate, importAPIProductPreserveProvider, importAPIProductSkipCleanup bool) error {
+// ImportAPIProduct function is used with import-api-product command. apiProductParamsPath is the path of the
+// api_product_params.yaml, ignored when empty or when the default file is not found
+func ImportAPIProduct(accessOAuthToken, adminEndpoint, importEnvironment, importPath, apiProductParamsPath string,
+	options ImportAPIProductOptions) error {
 	var exportDirectory = filepath.Join(utils.ExportDirectory, utils.ExportedApiProductsDirName)
 
 	resolvedApiProductFilePath, err := resolveImportAPIProductFilePath(importPath, exportDirectory)
@@ -275,7 +303,7 @@ func ImportAPIProduct(accessOAuthToken, adminEndpoint, importEnvironment, import
 		return err
 	}
 	defer func() {
-		if importAPIProductSkipCleanup {
+		if options.SkipCleanup {
 			utils.Logln(utils.LogPrefixInfo+"Leaving", tmpPath)
 			return
 		}
@@ -287,6 +315,26 @@ func ImportAPIProduct(accessOAuthToken, adminEndpoint, importEnvironment, import
 	}()
 	apiProductFilePath := tmpPath
 
+	paramsPath := ""
+	if apiProductParamsPath != "" {
+		paramsPath, err = resolveParamsPath(resolvedApiProductFilePath, apiProductParamsPath, utils.ParamFileAPIProduct)
+		if err != nil && apiProductParamsPath != utils.ParamFileAPIProduct {
+			return err
+		}
+	}
+
+	if options.SkipCleanup {
+		// encrypted values are written decrypted to the workspace, which must not be left on the disk
+		hasSecrets, err := apiProductContainsSecrets(apiProductFilePath, paramsPath)
+		if err != nil {
+			return err
+		}
+		if hasSecrets {
+			return errors.New("--skipCleanup can not be used when the API Product project, its APIs or the params " +
+				"file contain encrypted values, since they would be left decrypted in the temporary files")
+		}
+	}
+
 	// Pre Process dependent APIs
 	err = preProcessDependentAPIs(apiProductFilePath, importEnvironment)
 	if err != nil {
@@ -299,6 +347,20 @@ func ImportAPIProduct(accessOAuthToken, adminEndpoint, importEnvironment, import
 		return err
 	}
 
+	if paramsPath != "" {
+		utils.Logln(utils.LogPrefixInfo + "Injecting parameters to the API Product from " + paramsPath)
+		err = injectParamsToAPIProduct(apiProductFilePath, paramsPath, importEnvironment)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Apply --set and --set-file overrides
+	err = applyOverrides(apiProductFilePath, reflect.TypeOf(v2.APIProductDefinition{}), options.Overrides)
+	if err != nil {
+		return err
+	}
+
 	// Get API Product info
 	apiProductInfo, originalContent, err := getAPIProductDefinition(apiProductFilePath)
 	if err != nil {
@@ -355,7 +417,7 @@ func ImportAPIProduct(accessOAuthToken, adminEndpoint, importEnvironment, import
 			return err
 		}
 		defer func() {
-			if importAPIProductSkipCleanup {
+			if options.SkipCleanup {
 				utils.Logln(utils.LogPrefixInfo+"Leaving", tmp.Name())
 				return
 			}
@@ -369,7 +431,7 @@ func ImportAPIProduct(accessOAuthToken, adminEndpoint, importEnvironment, import
 	}
 
 	updateAPIProduct := false
-	if importAPIsUpdate || importAPIProductUpdate {
+	if options.UpdateAPIs || options.Update {
 		// Check for API Product existence
 		id, err := getApiProductID(apiProductInfo.ID.APIProductName, apiProductInfo.ID.Version, importEnvironment, accessOAuthToken)
 		if err != nil {
@@ -392,16 +454,16 @@ func ImportAPIProduct(accessOAuthToken, adminEndpoint, importEnvironment, import
 	}
 	extraParams := map[string]string{}
 	httpMethod := http.MethodPost
-	adminEndpoint += "/import/api-product" + "?preserveProvider=" + strconv.FormatBool(importAPIProductPreserveProvider)
+	adminEndpoint += "/import/api-product" + "?preserveProvider=" + strconv.FormatBool(options.PreserveProvider)
 
 	// If the user has specified import-apis flag or update-apis flag, importAPIs parameter should be passed as true
 	// because update is also an import task
-	if importAPIs || importAPIsUpdate {
+	if options.ImportAPIs || options.UpdateAPIs {
 		adminEndpoint += "&importAPIs=" + strconv.FormatBool(true)
 	}
 
 	// If the user need to update the APIs and the API Product, overwriteAPIs parameter should be passed as true
-	if importAPIsUpdate {
+	if options.UpdateAPIs {
 		adminEndpoint += "&overwriteAPIs=" + strconv.FormatBool(true)
 	}
 
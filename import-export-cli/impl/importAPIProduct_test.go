@@ -19,10 +19,13 @@
 package impl
 
 import (
+	"encoding/base64"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -53,16 +56,45 @@ func TestImportAPIProduct1(t *testing.T) {
 
 	name := utils.GetRelativeTestDataPathFromImpl() + string(os.PathSeparator) + "MyProduct-1.0.0"
 
-	err := ImportAPIProduct("access_token", server.URL, "test_env", name, false,
-		false, false, true,false)
+	err := ImportAPIProduct("access_token", server.URL, "test_env", name, "",
+		ImportAPIProductOptions{PreserveProvider: true})
 	assert.Nil(t, err, "Error should be nil")
 
 	utils.Insecure = true
-	err = ImportAPIProduct("access_token", server.URL, "test_env", name, false,
-		false, false, true,false)
+	err = ImportAPIProduct("access_token", server.URL, "test_env", name, "",
+		ImportAPIProductOptions{PreserveProvider: true})
 	assert.Nil(t, err, "Error should be nil")
 }
 
+func TestImportAPIProductSkipCleanupWithEncryptedValues(t *testing.T) {
+	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("API Product should not be imported")
+	}))
+	defer server.Close()
+
+	key := []byte("0123456789abcdef0123456789abcdef")
+	token, err := utils.EncryptSecret(key, "s3cret")
+	assert.Nil(t, err)
+	_ = os.Setenv(utils.SecretKeyEnvName, base64.StdEncoding.EncodeToString(key))
+	defer os.Unsetenv(utils.SecretKeyEnvName)
+
+	dir, err := ioutil.TempDir("", "product")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	productPath := filepath.Join(dir, "MyProduct-1.0.0")
+	assert.Nil(t, utils.CopyDir(utils.GetRelativeTestDataPathFromImpl()+"MyProduct-1.0.0", productPath))
+	// the encrypted value is in the params file of a dependent API
+	paramsPath := filepath.Join(productPath, "APIs", "PizzaShackAPI-1.0.0", utils.ParamFileAPI)
+	assert.Nil(t, ioutil.WriteFile(paramsPath, []byte("environments:\n  - name: test_env\n    security:\n"+
+		"      enabled: true\n      type: basic\n      username: admin\n      password: "+token+"\n"), 0644))
+
+	err = ImportAPIProduct("access_token", server.URL, "test_env", productPath, "",
+		ImportAPIProductOptions{PreserveProvider: true, SkipCleanup: true})
+	if assert.NotNil(t, err, "Import should fail") {
+		assert.Contains(t, err.Error(), "--skipCleanup can not be used")
+	}
+}
+
 func TestExtractAPIProductInfoWithCorrectJSON(t *testing.T) {
 	// Correct json
 	content := `{
@@ -19,6 +19,8 @@
 package impl
 
 import (
+	"encoding/base64"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -53,16 +55,36 @@ func TestImportAPI1(t *testing.T) {
 
 	name := utils.GetRelativeTestDataPathFromImpl() + "PizzaShackAPI-1.0.0"
 
-	err := ImportAPI("access_token", server.URL, "testEnv", name, "", false,
-		false, true)
+	err := ImportAPI("access_token", server.URL, "testEnv", name, "", ImportAPIOptions{SkipCleanup: true})
 	assert.Nil(t, err, "Error should be nil")
 
 	utils.Insecure = true
-	err = ImportAPI("access_token", server.URL, "testEnv", name, "", false,
-		false, true)
+	err = ImportAPI("access_token", server.URL, "testEnv", name, "", ImportAPIOptions{SkipCleanup: true})
 	assert.Nil(t, err, "Error should be nil")
 }
 
+func TestImportAPIStoppedByLintErrors(t *testing.T) {
+	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("API should not be imported")
+	}))
+	defer server.Close()
+
+	ruleSet, err := LoadLintRules([]byte(`rules:
+  - id: https-only
+    severity: error
+    given: $.transports
+    then:
+      function: pattern
+      notMatch: (^|,)http(,|$)
+`), "rules.yaml")
+	assert.Nil(t, err)
+	name := utils.GetRelativeTestDataPathFromImpl() + "PizzaShackAPI-1.0.0"
+	err = ImportAPI("access_token", server.URL, "testEnv", name, "", ImportAPIOptions{LintRules: ruleSet})
+	if assert.NotNil(t, err, "Import should fail") {
+		assert.Contains(t, err.Error(), "does not pass 1 lint rule(s)")
+	}
+}
+
 func TestNewFileUploadRequest(t *testing.T) {
 	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPut {
@@ -168,3 +190,75 @@ func TestGetAPIInfoMalformedDirectory(t *testing.T) {
 	assert.Nil(t, api,
 		"Should return nil for malformed directories")
 }
+
+func TestReplaceEnvVariablesWithEncryptedValues(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	token, err := utils.EncryptSecret(key, "s3cret")
+	assert.Nil(t, err)
+	_ = os.Setenv(utils.SecretKeyEnvName, base64.StdEncoding.EncodeToString(key))
+	defer os.Unsetenv(utils.SecretKeyEnvName)
+
+	projectDir, err := ioutil.TempDir("", "project")
+	assert.Nil(t, err)
+	defer os.RemoveAll(projectDir)
+	sequencePath := filepath.Join(projectDir, "Sequences", "in-sequence", "Custom", "auth.xml")
+	assert.Nil(t, os.MkdirAll(filepath.Dir(sequencePath), os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(sequencePath, []byte(`<property name="password" value="`+token+`"/>`), 0644))
+
+	err = replaceEnvVariables(projectDir)
+	assert.Nil(t, err, "Error should be nil")
+	content, err := ioutil.ReadFile(sequencePath)
+	assert.Nil(t, err)
+	assert.Equal(t, `<property name="password" value="s3cret"/>`, string(content))
+}
+
+func TestImportAPISkipCleanupWithEncryptedValues(t *testing.T) {
+	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("API should not be imported")
+	}))
+	defer server.Close()
+
+	key := []byte("0123456789abcdef0123456789abcdef")
+	token, err := utils.EncryptSecret(key, "s3cret")
+	assert.Nil(t, err)
+	_ = os.Setenv(utils.SecretKeyEnvName, base64.StdEncoding.EncodeToString(key))
+	defer os.Unsetenv(utils.SecretKeyEnvName)
+
+	paramsDir, err := ioutil.TempDir("", "params")
+	assert.Nil(t, err)
+	defer os.RemoveAll(paramsDir)
+	paramsPath := filepath.Join(paramsDir, "api_params.yaml")
+	assert.Nil(t, ioutil.WriteFile(paramsPath, []byte("environments:\n  - name: testEnv\n    security:\n"+
+		"      enabled: true\n      type: basic\n      username: admin\n      password: "+token+"\n"), 0644))
+
+	name := utils.GetRelativeTestDataPathFromImpl() + "PizzaShackAPI-1.0.0"
+	err = ImportAPI("access_token", server.URL, "testEnv", name, paramsPath, ImportAPIOptions{SkipCleanup: true})
+	if assert.NotNil(t, err, "Import should fail") {
+		assert.Contains(t, err.Error(), "--skipCleanup can not be used")
+	}
+}
+
+func TestProjectContainsSecrets(t *testing.T) {
+	projectDir, err := ioutil.TempDir("", "project")
+	assert.Nil(t, err)
+	defer os.RemoveAll(projectDir)
+	sequencePath := filepath.Join(projectDir, "Sequences", "in-sequence", "Custom", "auth.xml")
+	assert.Nil(t, os.MkdirAll(filepath.Dir(sequencePath), os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(sequencePath, []byte(`<property name="password" value="${PASSWORD}"/>`), 0644))
+
+	found, err := projectContainsSecrets(projectDir, "")
+	assert.Nil(t, err)
+	assert.False(t, found)
+
+	_ = os.Setenv("PASSWORD", utils.SecretPrefix+"9Zt2")
+	defer os.Unsetenv("PASSWORD")
+	found, err = projectContainsSecrets(projectDir, "")
+	assert.Nil(t, err)
+	assert.True(t, found, "Encrypted values given in environment variables should be found")
+
+	// $VAR is not substituted in the project files
+	assert.Nil(t, ioutil.WriteFile(sequencePath, []byte(`<property name="password" value="$PASSWORD"/>`), 0644))
+	found, err = projectContainsSecrets(projectDir, "")
+	assert.Nil(t, err)
+	assert.False(t, found, "Variables which are not substituted in the project files should be ignored")
+}
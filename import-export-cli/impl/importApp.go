@@ -20,9 +20,9 @@ package impl
 
 import (
 	"bytes"
-	"crypto/tls"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"mime/multipart"
 	"net/http"
 	"os"
@@ -38,28 +38,31 @@ import (
 // @param environment: Environment to import the application
 // @param filename: name of the application (zipped file) to be imported
 // @param appOwner: Owner of the application
+// @param appParamsPath: Path of the app_params.yaml, ignored when empty
 // @param updateApplication: Update the application if it already exists
 // @param preserveOwner: Preserve the owner after importing the application
 // @param skipSubscriptions: Skip importing subscriptions
 // @param skipKeys: skip importing keys of application
-func ImportApplicationToEnv(accessToken, environment, filename, appOwner string, updateApplication, preserveOwner,
-	skipSubscriptions, skipKeys bool) (*http.Response, error) {
+func ImportApplicationToEnv(accessToken, environment, filename, appOwner, appParamsPath string, updateApplication,
+	preserveOwner, skipSubscriptions, skipKeys bool) (*http.Response, error) {
 	adminEndpoint := utils.GetAdminEndpointOfEnv(environment, utils.MainConfigFilePath)
-	return ImportApplication(accessToken, adminEndpoint, filename, appOwner, updateApplication, preserveOwner,
-		skipSubscriptions, skipKeys)
+	return ImportApplication(accessToken, adminEndpoint, environment, filename, appOwner, appParamsPath,
+		updateApplication, preserveOwner, skipSubscriptions, skipKeys)
 }
 
 // ImportApplication function is used with import-app command
 // @param accessToken: OAuth2.0 access token for the resource being accessed
 // @param adminEndpoint: Admin REST API endpoint to use for importing the application
+// @param environment: Environment to import the application, used to select the parameters in app_params.yaml
 // @param filename: name of the application (zipped file) to be imported
 // @param appOwner: Owner of the application
+// @param appParamsPath: Path of the app_params.yaml, ignored when empty
 // @param updateApplication: Update the application if it already exists
 // @param preserveOwner: Preserve the owner after importing the application
 // @param skipSubscriptions: Skip importing subscriptions
 // @param skipKeys: skip importing keys of application
-func ImportApplication(accessToken, adminEndpoint, filename, appOwner string, updateApplication, preserveOwner,
-	skipSubscriptions, skipKeys bool) (*http.Response, error) {
+func ImportApplication(accessToken, adminEndpoint, environment, filename, appOwner, appParamsPath string,
+	updateApplication, preserveOwner, skipSubscriptions, skipKeys bool) (*http.Response, error) {
 
 	exportDirectory := filepath.Join(utils.ExportDirectory, utils.ExportedAppsDirName)
 	adminEndpoint = utils.AppendSlashToString(adminEndpoint)
@@ -77,6 +80,30 @@ func ImportApplication(accessToken, adminEndpoint, filename, appOwner string, up
 	}
 	fmt.Println("ZipFilePath:", zipFilePath)
 
+	if appParamsPath != "" {
+		paramsPath, err := resolveParamsPath(zipFilePath, appParamsPath, utils.ParamFileApp)
+		if err != nil && appParamsPath != utils.ParamFileApp {
+			return nil, err
+		}
+		if paramsPath != "" {
+			utils.Logln(utils.LogPrefixInfo + "Creating workspace")
+			workspace, err := ioutil.TempDir("", "apim")
+			if err != nil {
+				return nil, err
+			}
+			defer func() {
+				utils.Logln(utils.LogPrefixInfo+"Deleting", workspace)
+				if err := os.RemoveAll(workspace); err != nil {
+					utils.Logln(utils.LogPrefixError + err.Error())
+				}
+			}()
+			zipFilePath, err = injectParamsToApp(zipFilePath, paramsPath, environment, workspace)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	extraParams := map[string]string{}
 
 	req, err := NewAppFileUploadRequest(url, extraParams, "file", zipFilePath, accessToken)
@@ -84,21 +111,7 @@ func ImportApplication(accessToken, adminEndpoint, filename, appOwner string, up
 		utils.HandleErrorAndExit("Error creating request.", err)
 	}
 
-	var tr *http.Transport
-	if utils.Insecure {
-		tr = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		}
-	} else {
-		tr = &http.Transport{
-			TLSClientConfig: utils.GetTlsConfigWithCertificate(),
-		}
-	}
-
-	client := &http.Client{
-		Transport: tr,
-		Timeout:   time.Duration(utils.HttpRequestTimeout) * time.Second,
-	}
+	client := utils.NewHttpClient(time.Duration(utils.HttpRequestTimeout) * time.Second)
 
 	resp, err := client.Do(req)
 
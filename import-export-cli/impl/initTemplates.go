@@ -0,0 +1,303 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// TemplateProjectNameVar is the variable which is always available to templates with the name of the project
+const TemplateProjectNameVar = "ProjectName"
+
+// TemplateAPIDefinitionPath is the path of the API definition in a template, which is merged instead of being copied
+const TemplateAPIDefinitionPath = "Meta-information/api.yaml"
+
+// InitTemplate is a directory with the files of an API project which are rendered with Go templates by init
+type InitTemplate struct {
+	Name        string              `yaml:"-"`
+	Path        string              `yaml:"-"`
+	Description string              `yaml:"description"`
+	Variables   []*TemplateVariable `yaml:"variables"`
+}
+
+// TemplateVariable is a variable declared in the manifest of a template
+type TemplateVariable struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Default     string `yaml:"default"`
+}
+
+// LoadInitTemplate loads the template in templateDir along with its manifest (template.yaml), if it has one
+func LoadInitTemplate(templateDir string) (*InitTemplate, error) {
+	info, err := os.Stat(templateDir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", templateDir)
+	}
+
+	t := &InitTemplate{}
+	manifestPath := filepath.Join(templateDir, utils.TemplateManifestFileName)
+	if fileExists(manifestPath) {
+		content, err := ioutil.ReadFile(manifestPath)
+		if err != nil {
+			return nil, err
+		}
+		if err = yaml.UnmarshalStrict(content, t); err != nil {
+			return nil, fmt.Errorf("invalid template manifest %s: %v", manifestPath, err)
+		}
+		for _, variable := range t.Variables {
+			if variable.Name == "" {
+				return nil, fmt.Errorf("invalid template manifest %s: variables should have a name", manifestPath)
+			}
+		}
+	}
+	t.Name = filepath.Base(templateDir)
+	t.Path = templateDir
+	return t, nil
+}
+
+// ResolveInitTemplate finds the template given with nameOrPath, which is either the path of a template directory or
+// the name of a template registered in the templates directory of the config dir
+func ResolveInitTemplate(nameOrPath string) (*InitTemplate, error) {
+	if info, err := os.Stat(nameOrPath); err == nil && info.IsDir() {
+		return LoadInitTemplate(nameOrPath)
+	}
+	if strings.ContainsAny(nameOrPath, `/\`) {
+		return nil, fmt.Errorf("template directory %s not found", nameOrPath)
+	}
+	registered := filepath.Join(utils.DefaultTemplatesDirPath, nameOrPath)
+	if info, err := os.Stat(registered); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("template %s is not registered in %s", nameOrPath, utils.DefaultTemplatesDirPath)
+	}
+	return LoadInitTemplate(registered)
+}
+
+// ListInitTemplates lists the templates registered in templatesDir sorted by name
+func ListInitTemplates(templatesDir string) ([]*InitTemplate, error) {
+	entries, err := ioutil.ReadDir(templatesDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var templates []*InitTemplate
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		t, err := LoadInitTemplate(filepath.Join(templatesDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
+
+// ResolveVariables returns the values of the template variables. Values in given are used first, variables declared
+// in the manifest without a given value are asked with prompt and fall back to their defaults. prompt can be nil when
+// input cannot be read, in which case only the defaults are used
+func (t *InitTemplate) ResolveVariables(given map[string]string,
+	prompt func(variable *TemplateVariable) (string, error)) (map[string]string, error) {
+	vars := make(map[string]string, len(given))
+	for name, value := range given {
+		vars[name] = value
+	}
+
+	var missing []string
+	for _, variable := range t.Variables {
+		if _, ok := vars[variable.Name]; ok {
+			continue
+		}
+		value := ""
+		if prompt != nil {
+			var err error
+			value, err = prompt(variable)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if value == "" {
+			value = variable.Default
+		}
+		if value == "" {
+			missing = append(missing, variable.Name)
+			continue
+		}
+		vars[variable.Name] = value
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("values are required for template variable(s): %s", strings.Join(missing, ", "))
+	}
+	return vars, nil
+}
+
+// Render renders the names and the content of the files in the template using vars and the name of the project.
+// Files which are not text are copied as they are. Returns the content of the files keyed by their slash separated
+// path relative to the project, or an error if a rendered path is outside of the project
+func (t *InitTemplate) Render(projectName string, vars map[string]string) (map[string][]byte, error) {
+	data := map[string]string{TemplateProjectNameVar: projectName}
+	for name, value := range vars {
+		data[name] = value
+	}
+
+	files := make(map[string][]byte)
+	err := filepath.Walk(t.Path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(t.Path, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == utils.TemplateManifestFileName {
+			return nil
+		}
+
+		renderedPath, err := renderTemplate(rel, []byte(rel), data)
+		if err != nil {
+			return err
+		}
+		// rendered paths are checked before the project is created, so that nothing is written for a template with
+		// files outside of the project
+		if _, err = templateFilePath("", string(renderedPath)); err != nil {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if utf8.Valid(content) && !bytes.ContainsRune(content, 0) {
+			if content, err = renderTemplate(rel, content, data); err != nil {
+				return err
+			}
+		}
+		files[string(renderedPath)] = content
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// renderTemplate executes text as a Go template which fails on variables without a value
+func renderTemplate(name string, text []byte, data map[string]string) ([]byte, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(string(text))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// templateFilePath returns the path of the rendered file path inside projectDir. Paths which are absolute or escape
+// projectDir are rejected
+func templateFilePath(projectDir, path string) (string, error) {
+	cleanPath := filepath.Clean(filepath.FromSlash(path))
+	if filepath.IsAbs(cleanPath) || filepath.VolumeName(cleanPath) != "" || cleanPath == "." ||
+		cleanPath == ".." || strings.HasPrefix(cleanPath, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("template file %s is outside of the project", path)
+	}
+	return filepath.Join(projectDir, cleanPath), nil
+}
+
+// WriteTemplateFiles writes files rendered from a template to projectDir, replacing the files which exist.
+// Nothing is written if a file is outside of projectDir
+func WriteTemplateFiles(projectDir string, files map[string][]byte) error {
+	paths := make([]string, 0, len(files))
+	filePaths := make(map[string]string, len(files))
+	for path := range files {
+		filePath, err := templateFilePath(projectDir, path)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, path)
+		filePaths[path] = filePath
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		filePath := filePaths[path]
+		utils.Logln(utils.LogPrefixInfo + "Writing " + filePath)
+		if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filePath, files[path], os.ModePerm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MergeAPIDefinition merges the YAML API definition in content on top of def
+func MergeAPIDefinition(def *v2.APIDefinition, content []byte) (*v2.APIDefinition, error) {
+	apiDef := &v2.APIDefinition{}
+	err := yaml.Unmarshal(content, &apiDef)
+	if err != nil {
+		return nil, err
+	}
+
+	originalDefBytes, err := json.Marshal(def)
+	if err != nil {
+		return nil, err
+	}
+	newDefBytes, err := json.Marshal(apiDef)
+	if err != nil {
+		return nil, err
+	}
+
+	finalDefBytes, err := utils.MergeJSON(originalDefBytes, newDefBytes)
+	if err != nil {
+		return nil, err
+	}
+	mergedDef := &v2.APIDefinition{}
+	err = json.Unmarshal(finalDefBytes, &mergedDef)
+	if err != nil {
+		return nil, err
+	}
+	return mergedDef, nil
+}
@@ -0,0 +1,209 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+)
+
+const initTemplateManifest = `description: Organisation REST API
+variables:
+  - name: Team
+    description: Owning team
+  - name: Owner
+    default: apis@example.com
+`
+
+func TestLoadInitTemplate(t *testing.T) {
+	templateDir := createValidateTestProject(t, map[string]string{"template.yaml": initTemplateManifest})
+	defer os.RemoveAll(templateDir)
+
+	tmpl, err := LoadInitTemplate(templateDir)
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Base(templateDir), tmpl.Name)
+	assert.Equal(t, "Organisation REST API", tmpl.Description)
+	if assert.Len(t, tmpl.Variables, 2) {
+		assert.Equal(t, "Team", tmpl.Variables[0].Name)
+		assert.Equal(t, "apis@example.com", tmpl.Variables[1].Default)
+	}
+}
+
+func TestLoadInitTemplateWithInvalidManifest(t *testing.T) {
+	templateDir := createValidateTestProject(t, map[string]string{"template.yaml": "variables:\n  - default: x\n"})
+	defer os.RemoveAll(templateDir)
+
+	_, err := LoadInitTemplate(templateDir)
+	assert.NotNil(t, err, "Variables without a name should not be allowed")
+}
+
+func TestListInitTemplates(t *testing.T) {
+	templatesDir := createValidateTestProject(t, map[string]string{
+		"rest/template.yaml": initTemplateManifest,
+		"basic/README.md":    "# {{.ProjectName}}",
+		"notes.txt":          "not a template",
+	})
+	defer os.RemoveAll(templatesDir)
+
+	templates, err := ListInitTemplates(templatesDir)
+	assert.Nil(t, err)
+	if assert.Len(t, templates, 2) {
+		assert.Equal(t, "basic", templates[0].Name)
+		assert.Equal(t, "rest", templates[1].Name)
+		assert.Equal(t, "Organisation REST API", templates[1].Description)
+	}
+
+	templates, err = ListInitTemplates(filepath.Join(templatesDir, "missing"))
+	assert.Nil(t, err)
+	assert.Empty(t, templates)
+}
+
+func TestResolveInitTemplateWithUnknownName(t *testing.T) {
+	_, err := ResolveInitTemplate("no-such-template")
+	assert.NotNil(t, err)
+}
+
+func TestResolveTemplateVariables(t *testing.T) {
+	tmpl := &InitTemplate{Variables: []*TemplateVariable{{Name: "Team"}, {Name: "Owner", Default: "apis@example.com"}}}
+
+	vars, err := tmpl.ResolveVariables(map[string]string{"Team": "payments", "Extra": "x"}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"Team": "payments", "Owner": "apis@example.com", "Extra": "x"}, vars)
+
+	var prompted []string
+	vars, err = tmpl.ResolveVariables(nil, func(variable *TemplateVariable) (string, error) {
+		prompted = append(prompted, variable.Name)
+		if variable.Name == "Team" {
+			return "orders", nil
+		}
+		return "", nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Team", "Owner"}, prompted)
+	assert.Equal(t, map[string]string{"Team": "orders", "Owner": "apis@example.com"}, vars)
+
+	_, err = tmpl.ResolveVariables(nil, nil)
+	if assert.NotNil(t, err, "Variables without a value should not be allowed") {
+		assert.Contains(t, err.Error(), "Team")
+	}
+
+	_, err = tmpl.ResolveVariables(nil, func(variable *TemplateVariable) (string, error) {
+		return "", errors.New("EOF")
+	})
+	assert.NotNil(t, err)
+}
+
+func TestRenderInitTemplate(t *testing.T) {
+	templateDir := createValidateTestProject(t, map[string]string{
+		"template.yaml":                  initTemplateManifest,
+		"Meta-information/api.yaml":      "businessOwner: {{.Team}}\nbusinessOwnerEmail: {{.Owner}}\n",
+		"Docs/{{.ProjectName}}-guide.md": "# {{.ProjectName}} by {{.Team}}",
+		"Image/icon.png":                 "\x89PNG\x00{{.Team}}",
+	})
+	defer os.RemoveAll(templateDir)
+
+	tmpl, err := LoadInitTemplate(templateDir)
+	assert.Nil(t, err)
+	files, err := tmpl.Render("Orders", map[string]string{"Team": "payments", "Owner": "jane@example.com"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]byte{
+		"Meta-information/api.yaml": []byte("businessOwner: payments\nbusinessOwnerEmail: jane@example.com\n"),
+		"Docs/Orders-guide.md":      []byte("# Orders by payments"),
+		"Image/icon.png":            []byte("\x89PNG\x00{{.Team}}"),
+	}, files)
+
+	_, err = tmpl.Render("Orders", map[string]string{"Team": "payments"})
+	assert.NotNil(t, err, "Rendering should fail when a variable has no value")
+}
+
+func TestRenderInitTemplateOutsideOfProject(t *testing.T) {
+	templateDir := createValidateTestProject(t, map[string]string{
+		"template.yaml":            initTemplateManifest,
+		"README.md":                "# {{.ProjectName}}",
+		"{{.Team}}/{{.Owner}}.txt": "escaped",
+	})
+	defer os.RemoveAll(templateDir)
+
+	tmpl, err := LoadInitTemplate(templateDir)
+	assert.Nil(t, err)
+	for _, team := range []string{"..", "Docs/../..", "/tmp"} {
+		_, err = tmpl.Render("Orders", map[string]string{"Team": team, "Owner": "escaped"})
+		if assert.NotNil(t, err, team) {
+			assert.Contains(t, err.Error(), "is outside of the project", team)
+		}
+	}
+
+	files, err := tmpl.Render("Orders", map[string]string{"Team": "Docs", "Owner": "owner"})
+	assert.Nil(t, err)
+	assert.Contains(t, files, "Docs/owner.txt")
+}
+
+func TestWriteTemplateFiles(t *testing.T) {
+	projectDir := createValidateTestProject(t, map[string]string{"README.md": "default"})
+	defer os.RemoveAll(projectDir)
+
+	err := WriteTemplateFiles(projectDir, map[string][]byte{
+		"README.md":                          []byte("template"),
+		"Sequences/in-sequence/Custom/a.xml": []byte("<sequence/>"),
+	})
+	assert.Nil(t, err)
+	assert.FileExists(t, filepath.Join(projectDir, "Sequences", "in-sequence", "Custom", "a.xml"))
+	content, err := ioutil.ReadFile(filepath.Join(projectDir, "README.md"))
+	assert.Nil(t, err)
+	assert.Equal(t, "template", string(content))
+}
+
+func TestWriteTemplateFilesOutsideOfProject(t *testing.T) {
+	workspace, err := ioutil.TempDir("", "templates")
+	assert.Nil(t, err)
+	defer os.RemoveAll(workspace)
+	projectDir := filepath.Join(workspace, "project")
+
+	for _, path := range []string{"../escaped.txt", "Docs/../../escaped.txt", "/tmp/escaped.txt", "..", "."} {
+		err := WriteTemplateFiles(projectDir, map[string][]byte{
+			"README.md": []byte("template"),
+			path:        []byte("escaped"),
+		})
+		if assert.NotNil(t, err, path) {
+			assert.Contains(t, err.Error(), "is outside of the project", path)
+		}
+	}
+	_, err = os.Stat(filepath.Join(workspace, "escaped.txt"))
+	assert.True(t, os.IsNotExist(err), "File outside of the project should not be written")
+	_, err = os.Stat(projectDir)
+	assert.True(t, os.IsNotExist(err), "Nothing should be written when a file is outside of the project")
+
+	assert.Nil(t, WriteTemplateFiles(projectDir, map[string][]byte{"Docs/../README.md": []byte("template")}))
+	assert.FileExists(t, filepath.Join(projectDir, "README.md"))
+}
+
+func TestMergeAPIDefinition(t *testing.T) {
+	def := &v2.APIDefinition{ID: v2.ID{APIName: "Orders", Version: "1.0.0"}, Context: "/orders"}
+	merged, err := MergeAPIDefinition(def, []byte("businessOwner: payments\ncontext: /v1/orders\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, "Orders", merged.ID.APIName)
+	assert.Equal(t, "/v1/orders", merged.Context)
+	assert.Equal(t, "payments", merged.BusinessOwner)
+}
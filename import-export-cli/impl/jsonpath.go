@@ -0,0 +1,265 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonPathIdentifier matches member names which can be written in dot notation
+var jsonPathIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// jsonPath is a compiled JSONPath expression. Only the subset needed to select values of api.yaml and the swagger is
+// supported: the root ($), members (.name and ['name']), wildcards (.* and [*]) and unions of members
+// (['get','post'])
+type jsonPath struct {
+	expr  string
+	steps []jsonPathStep
+}
+
+// jsonPathStep selects children of the nodes matched by the previous step, either all of them or the named members
+type jsonPathStep struct {
+	wildcard bool
+	names    []string
+}
+
+// jsonPathMatch is a value matched by a JSONPath. Key is the member name or index of the value in its parent
+type jsonPathMatch struct {
+	Path  string
+	Key   string
+	Value interface{}
+}
+
+// compileJSONPath compiles expr, which should start with $
+func compileJSONPath(expr string) (*jsonPath, error) {
+	p := &jsonPathParser{input: strings.TrimSpace(expr)}
+	path, err := p.parsePath()
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSONPath %q: %v", expr, err)
+	}
+	path.expr = expr
+	return path, nil
+}
+
+// evaluate returns the values of doc matched by the path, in document order with members sorted by name
+func (path *jsonPath) evaluate(doc interface{}) []jsonPathMatch {
+	matches := []jsonPathMatch{{Path: "$", Value: doc}}
+	for _, step := range path.steps {
+		var next []jsonPathMatch
+		for _, match := range matches {
+			next = append(next, step.selectChildren(match)...)
+		}
+		matches = next
+	}
+	return matches
+}
+
+// jsonPathChildren returns the members of an object, sorted by name, or the elements of an array
+func jsonPathChildren(match jsonPathMatch) []jsonPathMatch {
+	switch value := match.Value.(type) {
+	case map[string]interface{}:
+		names := make([]string, 0, len(value))
+		for name := range value {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		result := make([]jsonPathMatch, len(names))
+		for i, name := range names {
+			result[i] = jsonPathMember(match, name, value[name])
+		}
+		return result
+	case []interface{}:
+		result := make([]jsonPathMatch, len(value))
+		for i, element := range value {
+			result[i] = jsonPathMatch{Path: fmt.Sprintf("%s[%d]", match.Path, i), Key: strconv.Itoa(i),
+				Value: element}
+		}
+		return result
+	}
+	return nil
+}
+
+func jsonPathMember(parent jsonPathMatch, name string, value interface{}) jsonPathMatch {
+	path := parent.Path + "['" + strings.Replace(name, "'", `\'`, -1) + "']"
+	if jsonPathIdentifier.MatchString(name) {
+		path = parent.Path + "." + name
+	}
+	return jsonPathMatch{Path: path, Key: name, Value: value}
+}
+
+// selectChildren returns the children of match selected by the step
+func (step *jsonPathStep) selectChildren(match jsonPathMatch) []jsonPathMatch {
+	if step.wildcard {
+		return jsonPathChildren(match)
+	}
+	object, ok := match.Value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	var result []jsonPathMatch
+	for _, name := range step.names {
+		if value, ok := object[name]; ok {
+			result = append(result, jsonPathMember(match, name, value))
+		}
+	}
+	return result
+}
+
+// jsonPathTruthy returns false for nil, false, zero, empty strings and empty collections
+func jsonPathTruthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	case map[string]interface{}:
+		return len(v) > 0
+	}
+	return true
+}
+
+// jsonPathParser parses JSONPath expressions
+type jsonPathParser struct {
+	input string
+	pos   int
+}
+
+func (p *jsonPathParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%s at %d", fmt.Sprintf(format, args...), p.pos+1)
+}
+
+func (p *jsonPathParser) peek(s string) bool {
+	return strings.HasPrefix(p.input[p.pos:], s)
+}
+
+func (p *jsonPathParser) skipSpaces() {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// parsePath parses the whole input as a path starting with $
+func (p *jsonPathParser) parsePath() (*jsonPath, error) {
+	if !p.peek("$") {
+		return nil, p.errorf("expected $")
+	}
+	p.pos++
+	path := &jsonPath{}
+	for p.pos < len(p.input) {
+		step := jsonPathStep{}
+		switch {
+		case p.peek(".."):
+			return nil, p.errorf("recursive descent is not supported")
+		case p.peek("."):
+			p.pos++
+			if err := p.parseDotMember(&step); err != nil {
+				return nil, err
+			}
+		case p.peek("["):
+			if err := p.parseBracket(&step); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, p.errorf("unexpected %q", p.input[p.pos:])
+		}
+		path.steps = append(path.steps, step)
+	}
+	return path, nil
+}
+
+func (p *jsonPathParser) parseDotMember(step *jsonPathStep) error {
+	if p.peek("*") {
+		p.pos++
+		step.wildcard = true
+		return nil
+	}
+	start := p.pos
+	for p.pos < len(p.input) && !strings.ContainsRune(".[ ", rune(p.input[p.pos])) {
+		p.pos++
+	}
+	if start == p.pos {
+		return p.errorf("expected a member name")
+	}
+	step.names = []string{p.input[start:p.pos]}
+	return nil
+}
+
+// parseBracket parses [*] or a union of quoted member names
+func (p *jsonPathParser) parseBracket(step *jsonPathStep) error {
+	p.pos++
+	p.skipSpaces()
+	if p.peek("*") {
+		p.pos++
+		step.wildcard = true
+	} else {
+		for {
+			p.skipSpaces()
+			if !p.peek("'") && !p.peek(`"`) {
+				return p.errorf("expected * or a quoted member name")
+			}
+			name, err := p.parseString()
+			if err != nil {
+				return err
+			}
+			step.names = append(step.names, name)
+			p.skipSpaces()
+			if !p.peek(",") {
+				break
+			}
+			p.pos++
+		}
+	}
+	p.skipSpaces()
+	if !p.peek("]") {
+		return p.errorf("expected ]")
+	}
+	p.pos++
+	return nil
+}
+
+// parseString parses a string quoted with ' or " where \ escapes the next character
+func (p *jsonPathParser) parseString() (string, error) {
+	quote := p.input[p.pos]
+	p.pos++
+	var sb strings.Builder
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		p.pos++
+		switch {
+		case c == '\\' && p.pos < len(p.input):
+			sb.WriteByte(p.input[p.pos])
+			p.pos++
+		case c == quote:
+			return sb.String(), nil
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
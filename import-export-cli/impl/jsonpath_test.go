@@ -0,0 +1,92 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const jsonPathTestDocument = `{
+  "transports": "http,https",
+  "uriTemplates": [
+    {"uriTemplate": "/order", "httpVerb": "POST", "authType": "None", "throttlingTier": "Gold"},
+    {"uriTemplate": "/order/{orderId}", "httpVerb": "GET", "authType": "Any"},
+    {"uriTemplate": "/menu", "httpVerb": "GET", "authType": "None", "count": 3}
+  ],
+  "paths": {
+    "/menu": {"get": {"description": "Menu"}},
+    "/order": {"post": {"x-auth-type": "None"}, "put": {}}
+  }
+}`
+
+func evaluateJSONPath(t *testing.T, expr string) []jsonPathMatch {
+	var doc interface{}
+	assert.Nil(t, json.Unmarshal([]byte(jsonPathTestDocument), &doc))
+	path, err := compileJSONPath(expr)
+	if !assert.Nil(t, err, expr) {
+		return nil
+	}
+	return path.evaluate(doc)
+}
+
+func jsonPathMatchPaths(matches []jsonPathMatch) []string {
+	paths := make([]string, len(matches))
+	for i, match := range matches {
+		paths[i] = match.Path
+	}
+	return paths
+}
+
+func TestJSONPathEvaluate(t *testing.T) {
+	tests := []struct {
+		expr  string
+		paths []string
+	}{
+		{"$", []string{"$"}},
+		{"$.transports", []string{"$.transports"}},
+		{"$['transports']", []string{"$.transports"}},
+		{"$.paths.*", []string{"$.paths['/menu']", "$.paths['/order']"}},
+		{"$.paths.*['post','put']", []string{"$.paths['/order'].post", "$.paths['/order'].put"}},
+		{"$.paths['/order'][\"post\"].x-auth-type", []string{"$.paths['/order'].post.x-auth-type"}},
+		{"$.uriTemplates[*].count", []string{"$.uriTemplates[2].count"}},
+		{"$.uriTemplates.*.httpVerb", []string{"$.uriTemplates[0].httpVerb", "$.uriTemplates[1].httpVerb",
+			"$.uriTemplates[2].httpVerb"}},
+		{"$.missing.field", []string{}},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.paths, jsonPathMatchPaths(evaluateJSONPath(t, test.expr)), test.expr)
+	}
+}
+
+func TestJSONPathMatchKeys(t *testing.T) {
+	matches := evaluateJSONPath(t, "$.paths.*")
+	assert.Equal(t, "/menu", matches[0].Key)
+	assert.Equal(t, "/order", matches[1].Key)
+}
+
+func TestCompileJSONPathErrors(t *testing.T) {
+	for _, expr := range []string{"paths", "$.", "$[", "$['a'", "$.a b", "$['a',0]", "$..a", "$.a[0]",
+		"$[?(@.a)]"} {
+		_, err := compileJSONPath(expr)
+		assert.NotNil(t, err, expr)
+	}
+}
@@ -0,0 +1,479 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// severities of lint rules. Rules with the severity off are not evaluated
+const (
+	LintSeverityError   = "error"
+	LintSeverityWarning = "warning"
+	LintSeverityInfo    = "info"
+	LintSeverityOff     = "off"
+)
+
+// documents of an API project evaluated by lint rules
+const (
+	LintTargetAPI     = "api"
+	LintTargetSwagger = "swagger"
+)
+
+// formats of lint reports
+const (
+	LintFormatText  = "text"
+	LintFormatJSON  = "json"
+	LintFormatSARIF = "sarif"
+)
+
+// lintCasings are the patterns of the casings supported by the casing function
+var lintCasings = map[string]*regexp.Regexp{
+	"kebab":  regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`),
+	"snake":  regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`),
+	"camel":  regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`),
+	"pascal": regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`),
+	"macro":  regexp.MustCompile(`^[A-Z0-9]+(_[A-Z0-9]+)*$`),
+}
+
+// LintRuleSet is a set of lint rules read from rule files
+type LintRuleSet struct {
+	Rules []*LintRule `yaml:"rules"`
+}
+
+// LintRule checks the values selected by Given, a JSONPath, in the api.yaml or the swagger of an API project
+type LintRule struct {
+	ID          string    `yaml:"id"`
+	Description string    `yaml:"description"`
+	Message     string    `yaml:"message"`
+	Severity    string    `yaml:"severity"`
+	Target      string    `yaml:"target"`
+	Given       string    `yaml:"given"`
+	Then        *LintThen `yaml:"then"`
+
+	source   string
+	given    *jsonPath
+	field    *jsonPath
+	match    *regexp.Regexp
+	notMatch *regexp.Regexp
+}
+
+// LintThen is the check done on each value selected by a rule. Field selects a member of the value, or its key
+// when it is @key. Function is one of truthy, falsy, defined, undefined, pattern, enumeration or casing
+type LintThen struct {
+	Field    string   `yaml:"field"`
+	Function string   `yaml:"function"`
+	Match    string   `yaml:"match"`
+	NotMatch string   `yaml:"notMatch"`
+	Values   []string `yaml:"values"`
+	Casing   string   `yaml:"casing"`
+}
+
+// LintIssue is a value of an API project which does not pass a rule. File is relative to the project and Path is the
+// JSONPath of the value in the file
+type LintIssue struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	File     string `json:"file"`
+	Path     string `json:"path"`
+}
+
+// LintReport is the result of linting an API project
+type LintReport struct {
+	Project  string      `json:"project"`
+	Errors   int         `json:"errors"`
+	Warnings int         `json:"warnings"`
+	Infos    int         `json:"infos"`
+	Issues   []LintIssue `json:"issues"`
+
+	rules []*LintRule
+}
+
+// LoadLintRules reads the rules of a rule file written in YAML. source is used in error messages. The rules are
+// validated by Compile
+func LoadLintRules(content []byte, source string) (*LintRuleSet, error) {
+	ruleSet := &LintRuleSet{}
+	if err := yaml.UnmarshalStrict(content, ruleSet); err != nil {
+		return nil, fmt.Errorf("error reading lint rules %s: %v", source, err)
+	}
+	var errorResults error
+	ids := make(map[string]bool)
+	for i, rule := range ruleSet.Rules {
+		if rule == nil {
+			continue
+		}
+		rule.source = source
+		if rule.ID == "" {
+			errorResults = multierror.Append(errorResults, fmt.Errorf("%s: rule %d does not have an id", source,
+				i+1))
+			continue
+		}
+		if ids[rule.ID] {
+			errorResults = multierror.Append(errorResults, fmt.Errorf("%s: rule %s is defined more than once",
+				source, rule.ID))
+		}
+		ids[rule.ID] = true
+	}
+	if errorResults != nil {
+		return nil, errorResults
+	}
+	return ruleSet, nil
+}
+
+// Compile validates the rules and compiles their JSONPaths and patterns
+func (ruleSet *LintRuleSet) Compile() error {
+	var errorResults error
+	for _, rule := range ruleSet.Rules {
+		if rule == nil {
+			continue
+		}
+		if err := rule.compile(); err != nil {
+			errorResults = multierror.Append(errorResults, fmt.Errorf("%s: rule %s: %v", rule.source, rule.ID,
+				err))
+		}
+	}
+	return errorResults
+}
+
+// compile validates the rule and compiles its JSONPaths and patterns
+func (rule *LintRule) compile() error {
+	switch rule.Severity {
+	case "":
+		rule.Severity = LintSeverityWarning
+	case LintSeverityError, LintSeverityWarning, LintSeverityInfo, LintSeverityOff:
+	default:
+		return fmt.Errorf("severity %q should be either error, warning, info or off", rule.Severity)
+	}
+	if rule.Severity == LintSeverityOff {
+		return nil
+	}
+	switch rule.Target {
+	case "":
+		rule.Target = LintTargetAPI
+	case LintTargetAPI, LintTargetSwagger:
+	default:
+		return fmt.Errorf("target %q should be either api or swagger", rule.Target)
+	}
+	if rule.Given == "" {
+		return fmt.Errorf("given is required")
+	}
+	var err error
+	if rule.given, err = compileJSONPath(rule.Given); err != nil {
+		return err
+	}
+	if rule.Then == nil {
+		return fmt.Errorf("then is required")
+	}
+
+	if field := rule.Then.Field; field != "" && field != "@key" {
+		if strings.HasPrefix(field, "[") {
+			rule.field, err = compileJSONPath("$" + field)
+		} else {
+			rule.field, err = compileJSONPath("$." + field)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	switch rule.Then.Function {
+	case "truthy", "falsy", "defined", "undefined":
+	case "pattern":
+		if rule.Then.Match == "" && rule.Then.NotMatch == "" {
+			return fmt.Errorf("pattern requires match or notMatch")
+		}
+		if rule.Then.Match != "" {
+			if rule.match, err = regexp.Compile(rule.Then.Match); err != nil {
+				return fmt.Errorf("invalid match: %v", err)
+			}
+		}
+		if rule.Then.NotMatch != "" {
+			if rule.notMatch, err = regexp.Compile(rule.Then.NotMatch); err != nil {
+				return fmt.Errorf("invalid notMatch: %v", err)
+			}
+		}
+	case "enumeration":
+		if len(rule.Then.Values) == 0 {
+			return fmt.Errorf("enumeration requires values")
+		}
+	case "casing":
+		if _, ok := lintCasings[rule.Then.Casing]; !ok {
+			return fmt.Errorf("casing %q should be either kebab, snake, camel, pascal or macro", rule.Then.Casing)
+		}
+	default:
+		return fmt.Errorf("function %q should be either truthy, falsy, defined, undefined, pattern, enumeration "+
+			"or casing", rule.Then.Function)
+	}
+	return nil
+}
+
+// Merge adds the rules of other to the rule set. Rules of other replace the rules with the same id. A rule without
+// given and then only changes the severity, description or message of the rule it replaces, so a rule file can turn
+// off a rule with just its id and the severity off
+func (ruleSet *LintRuleSet) Merge(other *LintRuleSet) {
+	for _, rule := range other.Rules {
+		if rule == nil {
+			continue
+		}
+		replaced := false
+		for i, existing := range ruleSet.Rules {
+			if existing == nil || existing.ID != rule.ID {
+				continue
+			}
+			if rule.Given == "" && rule.Then == nil {
+				changed := *existing
+				if rule.Severity != "" {
+					changed.Severity = rule.Severity
+				}
+				if rule.Description != "" {
+					changed.Description = rule.Description
+				}
+				if rule.Message != "" {
+					changed.Message = rule.Message
+				}
+				rule = &changed
+			}
+			ruleSet.Rules[i] = rule
+			replaced = true
+		}
+		if !replaced {
+			ruleSet.Rules = append(ruleSet.Rules, rule)
+		}
+	}
+}
+
+// LintAPIProject evaluates the rules against the api.yaml and the swagger of the API project in projectDir. Rules on
+// the swagger are skipped when the project does not have one
+func LintAPIProject(projectDir string, ruleSet *LintRuleSet) (*LintReport, error) {
+	if err := ruleSet.Compile(); err != nil {
+		return nil, err
+	}
+	apiPath, apiContent, err := resolveYamlOrJson(filepath.Join(projectDir, "Meta-information", "api"))
+	if err != nil {
+		return nil, err
+	}
+	var api interface{}
+	if err := json.Unmarshal(apiContent, &api); err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", apiPath, err)
+	}
+	documents := map[string]interface{}{LintTargetAPI: api}
+	files := map[string]string{LintTargetAPI: apiPath}
+
+	swaggerPath, swaggerContent, err := resolveYamlOrJson(filepath.Join(projectDir, "Meta-information", "swagger"))
+	if err == nil {
+		var swagger interface{}
+		if err := json.Unmarshal(swaggerContent, &swagger); err != nil {
+			return nil, fmt.Errorf("error reading %s: %v", swaggerPath, err)
+		}
+		documents[LintTargetSwagger] = swagger
+		files[LintTargetSwagger] = swaggerPath
+	}
+
+	report := &LintReport{Project: projectDir, Issues: []LintIssue{}}
+	for _, rule := range ruleSet.Rules {
+		if rule == nil || rule.Severity == LintSeverityOff {
+			continue
+		}
+		report.rules = append(report.rules, rule)
+		document, ok := documents[rule.Target]
+		if !ok {
+			continue
+		}
+		file := files[rule.Target]
+		if rel, err := filepath.Rel(projectDir, file); err == nil {
+			file = filepath.ToSlash(rel)
+		}
+		for _, match := range rule.given.evaluate(document) {
+			if path, ok := rule.check(match); !ok {
+				report.addIssue(rule, file, path)
+			}
+		}
+	}
+	return report, nil
+}
+
+// check applies the function of the rule to the value matched by the given path of the rule. Returns false with the
+// path of the checked value if the value does not pass
+func (rule *LintRule) check(match jsonPathMatch) (string, bool) {
+	path := match.Path
+	value := match.Value
+	defined := true
+	if rule.Then.Field == "@key" {
+		value = match.Key
+	} else if rule.field != nil {
+		fields := rule.field.evaluate(match.Value)
+		if len(fields) == 0 {
+			value, defined = nil, false
+			path = match.Path + strings.TrimPrefix(rule.field.expr, "$")
+		} else {
+			value, path = fields[0].Value, match.Path+strings.TrimPrefix(fields[0].Path, "$")
+		}
+	}
+
+	switch rule.Then.Function {
+	case "truthy":
+		return path, jsonPathTruthy(value)
+	case "falsy":
+		return path, !jsonPathTruthy(value)
+	case "defined":
+		return path, defined
+	case "undefined":
+		return path, !defined
+	}
+	if !defined || value == nil {
+		return path, true
+	}
+	s, ok := value.(string)
+	if !ok {
+		s = fmt.Sprint(value)
+	}
+	switch rule.Then.Function {
+	case "pattern":
+		if rule.match != nil && !rule.match.MatchString(s) {
+			return path, false
+		}
+		return path, rule.notMatch == nil || !rule.notMatch.MatchString(s)
+	case "enumeration":
+		for _, v := range rule.Then.Values {
+			if v == s {
+				return path, true
+			}
+		}
+		return path, false
+	}
+	return path, lintCasings[rule.Then.Casing].MatchString(s)
+}
+
+func (report *LintReport) addIssue(rule *LintRule, file, path string) {
+	message := rule.Message
+	if message == "" {
+		message = rule.Description
+	}
+	if message == "" {
+		message = "does not pass " + rule.ID
+	}
+	report.Issues = append(report.Issues, LintIssue{Rule: rule.ID, Severity: rule.Severity, Message: message,
+		File: file, Path: path})
+	switch rule.Severity {
+	case LintSeverityError:
+		report.Errors++
+	case LintSeverityWarning:
+		report.Warnings++
+	default:
+		report.Infos++
+	}
+}
+
+// FormatLintReport writes the report as text, JSON or SARIF 2.1.0 for code scanning tools
+func FormatLintReport(report *LintReport, format string) ([]byte, error) {
+	switch format {
+	case LintFormatJSON:
+		return json.MarshalIndent(report, "", "  ")
+	case LintFormatSARIF:
+		return json.MarshalIndent(report.sarif(), "", "  ")
+	case LintFormatText, "":
+		buf := &bytes.Buffer{}
+		for _, issue := range report.Issues {
+			fmt.Fprintf(buf, "%s: %s [%s] %s (%s)\n", issue.File, issue.Severity, issue.Rule, issue.Message,
+				issue.Path)
+		}
+		fmt.Fprintf(buf, "%d error(s), %d warning(s), %d info(s) found in %s", report.Errors, report.Warnings,
+			report.Infos, report.Project)
+		return buf.Bytes(), nil
+	}
+	return nil, fmt.Errorf("invalid format %s, should be either text, json or sarif", format)
+}
+
+// sarifLevels maps the severities of rules to the levels of SARIF results
+var sarifLevels = map[string]string{
+	LintSeverityError:   "error",
+	LintSeverityWarning: "warning",
+	LintSeverityInfo:    "note",
+}
+
+// sarif returns the report as a SARIF log with a run of apictl
+func (report *LintReport) sarif() map[string]interface{} {
+	rules := make([]interface{}, len(report.rules))
+	ruleIndexes := make(map[string]int)
+	for i, rule := range report.rules {
+		ruleIndexes[rule.ID] = i
+		description := rule.Description
+		if description == "" {
+			description = rule.ID
+		}
+		rules[i] = map[string]interface{}{
+			"id":                   rule.ID,
+			"shortDescription":     map[string]interface{}{"text": description},
+			"defaultConfiguration": map[string]interface{}{"level": sarifLevels[rule.Severity]},
+		}
+	}
+	results := make([]interface{}, len(report.Issues))
+	for i, issue := range report.Issues {
+		results[i] = map[string]interface{}{
+			"ruleId":    issue.Rule,
+			"ruleIndex": ruleIndexes[issue.Rule],
+			"level":     sarifLevels[issue.Severity],
+			"message":   map[string]interface{}{"text": issue.Message},
+			"locations": []interface{}{map[string]interface{}{
+				"physicalLocation": map[string]interface{}{
+					"artifactLocation": map[string]interface{}{"uri": issue.File},
+				},
+				"logicalLocations": []interface{}{map[string]interface{}{"fullyQualifiedName": issue.Path}},
+			}},
+		}
+	}
+	return map[string]interface{}{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []interface{}{map[string]interface{}{
+			"tool": map[string]interface{}{"driver": map[string]interface{}{
+				"name":           utils.ProjectName,
+				"informationUri": "https://github.com/wso2/product-apim-tooling",
+				"rules":          rules,
+			}},
+			"results": results,
+		}},
+	}
+}
+
+// lintAPIBeforeImport lints the API project in apiFilePath before it is imported. Issues are printed and an error is
+// returned if any rule with the severity error fails
+func lintAPIBeforeImport(apiFilePath string, ruleSet *LintRuleSet) error {
+	utils.Logln(utils.LogPrefixInfo + "Linting API...")
+	report, err := LintAPIProject(apiFilePath, ruleSet)
+	if err != nil {
+		return err
+	}
+	for _, issue := range report.Issues {
+		fmt.Printf("%s: %s [%s] %s (%s)\n", issue.File, issue.Severity, issue.Rule, issue.Message, issue.Path)
+	}
+	if report.Errors > 0 {
+		return fmt.Errorf("the API does not pass %d lint rule(s) with the severity error", report.Errors)
+	}
+	return nil
+}
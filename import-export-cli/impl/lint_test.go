@@ -0,0 +1,200 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const lintTestAPI = `id:
+  providerName: admin
+  apiName: PizzaShackAPI
+  version: 1.0.0
+context: /pizzashack/1.0.0
+transports: http,https
+`
+
+const lintTestSwagger = `swagger: "2.0"
+paths:
+  /menu:
+    get:
+      description: Menu of the shop
+  /orderItems:
+    post:
+      x-auth-type: None
+      description: Place an order
+    delete:
+      x-auth-type: Application & Application User
+`
+
+func loadDefaultLintRules(t *testing.T) *LintRuleSet {
+	content, err := ioutil.ReadFile("../box/resources/lint/default_rules.yaml")
+	assert.Nil(t, err)
+	ruleSet, err := LoadLintRules(content, "default rules")
+	assert.Nil(t, err)
+	assert.Nil(t, ruleSet.Compile())
+	return ruleSet
+}
+
+func lintIssues(report *LintReport) []string {
+	issues := make([]string, len(report.Issues))
+	for i, issue := range report.Issues {
+		issues[i] = issue.Severity + " " + issue.Rule + " " + issue.File + " " + issue.Path
+	}
+	return issues
+}
+
+func TestLintAPIProjectWithDefaultRules(t *testing.T) {
+	projectDir := createValidateTestProject(t, map[string]string{
+		"Meta-information/api.yaml":     lintTestAPI,
+		"Meta-information/swagger.yaml": lintTestSwagger,
+	})
+	defer os.RemoveAll(projectDir)
+
+	report, err := LintAPIProject(projectDir, loadDefaultLintRules(t))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"warning https-only Meta-information/api.yaml $.transports",
+		"error business-owner-email Meta-information/api.yaml $.businessOwnerEmail",
+		"warning kebab-case-paths Meta-information/swagger.yaml $.paths['/orderItems']",
+		"error no-none-auth-on-write Meta-information/swagger.yaml $.paths['/orderItems'].post.x-auth-type",
+		"warning operation-description Meta-information/swagger.yaml $.paths['/orderItems'].delete.description",
+	}, lintIssues(report))
+	assert.Equal(t, 2, report.Errors)
+	assert.Equal(t, 3, report.Warnings)
+}
+
+func TestLintAPIProjectWithCustomRules(t *testing.T) {
+	projectDir := createValidateTestProject(t, map[string]string{
+		"Meta-information/api.yaml": lintTestAPI + "businessOwnerEmail: owner@example.com\ntags: [Pizza]\n",
+	})
+	defer os.RemoveAll(projectDir)
+
+	ruleSet := loadDefaultLintRules(t)
+	custom, err := LoadLintRules([]byte(`rules:
+  - id: https-only
+    severity: off
+  - id: lowercase-tags
+    description: Tags should be in lower case
+    severity: info
+    given: $.tags[*]
+    then:
+      function: casing
+      casing: kebab
+  - id: known-visibility
+    given: $
+    then:
+      field: visibility
+      function: enumeration
+      values: [public, private]
+`), "custom.yaml")
+	assert.Nil(t, err)
+	ruleSet.Merge(custom)
+
+	report, err := LintAPIProject(projectDir, ruleSet)
+	assert.Nil(t, err)
+	assert.Equal(t, LintSeverityOff, ruleSet.Rules[0].Severity)
+	assert.Equal(t, "$", ruleSet.Rules[0].Given, "given of the replaced rule should be kept")
+	assert.Equal(t, []string{"info lowercase-tags Meta-information/api.yaml $.tags[0]"}, lintIssues(report))
+	assert.Equal(t, "Tags should be in lower case", report.Issues[0].Message)
+	assert.Equal(t, 0, report.Errors)
+}
+
+func TestLoadLintRulesErrors(t *testing.T) {
+	tests := []struct {
+		rules   string
+		message string
+	}{
+		{"rules:\n  - given: $\n", "rule 1 does not have an id"},
+		{"rules:\n  - id: a\n    severity: fatal\n", `severity "fatal" should be either error, warning, info or off`},
+		{"rules:\n  - id: a\n    given: $.a\n    then: {function: exists}\n", `function "exists" should be`},
+		{"rules:\n  - id: a\n    given: a\n    then: {function: truthy}\n", `invalid JSONPath "a"`},
+		{"rules:\n  - id: a\n    given: $\n    then: {function: pattern}\n", "pattern requires match or notMatch"},
+		{"rules:\n  - id: a\n    given: $\n    then: {function: casing, casing: title}\n", `casing "title"`},
+		{"rules:\n  - id: a\n    given: $\n    then: {function: truthy}\n  - id: a\n    given: $\n" +
+			"    then: {function: falsy}\n", "rule a is defined more than once"},
+		{"rules:\n  - id: a\n    when: $\n", "field when not found"},
+	}
+	for _, test := range tests {
+		ruleSet, err := LoadLintRules([]byte(test.rules), "rules.yaml")
+		if err == nil {
+			err = ruleSet.Compile()
+		}
+		if assert.NotNil(t, err, test.rules) {
+			assert.Contains(t, err.Error(), test.message, test.rules)
+		}
+	}
+}
+
+func TestFormatLintReportSARIF(t *testing.T) {
+	projectDir := createValidateTestProject(t, map[string]string{"Meta-information/api.yaml": lintTestAPI})
+	defer os.RemoveAll(projectDir)
+	report, err := LintAPIProject(projectDir, loadDefaultLintRules(t))
+	assert.Nil(t, err)
+
+	out, err := FormatLintReport(report, LintFormatSARIF)
+	assert.Nil(t, err)
+	var sarif struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				RuleIndex int    `json:"ruleIndex"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	assert.Nil(t, json.Unmarshal(out, &sarif))
+	assert.Equal(t, "2.1.0", sarif.Version)
+	run := sarif.Runs[0]
+	assert.Equal(t, "apictl", run.Tool.Driver.Name)
+	assert.Len(t, run.Tool.Driver.Rules, 5)
+	assert.Len(t, run.Results, 2)
+	assert.Equal(t, "business-owner-email", run.Results[1].RuleID)
+	assert.Equal(t, "business-owner-email", run.Tool.Driver.Rules[run.Results[1].RuleIndex].ID)
+	assert.Equal(t, "error", run.Results[1].Level)
+	assert.Equal(t, "Meta-information/api.yaml", run.Results[1].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+
+	text, err := FormatLintReport(report, LintFormatText)
+	assert.Nil(t, err)
+	assert.Contains(t, string(text), "Meta-information/api.yaml: warning [https-only] APIs should be exposed only "+
+		"over HTTPS ($.transports)")
+	_, err = FormatLintReport(report, "xml")
+	assert.NotNil(t, err)
+}
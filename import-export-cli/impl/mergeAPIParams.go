@@ -0,0 +1,212 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+)
+
+// mergeAPIMetadata merges policies, CORS, visibility, transports, tags, business information and additional
+// properties in environmentParams to the api. Values which are not present in api_params.yaml are kept as they
+// are in api.yaml
+func mergeAPIMetadata(environmentParams *params.Environment, api *gabs.Container) error {
+	if environmentParams.Policies != nil {
+		if environmentParams.Policies.Subscription != nil {
+			if err := mergeAvailableTiers(environmentParams.Policies.Subscription, api); err != nil {
+				return err
+			}
+		}
+		if err := mergeResourcePolicies(environmentParams.Policies.Resources, api); err != nil {
+			return err
+		}
+	}
+	if environmentParams.Cors != nil {
+		if err := setFields(environmentParams.Cors, api, "corsConfiguration"); err != nil {
+			return err
+		}
+	}
+	if environmentParams.Visibility != "" {
+		if _, err := api.Set(environmentParams.Visibility, "visibility"); err != nil {
+			return err
+		}
+	}
+	if environmentParams.VisibleRoles != nil {
+		if _, err := api.Set(strings.Join(environmentParams.VisibleRoles, ","), "visibleRoles"); err != nil {
+			return err
+		}
+	}
+	if environmentParams.Transports != nil {
+		if _, err := api.Set(strings.Join(environmentParams.Transports, ","), "transports"); err != nil {
+			return err
+		}
+	}
+	if environmentParams.Tags != nil {
+		if _, err := api.Set(environmentParams.Tags, "tags"); err != nil {
+			return err
+		}
+	}
+	if environmentParams.BusinessInformation != nil {
+		if err := setFields(environmentParams.BusinessInformation, api); err != nil {
+			return err
+		}
+	}
+	for key, value := range environmentParams.AdditionalProperties {
+		if _, err := api.Set(value, "additionalProperties", key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeAvailableTiers replaces the subscription tiers of the api. Details of tiers already in the api are kept
+func mergeAvailableTiers(tiers []string, api *gabs.Container) error {
+	existingTiers := make(map[string]interface{})
+	if api.Exists("availableTiers") {
+		children, _ := api.S("availableTiers").Children()
+		for _, child := range children {
+			if name, ok := child.S("name").Data().(string); ok {
+				existingTiers[name] = child.Data()
+			}
+		}
+	}
+
+	availableTiers := make([]interface{}, 0, len(tiers))
+	for _, tier := range tiers {
+		if existing, ok := existingTiers[tier]; ok {
+			availableTiers = append(availableTiers, existing)
+		} else {
+			availableTiers = append(availableTiers, map[string]interface{}{"name": tier})
+		}
+	}
+	_, err := api.Set(availableTiers, "availableTiers")
+	return err
+}
+
+// mergeResourcePolicies sets the throttling policies and scopes of the api resources. Scopes which are not defined in
+// the api are added to it
+// @return error if a resource is not defined in the api
+func mergeResourcePolicies(resources []params.ResourcePolicy, api *gabs.Container) error {
+	if len(resources) == 0 {
+		return nil
+	}
+	uriTemplates, _ := api.S("uriTemplates").Children()
+	for _, resource := range resources {
+		var scope interface{}
+		if resource.Scope != "" {
+			var err error
+			if scope, err = apiScope(resource.Scope, api); err != nil {
+				return err
+			}
+		}
+		found := false
+		for _, uriTemplate := range uriTemplates {
+			if uriTemplate.S("uriTemplate").Data() != resource.Target {
+				continue
+			}
+			verbs, _ := uriTemplate.S("httpVerbs").Children()
+			if verb, ok := uriTemplate.S("httpVerb").Data().(string); ok && strings.EqualFold(verb, resource.Verb) {
+				if resource.ThrottlingPolicy != "" {
+					if _, err := uriTemplate.Set(resource.ThrottlingPolicy, "throttlingTier"); err != nil {
+						return err
+					}
+				}
+				if scope != nil && len(verbs) == 0 {
+					if _, err := uriTemplate.Set([]interface{}{scope}, "scopes"); err != nil {
+						return err
+					}
+				}
+				found = true
+			}
+			// templates with several verbs keep a throttling tier and a scope per verb
+			for index, verb := range verbs {
+				if name, ok := verb.Data().(string); ok && strings.EqualFold(name, resource.Verb) {
+					if count, err := uriTemplate.ArrayCount("throttlingTiers"); err == nil && index < count &&
+						resource.ThrottlingPolicy != "" {
+						if _, err := uriTemplate.S("throttlingTiers").SetIndex(resource.ThrottlingPolicy, index); err != nil {
+							return err
+						}
+					}
+					if scope != nil {
+						if err := setResourceScope(uriTemplate, index, len(verbs), scope); err != nil {
+							return err
+						}
+					}
+					found = true
+				}
+			}
+		}
+		if !found {
+			return fmt.Errorf("resource %s %s in api_params.yaml is not defined in the API", resource.Verb,
+				resource.Target)
+		}
+	}
+	return nil
+}
+
+// apiScope returns the scope of the api with the key name. The scope is added to the api if it is not defined
+func apiScope(name string, api *gabs.Container) (interface{}, error) {
+	scopes, _ := api.S("scopes").Children()
+	for _, scope := range scopes {
+		if scope.S("key").Data() == name {
+			return scope.Data(), nil
+		}
+	}
+	scope := map[string]interface{}{"key": name, "name": name}
+	if !api.Exists("scopes") {
+		if _, err := api.Array("scopes"); err != nil {
+			return nil, err
+		}
+	}
+	return scope, api.ArrayAppend(scope, "scopes")
+}
+
+// setResourceScope sets the scope of the verb at index of an uri template with count verbs
+func setResourceScope(uriTemplate *gabs.Container, index, count int, scope interface{}) error {
+	scopes := make([]interface{}, count)
+	existing, _ := uriTemplate.S("scopes").Children()
+	for i := 0; i < count && i < len(existing); i++ {
+		scopes[i] = existing[i].Data()
+	}
+	scopes[index] = scope
+	_, err := uriTemplate.Set(scopes, "scopes")
+	return err
+}
+
+// setFields sets the fields of value which are not empty under path in the api
+func setFields(value interface{}, api *gabs.Container, path ...string) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for key, field := range fields {
+		if _, err := api.Set(field, append(path, key)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Jeffail/gabs"
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// createParamsTestProject creates an API project containing the api in specs/params/testdata
+func createParamsTestProject(t *testing.T) string {
+	projectDir, err := ioutil.TempDir("", "project")
+	assert.Nil(t, err)
+	metaDir := filepath.Join(projectDir, "Meta-information")
+	assert.Nil(t, os.MkdirAll(metaDir, os.ModePerm))
+	content, err := ioutil.ReadFile("../specs/params/testdata/api.json")
+	assert.Nil(t, err)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(metaDir, "api.json"), content, 0644))
+	return projectDir
+}
+
+func readMergedAPI(t *testing.T, projectDir string) *gabs.Container {
+	content, err := ioutil.ReadFile(filepath.Join(projectDir, "Meta-information", "api.yaml"))
+	assert.Nil(t, err)
+	jsonContent, err := utils.YamlToJson(content)
+	assert.Nil(t, err)
+	api, err := gabs.ParseJSON(jsonContent)
+	assert.Nil(t, err)
+	return api
+}
+
+func TestMergeAPIWithOverrides(t *testing.T) {
+	projectDir := createParamsTestProject(t)
+	defer os.RemoveAll(projectDir)
+	apiParams, err := params.LoadApiParamsFromFile("../specs/params/testdata/api_params-overrides.yml")
+	assert.Nil(t, err)
+
+	err = mergeAPI(projectDir, apiParams.GetEnv("dev"))
+	assert.Nil(t, err, "Error should be nil")
+	api := readMergedAPI(t, projectDir)
+
+	tiers, _ := api.S("availableTiers").Children()
+	assert.Equal(t, 2, len(tiers))
+	assert.Equal(t, "Gold", tiers[0].S("name").Data())
+	assert.Equal(t, "Allows unlimited requests", tiers[1].S("description").Data(),
+		"Details of existing tiers should be kept")
+
+	orderTemplate := api.S("uriTemplates").Index(0)
+	assert.Equal(t, "Unlimited", orderTemplate.S("throttlingTier").Data(), "GET should keep its policy")
+	assert.Equal(t, "10KPerMin", orderTemplate.S("throttlingTiers").Index(1).Data())
+	assert.Equal(t, "20KPerMin", api.S("uriTemplates").Index(1).S("throttlingTier").Data())
+
+	assert.Equal(t, true, api.Path("corsConfiguration.corsConfigurationEnabled").Data())
+	assert.Equal(t, []interface{}{"https://dev.pizzashack.com"},
+		api.Path("corsConfiguration.accessControlAllowOrigins").Data())
+	assert.Equal(t, 4, len(api.Path("corsConfiguration.accessControlAllowHeaders").Data().([]interface{})),
+		"CORS fields which are not set should be kept")
+
+	assert.Equal(t, "restricted", api.S("visibility").Data())
+	assert.Equal(t, "admin,Internal/subscriber", api.S("visibleRoles").Data())
+	assert.Equal(t, "https", api.S("transports").Data())
+	assert.Equal(t, []interface{}{"pizza", "dev"}, api.S("tags").Data())
+	assert.Equal(t, "Dev Team", api.S("technicalOwner").Data())
+	assert.Equal(t, "Jane Roe", api.S("businessOwner").Data(), "Owners which are not set should be kept")
+	assert.Equal(t, "us-east", api.Path("additionalProperties.region").Data())
+}
+
+func TestMergeAPIWithUnknownResource(t *testing.T) {
+	projectDir := createParamsTestProject(t)
+	defer os.RemoveAll(projectDir)
+
+	env := &params.Environment{
+		Name: "dev",
+		Policies: &params.Policies{
+			Resources: []params.ResourcePolicy{{Target: "/pizza", Verb: "GET", ThrottlingPolicy: "Gold"}},
+		},
+	}
+	err := mergeAPI(projectDir, env)
+	assert.NotNil(t, err, "Resources which are not in the API should return an error")
+}
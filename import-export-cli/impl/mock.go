@@ -0,0 +1,888 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Jeffail/gabs"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// mockMaxDepth limits how deep payloads are synthesised from recursive schemas
+const mockMaxDepth = 8
+
+// mockMaxStringLength limits the length of strings synthesised for a minLength
+const mockMaxStringLength = 1024
+
+// mockPathParamRegex matches the parameters of a swagger path
+var mockPathParamRegex = regexp.MustCompile(`\{([^}/]+)\}`)
+
+// MockServer serves the operations of the swagger of an API project. Responses are taken from the examples of the
+// swagger or synthesised from the schemas, and requests are validated against the parameters and the body schemas
+type MockServer struct {
+	// BasePath the operations are served under, taken from x-wso2-basePath, the context of the API or basePath of
+	// swagger 2.0 in that order
+	BasePath string
+	// Log receives a line for each request when set
+	Log io.Writer
+
+	swagger *gabs.Container
+	oas3    bool
+	routes  []*mockRoute
+}
+
+// mockRoute is a path of the swagger with its operations
+type mockRoute struct {
+	path       string
+	pattern    *regexp.Regexp
+	params     []string
+	item       map[string]interface{}
+	operations map[string]map[string]interface{}
+}
+
+// mockResponseWriter records the status written to a http.ResponseWriter
+type mockResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records status and writes it
+func (w *mockResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// NewMockServer creates a mock server for the swagger 2.0 or OpenAPI 3 definition of the API project in projectDir
+func NewMockServer(projectDir string) (*MockServer, error) {
+	fp, content, err := resolveYamlOrJson(filepath.Join(projectDir, "Meta-information", "swagger"))
+	if err != nil {
+		return nil, err
+	}
+	swagger, err := gabs.ParseJSON(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid swagger %s: %v", fp, err)
+	}
+
+	s := &MockServer{swagger: swagger, oas3: swagger.Exists("openapi")}
+	def, _, defErr := getAPIDefinition(projectDir)
+	basePath, ok := swagger.S("x-wso2-basePath").Data().(string)
+	if ok && strings.Contains(basePath, "{version}") {
+		version, _ := swagger.Path("info.version").Data().(string)
+		if defErr == nil && def.ID.Version != "" {
+			version = def.ID.Version
+		}
+		basePath = strings.ReplaceAll(basePath, "{version}", version)
+	}
+	if !ok && defErr == nil && def.Context != "" {
+		// the versioned context of the API is the path the gateway exposes the operations under
+		populateApiWithDefaults(def)
+		basePath, ok = def.Context, true
+	}
+	if !ok && !s.oas3 {
+		basePath, _ = swagger.S("basePath").Data().(string)
+	}
+	basePath = strings.TrimSuffix(basePath, "/")
+	if basePath != "" && !strings.HasPrefix(basePath, "/") {
+		basePath = "/" + basePath
+	}
+	s.BasePath = basePath
+
+	paths, _ := swagger.S("paths").Data().(map[string]interface{})
+	for path, value := range paths {
+		item, _ := value.(map[string]interface{})
+		route := &mockRoute{path: path, item: item, operations: make(map[string]map[string]interface{})}
+		for _, verb := range swaggerVerbs {
+			if operation, ok := item[verb].(map[string]interface{}); ok {
+				route.operations[strings.ToUpper(verb)] = operation
+			}
+		}
+		if len(route.operations) == 0 {
+			continue
+		}
+
+		pattern := "^"
+		last := 0
+		for _, match := range mockPathParamRegex.FindAllStringSubmatchIndex(path, -1) {
+			pattern += regexp.QuoteMeta(path[last:match[0]]) + "([^/]+)"
+			route.params = append(route.params, path[match[2]:match[3]])
+			last = match[1]
+		}
+		pattern += regexp.QuoteMeta(strings.TrimSuffix(path[last:], "/")) + "/?$"
+		if route.pattern, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("invalid path %s in %s: %v", path, fp, err)
+		}
+		s.routes = append(s.routes, route)
+	}
+	if len(s.routes) == 0 {
+		return nil, fmt.Errorf("%s does not have any operations to mock", fp)
+	}
+
+	// paths without parameters take precedence over templated paths
+	sort.Slice(s.routes, func(i, j int) bool {
+		if len(s.routes[i].params) != len(s.routes[j].params) {
+			return len(s.routes[i].params) < len(s.routes[j].params)
+		}
+		return s.routes[i].path < s.routes[j].path
+	})
+	return s, nil
+}
+
+// Operations returns the operations served by the mock server as "VERB path"
+func (s *MockServer) Operations() []string {
+	var operations []string
+	for _, route := range s.routes {
+		for _, verb := range swaggerVerbs {
+			if _, ok := route.operations[strings.ToUpper(verb)]; ok {
+				operations = append(operations, strings.ToUpper(verb)+" "+s.BasePath+route.path)
+			}
+		}
+	}
+	return operations
+}
+
+// ServeHTTP serves a request with the operation of the swagger matching the path and the method of the request
+func (s *MockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rw := &mockResponseWriter{ResponseWriter: w, status: http.StatusOK}
+	s.serve(rw, r)
+	if s.Log != nil {
+		_, _ = fmt.Fprintf(s.Log, "%s %s %d\n", r.Method, r.URL.RequestURI(), rw.status)
+	}
+}
+
+func (s *MockServer) serve(w http.ResponseWriter, r *http.Request) {
+	// allow frontends served from other origins to call the mock
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	path := r.URL.Path
+	if s.BasePath != "" {
+		if path != s.BasePath && !strings.HasPrefix(path, s.BasePath+"/") {
+			writeMockError(w, http.StatusNotFound, "No operation matches "+r.URL.Path, nil)
+			return
+		}
+		path = strings.TrimPrefix(path, s.BasePath)
+	}
+	if path == "" {
+		path = "/"
+	}
+
+	for _, route := range s.routes {
+		match := route.pattern.FindStringSubmatch(path)
+		if match == nil {
+			continue
+		}
+		operation, ok := route.operations[r.Method]
+		if !ok {
+			var allowed []string
+			for _, verb := range swaggerVerbs {
+				if _, ok := route.operations[strings.ToUpper(verb)]; ok {
+					allowed = append(allowed, strings.ToUpper(verb))
+				}
+			}
+			if r.Method == http.MethodOptions {
+				w.Header().Set("Access-Control-Allow-Methods", strings.Join(allowed, ", "))
+				w.Header().Set("Access-Control-Allow-Headers", "*")
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+			writeMockError(w, http.StatusMethodNotAllowed, "Method "+r.Method+" is not allowed for "+route.path, nil)
+			return
+		}
+
+		pathParams := make(map[string]string)
+		for i, name := range route.params {
+			value, err := url.PathUnescape(match[i+1])
+			if err != nil {
+				value = match[i+1]
+			}
+			pathParams[name] = value
+		}
+		if errs := s.validateRequest(r, route, operation, pathParams); len(errs) > 0 {
+			writeMockError(w, http.StatusBadRequest, "Invalid request", errs)
+			return
+		}
+		s.writeResponse(w, operation)
+		return
+	}
+	writeMockError(w, http.StatusNotFound, "No operation matches "+r.URL.Path, nil)
+}
+
+// writeMockError writes an error response in the format of the errors of the gateway
+func writeMockError(w http.ResponseWriter, status int, message string, errs []string) {
+	body := map[string]interface{}{"code": status, "message": message}
+	if len(errs) > 0 {
+		body["errors"] = errs
+	}
+	content, _ := json.Marshal(body)
+	w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+	w.WriteHeader(status)
+	_, _ = w.Write(content)
+}
+
+// resolve returns the object referred with $ref by value, or value if it is not a reference. Only references inside
+// the swagger are resolved
+func (s *MockServer) resolve(value interface{}) map[string]interface{} {
+	object, _ := value.(map[string]interface{})
+	for i := 0; i < mockMaxDepth && object != nil; i++ {
+		ref, ok := object["$ref"].(string)
+		if !ok {
+			return object
+		}
+		if !strings.HasPrefix(ref, "#/") {
+			return nil
+		}
+		var segments []string
+		for _, segment := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			segments = append(segments, strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~"))
+		}
+		object, _ = s.swagger.Search(segments...).Data().(map[string]interface{})
+	}
+	return object
+}
+
+// parameters returns the parameters of the path item overridden by the parameters of the operation
+func (s *MockServer) parameters(route *mockRoute, operation map[string]interface{}) []map[string]interface{} {
+	var parameters []map[string]interface{}
+	index := make(map[string]int)
+	for _, list := range []interface{}{route.item["parameters"], operation["parameters"]} {
+		items, _ := list.([]interface{})
+		for _, item := range items {
+			parameter := s.resolve(item)
+			if parameter == nil {
+				continue
+			}
+			key := fmt.Sprint(parameter["in"], ":", parameter["name"])
+			if i, ok := index[key]; ok {
+				parameters[i] = parameter
+				continue
+			}
+			index[key] = len(parameters)
+			parameters = append(parameters, parameter)
+		}
+	}
+	return parameters
+}
+
+// validateRequest validates the parameters and the body of the request. Returns the errors found
+func (s *MockServer) validateRequest(r *http.Request, route *mockRoute, operation map[string]interface{},
+	pathParams map[string]string) []string {
+	var errs []string
+	query := r.URL.Query()
+	for _, parameter := range s.parameters(route, operation) {
+		name, _ := parameter["name"].(string)
+		in, _ := parameter["in"].(string)
+		required, _ := parameter["required"].(bool)
+		schema := parameter
+		if s.oas3 {
+			schema = s.resolve(parameter["schema"])
+		}
+
+		var values []string
+		switch in {
+		case "path":
+			if value, ok := pathParams[name]; ok {
+				values = []string{value}
+			}
+		case "query":
+			values = query[name]
+		case "header":
+			values = r.Header[http.CanonicalHeaderKey(name)]
+		case "cookie":
+			if cookie, err := r.Cookie(name); err == nil {
+				values = []string{cookie.Value}
+			}
+		case "formData":
+			_ = r.ParseForm()
+			values = r.PostForm[name]
+		case "body":
+			content := make(map[string]interface{})
+			for _, mediaType := range s.mediaTypes(operation, "consumes") {
+				content[mediaType] = map[string]interface{}{"schema": parameter["schema"]}
+			}
+			errs = append(errs, s.validateBody(r, required, content)...)
+			continue
+		default:
+			continue
+		}
+
+		if len(values) == 0 {
+			if required {
+				errs = append(errs, fmt.Sprintf("%s parameter %s is required", in, name))
+			}
+			continue
+		}
+		if schema == nil || (in == "formData" && schema["type"] == "file") {
+			continue
+		}
+		value, err := parseMockParameter(s.resolveSchema(schema), values)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s parameter %s %v", in, name, err))
+			continue
+		}
+		errs = append(errs, s.validateValue(schema, value, in+" parameter "+name)...)
+	}
+
+	if s.oas3 {
+		if requestBody := s.resolve(operation["requestBody"]); requestBody != nil {
+			required, _ := requestBody["required"].(bool)
+			content, _ := requestBody["content"].(map[string]interface{})
+			errs = append(errs, s.validateBody(r, required, content)...)
+		}
+	}
+	return errs
+}
+
+// validateBody validates the body of the request against the schema of its media type in content
+func (s *MockServer) validateBody(r *http.Request, required bool, content map[string]interface{}) []string {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return []string{"body could not be read: " + err.Error()}
+	}
+	if len(strings.TrimSpace(string(body))) == 0 {
+		if required {
+			return []string{"body is required"}
+		}
+		return nil
+	}
+
+	mediaType := strings.TrimSpace(strings.Split(r.Header.Get(utils.HeaderContentType), ";")[0])
+	if mediaType == "" {
+		mediaType = utils.HeaderValueApplicationJSON
+	}
+	mediaTypeObject, ok := content[mediaType]
+	if !ok {
+		for accepted, object := range content {
+			if accepted == "*/*" || (strings.HasSuffix(accepted, "/*") &&
+				strings.HasPrefix(mediaType, strings.TrimSuffix(accepted, "*"))) {
+				mediaTypeObject, ok = object, true
+				break
+			}
+		}
+	}
+	if !ok && len(content) > 0 {
+		return []string{"content type " + mediaType + " is not supported"}
+	}
+	if !strings.Contains(mediaType, "json") {
+		return nil
+	}
+
+	var value interface{}
+	if err = json.Unmarshal(body, &value); err != nil {
+		return []string{"body is not valid JSON: " + err.Error()}
+	}
+	object, _ := mediaTypeObject.(map[string]interface{})
+	return s.validateValue(object["schema"], value, "body")
+}
+
+// resolveSchema resolves the references of schema and of the items of an array schema
+func (s *MockServer) resolveSchema(schema interface{}) map[string]interface{} {
+	resolved := s.resolve(schema)
+	if resolved == nil {
+		return nil
+	}
+	if items, ok := resolved["items"]; ok {
+		copied := make(map[string]interface{}, len(resolved))
+		for key, value := range resolved {
+			copied[key] = value
+		}
+		copied["items"] = s.resolve(items)
+		return copied
+	}
+	return resolved
+}
+
+// parseMockParameter converts the values of a parameter to the type of schema
+func parseMockParameter(schema map[string]interface{}, values []string) (interface{}, error) {
+	if schema == nil {
+		return values[0], nil
+	}
+	if schema["type"] == "array" {
+		if len(values) == 1 {
+			values = strings.Split(values[0], ",")
+		}
+		items, _ := schema["items"].(map[string]interface{})
+		var list []interface{}
+		for _, value := range values {
+			item, err := parseMockParameter(items, []string{value})
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+		}
+		return list, nil
+	}
+
+	value := values[0]
+	switch schema["type"] {
+	case "integer":
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("should be an integer")
+		}
+		return float64(i), nil
+	case "number":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("should be a number")
+		}
+		return f, nil
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("should be a boolean")
+		}
+		return b, nil
+	}
+	return value, nil
+}
+
+// validateValue validates value against schema. where names value in the errors
+func (s *MockServer) validateValue(schemaValue interface{}, value interface{}, where string) []string {
+	schema := s.resolve(schemaValue)
+	if schema == nil {
+		return nil
+	}
+
+	var errs []string
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for _, sub := range allOf {
+			errs = append(errs, s.validateValue(sub, value, where)...)
+		}
+	}
+	for _, keyword := range []string{"oneOf", "anyOf"} {
+		if subs, ok := schema[keyword].([]interface{}); ok && len(subs) > 0 {
+			matched := false
+			for _, sub := range subs {
+				if len(s.validateValue(sub, value, where)) == 0 {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				errs = append(errs, where+" does not match any of the schemas in "+keyword)
+			}
+		}
+	}
+
+	if value == nil {
+		nullable, _ := schema["nullable"].(bool)
+		xNullable, _ := schema["x-nullable"].(bool)
+		if _, typed := schema["type"]; typed && !nullable && !xNullable {
+			errs = append(errs, where+" should not be null")
+		}
+		return errs
+	}
+
+	schemaType, _ := schema["type"].(string)
+	if schemaType != "" && !mockTypeMatches(schemaType, value) {
+		return append(errs, fmt.Sprintf("%s should be %s", where, mockArticle(schemaType)))
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		found := false
+		for _, allowed := range enum {
+			if reflect.DeepEqual(allowed, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, fmt.Sprintf("%s should be one of %v", where, enum))
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		length := float64(utf8.RuneCountInString(v))
+		if min, ok := schema["minLength"].(float64); ok && length < min {
+			errs = append(errs, fmt.Sprintf("%s should have at least %v characters", where, min))
+		}
+		if max, ok := schema["maxLength"].(float64); ok && length > max {
+			errs = append(errs, fmt.Sprintf("%s should have at most %v characters", where, max))
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(v) {
+				errs = append(errs, fmt.Sprintf("%s should match %s", where, pattern))
+			}
+		}
+	case float64:
+		exclusiveMin, _ := schema["exclusiveMinimum"].(bool)
+		exclusiveMax, _ := schema["exclusiveMaximum"].(bool)
+		if min, ok := schema["minimum"].(float64); ok && (v < min || (exclusiveMin && v == min)) {
+			errs = append(errs, fmt.Sprintf("%s should not be less than %v", where, min))
+		}
+		if max, ok := schema["maximum"].(float64); ok && (v > max || (exclusiveMax && v == max)) {
+			errs = append(errs, fmt.Sprintf("%s should not be greater than %v", where, max))
+		}
+	case []interface{}:
+		if min, ok := schema["minItems"].(float64); ok && float64(len(v)) < min {
+			errs = append(errs, fmt.Sprintf("%s should have at least %v items", where, min))
+		}
+		if max, ok := schema["maxItems"].(float64); ok && float64(len(v)) > max {
+			errs = append(errs, fmt.Sprintf("%s should have at most %v items", where, max))
+		}
+		if items, ok := schema["items"]; ok {
+			for i, item := range v {
+				errs = append(errs, s.validateValue(items, item, fmt.Sprintf("%s[%d]", where, i))...)
+			}
+		}
+	case map[string]interface{}:
+		required, _ := schema["required"].([]interface{})
+		for _, name := range required {
+			if _, ok := v[fmt.Sprint(name)]; !ok {
+				errs = append(errs, fmt.Sprintf("%s.%v is required", where, name))
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		for _, name := range sortedMapKeys(v) {
+			if property, ok := properties[name]; ok {
+				errs = append(errs, s.validateValue(property, v[name], where+"."+name)...)
+			} else if additional, ok := schema["additionalProperties"]; ok {
+				if allowed, ok := additional.(bool); ok && !allowed {
+					errs = append(errs, fmt.Sprintf("%s.%s is not allowed", where, name))
+				} else if !ok {
+					errs = append(errs, s.validateValue(additional, v[name], where+"."+name)...)
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// mockTypeMatches returns true if value decoded from JSON is of the swagger type schemaType
+func mockTypeMatches(schemaType string, value interface{}) bool {
+	switch schemaType {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == math.Trunc(f)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	}
+	return true
+}
+
+// mockArticle prefixes schemaType with an indefinite article
+func mockArticle(schemaType string) string {
+	if strings.ContainsAny(schemaType[:1], "aeiou") {
+		return "an " + schemaType
+	}
+	return "a " + schemaType
+}
+
+// sortedMapKeys returns the keys of m in order
+func sortedMapKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// writeResponse writes the response of the operation with the lowest success status code
+func (s *MockServer) writeResponse(w http.ResponseWriter, operation map[string]interface{}) {
+	responses, _ := operation["responses"].(map[string]interface{})
+	status, key := http.StatusOK, ""
+	for _, code := range sortedMapKeys(responses) {
+		if c, err := strconv.Atoi(code); err == nil && c >= 200 && c < 300 {
+			status, key = c, code
+			break
+		}
+	}
+	if key == "" {
+		if _, ok := responses["default"]; ok {
+			key = "default"
+		}
+	}
+
+	mediaType, body, ok := s.responseBody(s.resolve(responses[key]), operation)
+	if !ok || status == http.StatusNoContent {
+		w.WriteHeader(status)
+		return
+	}
+	content, isString := body.(string)
+	if !isString || strings.Contains(mediaType, "json") {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			writeMockError(w, http.StatusInternalServerError, "Response could not be encoded", []string{err.Error()})
+			return
+		}
+		content = string(encoded)
+	}
+	w.Header().Set(utils.HeaderContentType, mediaType)
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(content))
+}
+
+// responseBody returns the media type and the payload of response, taken from its examples or synthesised from
+// its schema
+func (s *MockServer) responseBody(response, operation map[string]interface{}) (string, interface{}, bool) {
+	if response == nil {
+		return "", nil, false
+	}
+
+	if s.oas3 {
+		content, _ := response["content"].(map[string]interface{})
+		mediaType := preferredMediaType(sortedMapKeys(content))
+		if mediaType == "" {
+			return "", nil, false
+		}
+		object, _ := content[mediaType].(map[string]interface{})
+		if example, ok := object["example"]; ok {
+			return mediaType, example, true
+		}
+		examples, _ := object["examples"].(map[string]interface{})
+		for _, name := range sortedMapKeys(examples) {
+			if example := s.resolve(examples[name]); example != nil {
+				if value, ok := example["value"]; ok {
+					return mediaType, value, true
+				}
+			}
+		}
+		if schema, ok := object["schema"]; ok {
+			return mediaType, s.mockValue(schema, 0), true
+		}
+		return mediaType, nil, false
+	}
+
+	mediaType := preferredMediaType(s.mediaTypes(operation, "produces"))
+	examples, _ := response["examples"].(map[string]interface{})
+	if example, ok := examples[mediaType]; ok {
+		return mediaType, example, true
+	}
+	if exampleTypes := sortedMapKeys(examples); len(exampleTypes) > 0 {
+		return exampleTypes[0], examples[exampleTypes[0]], true
+	}
+	if schema, ok := response["schema"]; ok {
+		return mediaType, s.mockValue(schema, 0), true
+	}
+	return mediaType, nil, false
+}
+
+// mediaTypes returns the media types of a swagger 2.0 operation given with keyword (consumes or produces), falling
+// back to the ones of the swagger and then to application/json
+func (s *MockServer) mediaTypes(operation map[string]interface{}, keyword string) []string {
+	list, ok := operation[keyword].([]interface{})
+	if !ok {
+		list, _ = s.swagger.S(keyword).Data().([]interface{})
+	}
+	var mediaTypes []string
+	for _, mediaType := range list {
+		mediaTypes = append(mediaTypes, fmt.Sprint(mediaType))
+	}
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{utils.HeaderValueApplicationJSON}
+	}
+	return mediaTypes
+}
+
+// preferredMediaType returns application/json, a JSON media type or the first of mediaTypes in that order
+func preferredMediaType(mediaTypes []string) string {
+	for _, mediaType := range mediaTypes {
+		if mediaType == utils.HeaderValueApplicationJSON {
+			return mediaType
+		}
+	}
+	for _, mediaType := range mediaTypes {
+		if strings.Contains(mediaType, "json") {
+			return mediaType
+		}
+	}
+	if len(mediaTypes) > 0 {
+		return mediaTypes[0]
+	}
+	return ""
+}
+
+// mockValue synthesises a value for schema, using its example, default or first enum value when it has one
+func (s *MockServer) mockValue(schemaValue interface{}, depth int) interface{} {
+	schema := s.resolve(schemaValue)
+	if schema == nil || depth > mockMaxDepth {
+		return nil
+	}
+	for _, keyword := range []string{"example", "default"} {
+		if value, ok := schema[keyword]; ok {
+			return value
+		}
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		return enum[0]
+	}
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		merged := make(map[string]interface{})
+		for _, sub := range allOf {
+			if object, ok := s.mockValue(sub, depth+1).(map[string]interface{}); ok {
+				for key, value := range object {
+					merged[key] = value
+				}
+			}
+		}
+		return merged
+	}
+	for _, keyword := range []string{"oneOf", "anyOf"} {
+		if subs, ok := schema[keyword].([]interface{}); ok && len(subs) > 0 {
+			return s.mockValue(subs[0], depth+1)
+		}
+	}
+
+	schemaType, _ := schema["type"].(string)
+	if schemaType == "" {
+		if _, ok := schema["properties"]; ok {
+			schemaType = "object"
+		} else if _, ok := schema["items"]; ok {
+			schemaType = "array"
+		}
+	}
+	switch schemaType {
+	case "string":
+		return mockString(schema)
+	case "integer":
+		if min, ok := schema["minimum"].(float64); ok {
+			return math.Ceil(min)
+		}
+		return 0
+	case "number":
+		if min, ok := schema["minimum"].(float64); ok {
+			return min
+		}
+		return 0.0
+	case "boolean":
+		return true
+	case "array":
+		return []interface{}{s.mockValue(schema["items"], depth+1)}
+	case "object":
+		object := make(map[string]interface{})
+		properties, _ := schema["properties"].(map[string]interface{})
+		for _, name := range sortedMapKeys(properties) {
+			object[name] = s.mockValue(properties[name], depth+1)
+		}
+		if additional, ok := schema["additionalProperties"].(map[string]interface{}); ok && len(properties) == 0 {
+			object["key"] = s.mockValue(additional, depth+1)
+		}
+		return object
+	}
+	return nil
+}
+
+// mockString returns a string in the format of schema. Negative length bounds are ignored and the length is capped
+// at mockMaxStringLength
+func mockString(schema map[string]interface{}) string {
+	format, _ := schema["format"].(string)
+	value := "string"
+	switch format {
+	case "date":
+		value = "2020-01-01"
+	case "date-time":
+		value = "2020-01-01T00:00:00Z"
+	case "email":
+		value = "user@example.com"
+	case "uuid":
+		value = "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	case "uri", "url":
+		value = "https://example.com"
+	case "hostname":
+		value = "example.com"
+	case "ipv4":
+		value = "127.0.0.1"
+	case "ipv6":
+		value = "::1"
+	case "byte":
+		value = "c3RyaW5n"
+	}
+	if min, ok := schema["minLength"].(float64); ok && float64(len(value)) < min {
+		if min > mockMaxStringLength {
+			min = mockMaxStringLength
+		}
+		value += strings.Repeat("s", int(min)-len(value))
+	}
+	if max, ok := schema["maxLength"].(float64); ok && max >= 0 && float64(len(value)) > max {
+		value = value[:int(max)]
+	}
+	return value
+}
+
+// PointSandboxEndpointToMock sets the sandbox endpoint of environment in the API params file of the project in
+// projectDir to url. The environment is added to the params file if it does not have it
+func PointSandboxEndpointToMock(projectDir, paramsPath, environment, url string) (string, error) {
+	paramsFile, err := resolveAPIParamsPath(projectDir, paramsPath)
+	if err != nil {
+		return "", err
+	}
+	content, err := ioutil.ReadFile(paramsFile)
+	if err != nil {
+		return "", err
+	}
+	var doc yaml.MapSlice
+	if err = yaml.Unmarshal(content, &doc); err != nil {
+		return "", fmt.Errorf("invalid params file %s: %v", paramsFile, err)
+	}
+
+	environments, _ := mapSliceValue(doc, "environments").([]interface{})
+	index := -1
+	for i, item := range environments {
+		if env, ok := item.(yaml.MapSlice); ok && fmt.Sprint(mapSliceValue(env, "name")) == environment {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		environments = append(environments, yaml.MapSlice{{Key: "name", Value: environment}})
+		index = len(environments) - 1
+	}
+	env := environments[index].(yaml.MapSlice)
+	endpoints, _ := mapSliceValue(env, "endpoints").(yaml.MapSlice)
+	sandbox, _ := mapSliceValue(endpoints, "sandbox").(yaml.MapSlice)
+	endpoints = setMapSliceValue(endpoints, "sandbox", setMapSliceValue(sandbox, "url", url))
+	environments[index] = setMapSliceValue(env, "endpoints", endpoints)
+	doc = setMapSliceValue(doc, "environments", environments)
+
+	content, err = yaml.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	utils.Logln(utils.LogPrefixInfo+"Writing", paramsFile)
+	return paramsFile, ioutil.WriteFile(paramsFile, content, 0644)
+}
@@ -0,0 +1,363 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const mockTestSwagger2 = `swagger: "2.0"
+info:
+  title: PizzaShackAPI
+  version: 1.0.0
+x-wso2-basePath: /pizzashack/{version}
+paths:
+  /menu:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          type: integer
+          maximum: 10
+      responses:
+        "200":
+          description: OK
+          schema:
+            type: array
+            items:
+              $ref: '#/definitions/MenuItem'
+  /order:
+    post:
+      parameters:
+        - name: body
+          in: body
+          required: true
+          schema:
+            $ref: '#/definitions/Order'
+      responses:
+        "201":
+          description: Created
+          examples:
+            application/json:
+              orderId: 42
+  /order/{orderId}:
+    parameters:
+      - name: orderId
+        in: path
+        required: true
+        type: string
+        pattern: ^[0-9]+$
+    get:
+      responses:
+        "200":
+          description: OK
+          schema:
+            $ref: '#/definitions/Order'
+    delete:
+      responses:
+        "204":
+          description: Deleted
+definitions:
+  MenuItem:
+    type: object
+    properties:
+      name:
+        type: string
+        example: Chicken Parmesan
+      price:
+        type: number
+  Order:
+    type: object
+    required: [pizzaType, quantity]
+    properties:
+      pizzaType:
+        type: string
+        enum: [Margherita, Hawaiian]
+      quantity:
+        type: integer
+        minimum: 1
+      delivered:
+        type: boolean
+      createdAt:
+        type: string
+        format: date-time
+`
+
+const mockTestOpenAPI3 = `openapi: 3.0.1
+info:
+  title: Petstore
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: X-Request-ID
+          in: header
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              examples:
+                cats:
+                  value: [{id: 1, name: Tom}]
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      additionalProperties: false
+      properties:
+        id:
+          type: integer
+          minimum: 1
+        name:
+          type: string
+          minLength: 1
+`
+
+func newMockTestServer(t *testing.T, swagger string) (*MockServer, *httptest.Server, string) {
+	projectDir := createValidateTestProject(t, map[string]string{"Meta-information/swagger.yaml": swagger})
+	mock, err := NewMockServer(projectDir)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	return mock, httptest.NewServer(mock), projectDir
+}
+
+func doMockRequest(t *testing.T, method, url, body string, headers map[string]string) (int, string) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	assert.Nil(t, err)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if !assert.Nil(t, err) {
+		return 0, ""
+	}
+	defer resp.Body.Close()
+	content, err := ioutil.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	return resp.StatusCode, string(content)
+}
+
+func TestMockString(t *testing.T) {
+	assert.Equal(t, "string", mockString(map[string]interface{}{}))
+	assert.Equal(t, "stringss", mockString(map[string]interface{}{"minLength": float64(8)}))
+	assert.Equal(t, "str", mockString(map[string]interface{}{"maxLength": float64(3)}))
+	assert.Equal(t, "string", mockString(map[string]interface{}{"minLength": float64(-1),
+		"maxLength": float64(-1)}), "Negative bounds should be ignored")
+	assert.Len(t, mockString(map[string]interface{}{"minLength": float64(1e12)}), mockMaxStringLength,
+		"Length should be capped")
+}
+
+func TestMockServerSwagger2(t *testing.T) {
+	mock, server, projectDir := newMockTestServer(t, mockTestSwagger2)
+	defer os.RemoveAll(projectDir)
+	defer server.Close()
+
+	assert.Equal(t, "/pizzashack/1.0.0", mock.BasePath)
+	assert.Equal(t, []string{"GET /pizzashack/1.0.0/menu", "POST /pizzashack/1.0.0/order",
+		"GET /pizzashack/1.0.0/order/{orderId}", "DELETE /pizzashack/1.0.0/order/{orderId}"}, mock.Operations())
+	base := server.URL + mock.BasePath
+
+	status, body := doMockRequest(t, http.MethodGet, base+"/menu?limit=5", "", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `[{"name":"Chicken Parmesan","price":0}]`, body)
+
+	status, body = doMockRequest(t, http.MethodGet, base+"/order/7", "", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"pizzaType":"Margherita","quantity":1,"delivered":true,"createdAt":"2020-01-01T00:00:00Z"}`,
+		body)
+
+	status, body = doMockRequest(t, http.MethodPost, base+"/order", `{"pizzaType":"Hawaiian","quantity":2}`, nil)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.JSONEq(t, `{"orderId":42}`, body)
+
+	status, _ = doMockRequest(t, http.MethodDelete, base+"/order/7", "", nil)
+	assert.Equal(t, http.StatusNoContent, status)
+
+	status, _ = doMockRequest(t, http.MethodGet, server.URL+"/menu", "", nil)
+	assert.Equal(t, http.StatusNotFound, status, "Paths outside the base path should not be served")
+
+	status, _ = doMockRequest(t, http.MethodPut, base+"/menu", "", nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, status)
+}
+
+func TestMockServerSwagger2InvalidRequests(t *testing.T) {
+	mock, server, projectDir := newMockTestServer(t, mockTestSwagger2)
+	defer os.RemoveAll(projectDir)
+	defer server.Close()
+	base := server.URL + mock.BasePath
+
+	status, body := doMockRequest(t, http.MethodGet, base+"/menu?limit=many", "", nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body, "query parameter limit should be an integer")
+
+	status, body = doMockRequest(t, http.MethodGet, base+"/menu?limit=11", "", nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body, "query parameter limit should not be greater than 10")
+
+	status, body = doMockRequest(t, http.MethodGet, base+"/order/abc", "", nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body, "path parameter orderId should match")
+
+	status, body = doMockRequest(t, http.MethodPost, base+"/order", "", nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body, "body is required")
+
+	status, body = doMockRequest(t, http.MethodPost, base+"/order", `{"pizzaType":"Pepperoni","quantity":0}`, nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body, "body.pizzaType should be one of [Margherita Hawaiian]")
+	assert.Contains(t, body, "body.quantity should not be less than 1")
+
+	status, body = doMockRequest(t, http.MethodPost, base+"/order", `{"pizzaType":"Hawaiian"}`, nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body, "body.quantity is required")
+}
+
+func TestMockServerOpenAPI3(t *testing.T) {
+	mock, server, projectDir := newMockTestServer(t, mockTestOpenAPI3)
+	defer os.RemoveAll(projectDir)
+	defer server.Close()
+	assert.Equal(t, "", mock.BasePath)
+
+	status, body := doMockRequest(t, http.MethodGet, server.URL+"/pets", "", map[string]string{"X-Request-ID": "1"})
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `[{"id":1,"name":"Tom"}]`, body)
+
+	status, body = doMockRequest(t, http.MethodGet, server.URL+"/pets", "", nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body, "header parameter X-Request-ID is required")
+
+	status, body = doMockRequest(t, http.MethodPost, server.URL+"/pets", `{"name":"Rex"}`,
+		map[string]string{"Content-Type": "application/json"})
+	assert.Equal(t, http.StatusCreated, status)
+	assert.JSONEq(t, `{"id":1,"name":"string"}`, body)
+
+	status, body = doMockRequest(t, http.MethodPost, server.URL+"/pets", `{"name":"","age":3}`, nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body, "body.name should have at least 1 characters")
+	assert.Contains(t, body, "body.age is not allowed")
+
+	status, body = doMockRequest(t, http.MethodPost, server.URL+"/pets", `name=Rex`,
+		map[string]string{"Content-Type": "application/x-www-form-urlencoded"})
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body, "content type application/x-www-form-urlencoded is not supported")
+
+	status, _ = doMockRequest(t, http.MethodOptions, server.URL+"/pets", "", nil)
+	assert.Equal(t, http.StatusNoContent, status, "CORS preflight requests should be allowed")
+}
+
+func TestMockServerBasePathFromContext(t *testing.T) {
+	projectDir := createValidateTestProject(t, map[string]string{
+		"Meta-information/api.yaml": validateTestAPI,
+		"Meta-information/swagger.yaml": "swagger: \"2.0\"\nbasePath: /v1\npaths:\n  /menu:\n    get:\n" +
+			"      responses:\n        \"200\":\n          description: OK\n",
+	})
+	defer os.RemoveAll(projectDir)
+
+	mock, err := NewMockServer(projectDir)
+	assert.Nil(t, err)
+	assert.Equal(t, "/pizzashack/1.0.0", mock.BasePath)
+}
+
+func TestMockServerBasePathFromExtension(t *testing.T) {
+	projectDir := createValidateTestProject(t, map[string]string{
+		"Meta-information/api.yaml": validateTestAPI,
+		"Meta-information/swagger.yaml": "swagger: \"2.0\"\ninfo:\n  version: 2.0.0\n" +
+			"x-wso2-basePath: /orders/{version}\npaths:\n  /menu:\n    get:\n" +
+			"      responses:\n        \"200\":\n          description: OK\n",
+	})
+	defer os.RemoveAll(projectDir)
+
+	mock, err := NewMockServer(projectDir)
+	assert.Nil(t, err)
+	assert.Equal(t, "/orders/1.0.0", mock.BasePath,
+		"x-wso2-basePath should be used with the version of the API")
+}
+
+func TestNewMockServerWithoutOperations(t *testing.T) {
+	projectDir := createValidateTestProject(t, map[string]string{
+		"Meta-information/swagger.yaml": "swagger: \"2.0\"\npaths: {}\n",
+	})
+	defer os.RemoveAll(projectDir)
+
+	_, err := NewMockServer(projectDir)
+	assert.NotNil(t, err)
+}
+
+func TestPointSandboxEndpointToMock(t *testing.T) {
+	projectDir := createValidateTestProject(t, map[string]string{
+		"api_params.yaml": `environments:
+  - name: dev
+    endpoints:
+      production:
+        url: https://dev.example.com
+      sandbox:
+`,
+	})
+	defer os.RemoveAll(projectDir)
+
+	paramsFile, err := PointSandboxEndpointToMock(projectDir, "api_params.yaml", "dev", "http://localhost:8080/pizza")
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(projectDir, "api_params.yaml"), paramsFile)
+	_, err = PointSandboxEndpointToMock(projectDir, "api_params.yaml", "test", "http://localhost:8080/pizza")
+	assert.Nil(t, err)
+
+	content, err := ioutil.ReadFile(paramsFile)
+	assert.Nil(t, err)
+	assert.Equal(t, `environments:
+- name: dev
+  endpoints:
+    production:
+      url: https://dev.example.com
+    sandbox:
+      url: http://localhost:8080/pizza
+- name: test
+  endpoints:
+    sandbox:
+      url: http://localhost:8080/pizza
+`, string(content))
+}
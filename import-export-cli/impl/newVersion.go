@@ -0,0 +1,231 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// Lifecycle actions used when rolling out a new version of an API
+const (
+	LifecycleActionPublish   = "Publish"
+	LifecycleActionDeprecate = "Deprecate"
+)
+
+// NewAPIVersionToEnv creates newVersion of an API in environment. See NewAPIVersion
+func NewAPIVersionToEnv(accessToken, environment, name, version, provider, newVersion string, defaultVersion,
+	publish, deprecateOld bool) (*utils.API, error) {
+	apiListEndpoint := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath)
+	unifiedSearchEndpoint := utils.GetUnifiedSearchEndpointOfEnv(environment, utils.MainConfigFilePath)
+	return NewAPIVersion(accessToken, apiListEndpoint, unifiedSearchEndpoint, name, version, provider, newVersion,
+		defaultVersion, publish, deprecateOld)
+}
+
+// NewAPIVersion creates newVersion of an API with the copy-api operation of the Publisher, which is made the default
+// version with defaultVersion. The new version is published with publish and the old version is deprecated once the
+// new version is published with deprecateOld.
+// Returns the new version of the API
+func NewAPIVersion(accessToken, apiListEndpoint, unifiedSearchEndpoint, name, version, provider, newVersion string,
+	defaultVersion, publish, deprecateOld bool) (*utils.API, error) {
+	apiId, err := searchAPIId(accessToken, unifiedSearchEndpoint, name, version, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	apiListEndpoint = utils.AppendSlashToString(apiListEndpoint)
+	headers := make(map[string]string)
+	headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	queryParams := map[string]string{
+		"apiId":          apiId,
+		"newVersion":     newVersion,
+		"defaultVersion": strconv.FormatBool(defaultVersion),
+	}
+	utils.Logln(utils.LogPrefixInfo+"NewAPIVersion: URL:", apiListEndpoint+"copy-api")
+	resp, err := utils.InvokePostRequestWithQueryParam(queryParams, apiListEndpoint+"copy-api", headers, "")
+	if err != nil {
+		return nil, err
+	}
+	utils.Logln(utils.LogPrefixInfo+"Response:", resp.Status())
+	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("creating version %s of %s failed: %s %s", newVersion, name, resp.Status(),
+			resp.Body())
+	}
+	api := &utils.API{}
+	if err = json.Unmarshal(resp.Body(), api); err != nil {
+		return nil, err
+	}
+
+	if publish {
+		if err = changeAPILifecycle(accessToken, apiListEndpoint, api.ID, LifecycleActionPublish); err != nil {
+			return api, fmt.Errorf("publishing version %s of %s failed: %v", newVersion, name, err)
+		}
+		api.LifeCycleStatus = "PUBLISHED"
+	}
+	if deprecateOld {
+		if err = changeAPILifecycle(accessToken, apiListEndpoint, apiId, LifecycleActionDeprecate); err != nil {
+			return api, fmt.Errorf("deprecating version %s of %s failed: %v", version, name, err)
+		}
+	}
+	return api, nil
+}
+
+// changeAPILifecycle changes the lifecycle state of the API with apiId using action
+func changeAPILifecycle(accessToken, apiListEndpoint, apiId, action string) error {
+	headers := make(map[string]string)
+	headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	queryParams := map[string]string{
+		"apiId":  apiId,
+		"action": action,
+	}
+	url := utils.AppendSlashToString(apiListEndpoint) + "change-lifecycle"
+	utils.Logln(utils.LogPrefixInfo+"APIStateChange: URL:", url, "Action:", action)
+	resp, err := utils.InvokePostRequestWithQueryParam(queryParams, url, headers, "")
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return fmt.Errorf("%s %s", resp.Status(), resp.Body())
+	}
+	return nil
+}
+
+// NewAPIProjectVersion rewrites the API project in projectDir as newVersion of the API without connecting to an
+// environment. The version in the API definition, the context derived from the context template and the version in
+// the info of the swagger or AsyncAPI document are changed. The API is made the default version with defaultVersion.
+// Returns the old version of the API
+func NewAPIProjectVersion(projectDir, newVersion string, defaultVersion bool) (string, error) {
+	apiFile, api, err := readProjectDocument(filepath.Join(projectDir, "Meta-information", "api"))
+	if err != nil {
+		return "", err
+	}
+	if apiFile == "" {
+		return "", fmt.Errorf("%s does not have an API definition in Meta-information", projectDir)
+	}
+	id, ok := mapSliceValue(api, "id").(yaml.MapSlice)
+	if !ok {
+		return "", fmt.Errorf("%s does not have an id", apiFile)
+	}
+	oldVersion := fmt.Sprint(mapSliceValue(id, "version"))
+	if oldVersion == newVersion {
+		return "", fmt.Errorf("the API in %s is already version %s", projectDir, newVersion)
+	}
+
+	api = setMapSliceValue(api, "id", setMapSliceValue(id, "version", newVersion))
+	if contextTemplate, ok := mapSliceValue(api, "contextTemplate").(string); ok &&
+		strings.Contains(contextTemplate, "{version}") {
+		api = setMapSliceValue(api, "context", strings.ReplaceAll(contextTemplate, "{version}", newVersion))
+	}
+	api = setMapSliceValue(api, "isDefaultVersion", defaultVersion)
+	if err = writeProjectDocument(apiFile, api); err != nil {
+		return "", err
+	}
+
+	for _, name := range []string{"swagger", "asyncapi"} {
+		file, doc, err := readProjectDocument(filepath.Join(projectDir, "Meta-information", name))
+		if err != nil {
+			return "", err
+		}
+		if file == "" {
+			continue
+		}
+		info, ok := mapSliceValue(doc, "info").(yaml.MapSlice)
+		if !ok {
+			return "", fmt.Errorf("%s does not have an info", file)
+		}
+		doc = setMapSliceValue(doc, "info", setMapSliceValue(info, "version", newVersion))
+		if err = writeProjectDocument(file, doc); err != nil {
+			return "", err
+		}
+	}
+	return oldVersion, nil
+}
+
+// readProjectDocument reads the YAML or JSON document filename (without the extension) keeping the order of its keys.
+// Returns an empty file name if the document does not exist
+func readProjectDocument(filename string) (string, yaml.MapSlice, error) {
+	for _, ext := range []string{".yaml", ".json"} {
+		content, err := ioutil.ReadFile(filename + ext)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		var doc yaml.MapSlice
+		if err = yaml.Unmarshal(content, &doc); err != nil {
+			return "", nil, fmt.Errorf("invalid document %s: %v", filename+ext, err)
+		}
+		return filename + ext, doc, nil
+	}
+	return "", nil, nil
+}
+
+// writeProjectDocument writes doc to file in the format given by the extension of file
+func writeProjectDocument(file string, doc yaml.MapSlice) error {
+	content, err := yaml.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	if filepath.Ext(file) == ".json" {
+		if content, err = utils.YamlToJson(content); err != nil {
+			return err
+		}
+		var indented bytes.Buffer
+		if err = json.Indent(&indented, content, "", "  "); err != nil {
+			return err
+		}
+		content = indented.Bytes()
+	}
+	utils.Logln(utils.LogPrefixInfo+"Writing", file)
+	return ioutil.WriteFile(file, content, 0644)
+}
+
+// mapSliceValue returns the value of key in m or nil if m does not have key
+func mapSliceValue(m yaml.MapSlice, key string) interface{} {
+	for _, item := range m {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return nil
+}
+
+// setMapSliceValue sets the value of key in m, appending key if m does not have it
+func setMapSliceValue(m yaml.MapSlice, key string, value interface{}) yaml.MapSlice {
+	for i, item := range m {
+		if item.Key == key {
+			m[i].Value = value
+			return m
+		}
+	}
+	return append(m, yaml.MapItem{Key: key, Value: value})
+}
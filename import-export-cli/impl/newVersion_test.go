@@ -0,0 +1,144 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func newAPIVersionTestServer(t *testing.T, lifecycleActions *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+		switch r.URL.Path {
+		case "/search":
+			assert.Equal(t, `name:"PizzaShackAPI" version:"1.0.0"`, r.URL.Query().Get("query"))
+			_, _ = w.Write([]byte(`{"count":1,"list":[{"id":"old-id","name":"PizzaShackAPI","version":"1.0.0"}]}`))
+		case "/apis/copy-api":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "old-id", r.URL.Query().Get("apiId"))
+			assert.Equal(t, "2.0.0", r.URL.Query().Get("newVersion"))
+			assert.Equal(t, "true", r.URL.Query().Get("defaultVersion"))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"new-id","name":"PizzaShackAPI","version":"2.0.0",` +
+				`"context":"/pizzashack/2.0.0","lifeCycleStatus":"CREATED"}`))
+		case "/apis/change-lifecycle":
+			*lifecycleActions = append(*lifecycleActions, r.URL.Query().Get("action")+":"+r.URL.Query().Get("apiId"))
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestNewAPIVersion(t *testing.T) {
+	var actions []string
+	server := newAPIVersionTestServer(t, &actions)
+	defer server.Close()
+
+	api, err := NewAPIVersion("token", server.URL+"/apis", server.URL+"/search", "PizzaShackAPI", "1.0.0", "",
+		"2.0.0", true, false, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "new-id", api.ID)
+	assert.Equal(t, "/pizzashack/2.0.0", api.Context)
+	assert.Empty(t, actions, "Lifecycle should not be changed")
+}
+
+func TestNewAPIVersionPublishedAndOldDeprecated(t *testing.T) {
+	var actions []string
+	server := newAPIVersionTestServer(t, &actions)
+	defer server.Close()
+
+	api, err := NewAPIVersion("token", server.URL+"/apis", server.URL+"/search", "PizzaShackAPI", "1.0.0", "",
+		"2.0.0", true, true, true)
+	assert.Nil(t, err)
+	assert.Equal(t, "PUBLISHED", api.LifeCycleStatus)
+	assert.Equal(t, []string{"Publish:new-id", "Deprecate:old-id"}, actions)
+}
+
+func TestNewAPIVersionWhenCopyFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/search" {
+			_, _ = w.Write([]byte(`{"count":1,"list":[{"id":"old-id"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"description":"version already exists"}`))
+	}))
+	defer server.Close()
+
+	_, err := NewAPIVersion("token", server.URL+"/apis", server.URL+"/search", "PizzaShackAPI", "1.0.0", "",
+		"2.0.0", false, true, true)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "version already exists")
+	}
+}
+
+func TestNewAPIProjectVersion(t *testing.T) {
+	projectDir := createValidateTestProject(t, map[string]string{
+		"Meta-information/api.yaml": `id:
+  providerName: admin
+  apiName: PizzaShackAPI
+  version: 1.0.0
+context: /pizzashack/1.0.0
+contextTemplate: /pizzashack/{version}
+isDefaultVersion: false
+`,
+		"Meta-information/swagger.json": `{"swagger":"2.0","info":{"title":"PizzaShackAPI","version":"1.0.0"}}`,
+	})
+	defer os.RemoveAll(projectDir)
+
+	oldVersion, err := NewAPIProjectVersion(projectDir, "2.0.0", true)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.0", oldVersion)
+
+	api, err := ioutil.ReadFile(filepath.Join(projectDir, "Meta-information", "api.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, `id:
+  providerName: admin
+  apiName: PizzaShackAPI
+  version: 2.0.0
+context: /pizzashack/2.0.0
+contextTemplate: /pizzashack/{version}
+isDefaultVersion: true
+`, string(api))
+
+	swagger, err := ioutil.ReadFile(filepath.Join(projectDir, "Meta-information", "swagger.json"))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"swagger":"2.0","info":{"title":"PizzaShackAPI","version":"2.0.0"}}`, string(swagger))
+
+	_, err = NewAPIProjectVersion(projectDir, "2.0.0", true)
+	assert.NotNil(t, err, "Changing to the same version should fail")
+}
+
+func TestNewAPIProjectVersionWithoutAPIDefinition(t *testing.T) {
+	projectDir := createValidateTestProject(t, map[string]string{"README.md": ""})
+	defer os.RemoveAll(projectDir)
+
+	_, err := NewAPIProjectVersion(projectDir, "2.0.0", false)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,396 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Override sets a field of api.yaml given by a path such as endpointConfig.production_endpoints.url or
+// uriTemplates[0].throttlingTier
+type Override struct {
+	Path  string
+	Value string
+	// FromFile is true when Value is the content of a file given with --set-file
+	FromFile bool
+}
+
+// ParseOverrides parses the values of --set (path=value) and --set-file (path=file) flags
+func ParseOverrides(setValues, setFiles []string) ([]Override, error) {
+	var overrides []Override
+	for _, setValue := range setValues {
+		path, value, err := splitOverride(setValue)
+		if err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, Override{Path: path, Value: value})
+	}
+	for _, setFile := range setFiles {
+		path, file, err := splitOverride(setFile)
+		if err != nil {
+			return nil, err
+		}
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, Override{Path: path, Value: string(content), FromFile: true})
+	}
+	return overrides, nil
+}
+
+func splitOverride(s string) (string, string, error) {
+	index := strings.Index(s, "=")
+	if index <= 0 {
+		return "", "", fmt.Errorf("invalid override %q, expected path=value", s)
+	}
+	return strings.TrimSpace(s[:index]), s[index+1:], nil
+}
+
+// applyOverrides sets the overrides in Meta-information/api.yaml of the project in apiDirectory. schema is the type of
+// the definition (v2.APIDefinition or v2.APIProductDefinition), which gives the keys that can be added to api.yaml
+func applyOverrides(apiDirectory string, schema reflect.Type, overrides []Override) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+	fp, jsonContent, err := resolveYamlOrJson(filepath.Join(apiDirectory, "Meta-information", "api"))
+	if err != nil {
+		return err
+	}
+	utils.Logln(utils.LogPrefixInfo+"Applying overrides to:", fp)
+	api, err := gabs.ParseJSON(jsonContent)
+	if err != nil {
+		return err
+	}
+	for _, override := range overrides {
+		segments, err := splitOverridePath(override.Path)
+		if err != nil {
+			return err
+		}
+		if err := setOverride(api, schema, segments, 0, override); err != nil {
+			return err
+		}
+	}
+
+	content, err := utils.JsonToYaml(api.Bytes())
+	if err != nil {
+		return err
+	}
+	apiPath := filepath.Join(apiDirectory, "Meta-information", "api.yaml")
+	utils.Logln(utils.LogPrefixInfo+"Writing", apiPath)
+	return ioutil.WriteFile(apiPath, content, 0644)
+}
+
+// splitOverridePath splits a path into keys and list indexes. a.b[0].c and a.b.0.c are equivalent and dots in
+// keys can be escaped as \.
+func splitOverridePath(path string) ([]string, error) {
+	var segments []string
+	var current strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; {
+		case c == '\\' && i+1 < len(path) && path[i+1] == '.':
+			current.WriteByte('.')
+			i++
+		case c == '.':
+			segments = append(segments, current.String())
+			current.Reset()
+		case c == '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q, missing ]", path)
+			}
+			if current.Len() > 0 {
+				segments = append(segments, current.String())
+				current.Reset()
+			}
+			segments = append(segments, path[i+1:i+end])
+			i += end
+			// a dot may follow the index
+			if i+1 < len(path) && path[i+1] == '.' {
+				i++
+			}
+		default:
+			current.WriteByte(c)
+		}
+	}
+	if current.Len() > 0 {
+		segments = append(segments, current.String())
+	}
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, fmt.Errorf("invalid path %q", path)
+		}
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("invalid path %q", path)
+	}
+	return segments, nil
+}
+
+// endpointSchema is an endpoint of the endpointConfig of api.yaml
+type endpointSchema struct {
+	Url    string            `json:"url"`
+	Config map[string]string `json:"config"`
+}
+
+// endpointConfigSchema lists the fields of the endpointConfig of api.yaml, which is a JSON document stored as a
+// string. Load balanced endpoints are lists of endpoints
+type endpointConfigSchema struct {
+	EndpointType        string                 `json:"endpoint_type"`
+	ProductionEndpoints *endpointSchema        `json:"production_endpoints"`
+	SandboxEndpoints    *endpointSchema        `json:"sandbox_endpoints"`
+	ProductionFailovers []endpointSchema       `json:"production_failovers"`
+	SandboxFailovers    []endpointSchema       `json:"sandbox_failovers"`
+	AlgoClassName       string                 `json:"algoClassName"`
+	AlgoCombo           string                 `json:"algoCombo"`
+	SessionManagement   string                 `json:"sessionManagement"`
+	SessionTimeOut      string                 `json:"sessionTimeOut"`
+	FailOver            string                 `json:"failOver"`
+	EndpointSecurity    map[string]interface{} `json:"endpoint_security"`
+}
+
+// overrideDocuments are the fields which are JSON documents stored as strings, with the schema of the documents
+var overrideDocuments = map[string]reflect.Type{
+	"endpointConfig": reflect.TypeOf(endpointConfigSchema{}),
+}
+
+// schemaField returns the schema of the field key of schema, which is the json tag of a struct field. Maps accept any
+// key and a nil schema is free form
+func schemaField(schema reflect.Type, key string) (reflect.Type, bool) {
+	if schema == nil {
+		return nil, true
+	}
+	for schema.Kind() == reflect.Ptr {
+		schema = schema.Elem()
+	}
+	switch schema.Kind() {
+	case reflect.Interface:
+		return nil, true
+	case reflect.Map:
+		return schema.Elem(), true
+	case reflect.Struct:
+		for i := 0; i < schema.NumField(); i++ {
+			field := schema.Field(i)
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if name != "" && name != "-" && name == key {
+				return field.Type, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// elementSchema returns the schema of the items of a list. A struct is used for the items as well, since endpoints
+// can be given as a list
+func elementSchema(schema reflect.Type) reflect.Type {
+	if schema == nil {
+		return nil
+	}
+	for schema.Kind() == reflect.Ptr {
+		schema = schema.Elem()
+	}
+	switch schema.Kind() {
+	case reflect.Slice, reflect.Array:
+		return schema.Elem()
+	case reflect.Struct:
+		return schema
+	}
+	return nil
+}
+
+// newOverrideValue returns an empty value of schema, used to convert the values which are not in api.yaml yet. nil
+// is returned for free form values
+func newOverrideValue(schema reflect.Type) interface{} {
+	if schema == nil {
+		return nil
+	}
+	for schema.Kind() == reflect.Ptr {
+		schema = schema.Elem()
+	}
+	switch schema.Kind() {
+	case reflect.String:
+		return ""
+	case reflect.Bool:
+		return false
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8,
+		reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return float64(0)
+	case reflect.Slice, reflect.Array:
+		return []interface{}{}
+	case reflect.Map, reflect.Struct:
+		return map[string]interface{}{}
+	}
+	return nil
+}
+
+// newOverrideContainer returns an empty object or list for the key which is not in api.yaml yet, so the rest of
+// the path can be set in it. Documents stored as strings are created empty
+func newOverrideContainer(key string, schema reflect.Type) (interface{}, bool) {
+	if _, isDocument := overrideDocuments[key]; isDocument {
+		return "{}", true
+	}
+	if schema == nil {
+		return map[string]interface{}{}, true
+	}
+	for schema.Kind() == reflect.Ptr {
+		schema = schema.Elem()
+	}
+	switch schema.Kind() {
+	case reflect.Slice, reflect.Array:
+		return []interface{}{}, true
+	case reflect.Map, reflect.Struct, reflect.Interface:
+		return map[string]interface{}{}, true
+	}
+	return nil, false
+}
+
+// setOverride sets the value of override at segments[index:] in container. schema describes container and is used
+// to validate and create the keys which are not in api.yaml yet
+func setOverride(container *gabs.Container, schema reflect.Type, segments []string, index int,
+	override Override) error {
+	segment := segments[index]
+	last := index == len(segments)-1
+	traversed := strings.Join(segments[:index+1], ".")
+
+	var child *gabs.Container
+	var childSchema reflect.Type
+	if array, ok := container.Data().([]interface{}); ok {
+		i, err := strconv.Atoi(segment)
+		if err != nil {
+			return fmt.Errorf("%s in api.yaml is a list, %q is not a valid index", strings.Join(segments[:index], "."),
+				segment)
+		}
+		if i < 0 || i >= len(array) {
+			return fmt.Errorf("index %d of %s is out of range, the list has %d items", i,
+				strings.Join(segments[:index], "."), len(array))
+		}
+		childSchema = elementSchema(schema)
+		if last {
+			value, err := coerceOverride(array[i], childSchema, override)
+			if err != nil {
+				return fmt.Errorf("invalid value for %s: %v", traversed, err)
+			}
+			_, err = container.SetIndex(value, i)
+			return err
+		}
+		child = container.Index(i)
+	} else if object, ok := container.Data().(map[string]interface{}); ok {
+		current, exists := object[segment]
+		fieldSchema, known := schemaField(schema, segment)
+		if !exists && !known {
+			return fmt.Errorf("unknown path %q in api.yaml", traversed)
+		}
+		childSchema = fieldSchema
+		if last {
+			value, err := coerceOverride(current, childSchema, override)
+			if err != nil {
+				return fmt.Errorf("invalid value for %s: %v", traversed, err)
+			}
+			_, err = container.Set(value, segment)
+			return err
+		}
+		if current == nil {
+			value, ok := newOverrideContainer(segment, childSchema)
+			if !ok {
+				return fmt.Errorf("unknown path %q in api.yaml, %s is not an object", strings.Join(segments, "."),
+					traversed)
+			}
+			if _, err := container.Set(value, segment); err != nil {
+				return err
+			}
+		}
+		child = container.S(segment)
+	} else {
+		return fmt.Errorf("unknown path %q in api.yaml", traversed)
+	}
+
+	// values such as endpointConfig are JSON documents stored as strings
+	if content, ok := child.Data().(string); ok {
+		document, err := gabs.ParseJSON([]byte(content))
+		if err != nil {
+			return fmt.Errorf("unknown path %q in api.yaml, %s is a string",
+				strings.Join(segments, "."), traversed)
+		}
+		if err := setOverride(document, overrideDocuments[segment], segments, index+1, override); err != nil {
+			return err
+		}
+		if array, ok := container.Data().([]interface{}); ok {
+			i, _ := strconv.Atoi(segment)
+			array[i] = document.String()
+			return nil
+		}
+		_, err = container.Set(document.String(), segment)
+		return err
+	}
+	return setOverride(child, childSchema, segments, index+1, override)
+}
+
+// coerceOverride converts the value of override to the type of the current value, or to the type given by schema
+// when there is no current value. Lists and objects are given in JSON or YAML. The type is inferred when neither
+// is known
+func coerceOverride(current interface{}, schema reflect.Type, override Override) (interface{}, error) {
+	if current == nil {
+		current = newOverrideValue(schema)
+	}
+	value := override.Value
+	switch current.(type) {
+	case string:
+		return value, nil
+	case bool:
+		return strconv.ParseBool(strings.TrimSpace(value))
+	case float64:
+		return strconv.ParseFloat(strings.TrimSpace(value), 64)
+	case []interface{}, map[string]interface{}:
+		var parsed interface{}
+		jsonValue, err := utils.YamlToJson([]byte(value))
+		if err == nil {
+			err = json.Unmarshal(jsonValue, &parsed)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, isList := current.([]interface{}); isList {
+			if _, ok := parsed.([]interface{}); !ok {
+				return nil, fmt.Errorf("expected a list but found %q", value)
+			}
+		} else if _, ok := parsed.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("expected an object but found %q", value)
+		}
+		return parsed, nil
+	}
+	if override.FromFile {
+		return value, nil
+	}
+	if b, err := strconv.ParseBool(value); err == nil {
+		return b, nil
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f, nil
+	}
+	return value, nil
+}
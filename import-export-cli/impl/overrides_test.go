@@ -0,0 +1,191 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+
+	"github.com/Jeffail/gabs"
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestSplitOverridePath(t *testing.T) {
+	segments, err := splitOverridePath("uriTemplates[0].throttlingTier")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"uriTemplates", "0", "throttlingTier"}, segments)
+
+	segments, err = splitOverridePath("uriTemplates.0.throttlingTiers[1]")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"uriTemplates", "0", "throttlingTiers", "1"}, segments)
+
+	segments, err = splitOverridePath(`additionalProperties.app\.region`)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"additionalProperties", "app.region"}, segments)
+
+	_, err = splitOverridePath("uriTemplates..throttlingTier")
+	assert.NotNil(t, err, "Empty keys should return an error")
+	_, err = splitOverridePath("uriTemplates[0")
+	assert.NotNil(t, err, "Unterminated indexes should return an error")
+}
+
+func TestParseOverrides(t *testing.T) {
+	file, err := ioutil.TempFile("", "description")
+	assert.Nil(t, err)
+	defer os.Remove(file.Name())
+	_, _ = file.WriteString("Pizza ordering API")
+	_ = file.Close()
+
+	overrides, err := ParseOverrides([]string{"transports=http,https"}, []string{"description=" + file.Name()})
+	assert.Nil(t, err)
+	assert.Equal(t, []Override{
+		{Path: "transports", Value: "http,https"},
+		{Path: "description", Value: "Pizza ordering API", FromFile: true},
+	}, overrides)
+
+	_, err = ParseOverrides([]string{"context"}, nil)
+	assert.NotNil(t, err, "Overrides without a value should return an error")
+}
+
+func TestApplyOverrides(t *testing.T) {
+	projectDir := createParamsTestProject(t)
+	defer os.RemoveAll(projectDir)
+
+	err := applyOverrides(projectDir, reflect.TypeOf(v2.APIDefinition{}), []Override{
+		{Path: "context", Value: "/pizzashack-pr42/1.0.0"},
+		{Path: "endpointConfig.production_endpoints.url", Value: "http://pr42.dev.pizzashack.com"},
+		{Path: "uriTemplates[1].throttlingTier", Value: "Gold"},
+		{Path: "cacheTimeout", Value: "100"},
+		{Path: "isDefaultVersion", Value: "true"},
+		{Path: "tags", Value: "[pizza, pr42]"},
+		{Path: "additionalProperties.build", Value: "42"},
+	})
+	assert.Nil(t, err, "Error should be nil")
+	api := readMergedAPI(t, projectDir)
+
+	assert.Equal(t, "/pizzashack-pr42/1.0.0", api.S("context").Data())
+	assert.Equal(t, "Gold", api.S("uriTemplates").Index(1).S("throttlingTier").Data())
+	assert.Equal(t, float64(100), api.S("cacheTimeout").Data())
+	assert.Equal(t, true, api.S("isDefaultVersion").Data())
+	assert.Equal(t, []interface{}{"pizza", "pr42"}, api.S("tags").Data())
+	assert.Equal(t, "42", api.Path("additionalProperties.build").Data(), "Additional properties are strings")
+
+	endpointConfig, err := gabs.ParseJSON([]byte(api.S("endpointConfig").Data().(string)))
+	assert.Nil(t, err, "endpointConfig should be kept as a JSON string")
+	assert.Equal(t, "http://pr42.dev.pizzashack.com", endpointConfig.Path("production_endpoints.url").Data())
+	assert.Equal(t, "40", endpointConfig.Path("production_endpoints.config.suspendDuration").Data())
+}
+
+func TestApplyOverridesErrors(t *testing.T) {
+	projectDir := createParamsTestProject(t)
+	defer os.RemoveAll(projectDir)
+
+	tests := map[string]Override{
+		"unknown path":       {Path: "contxt", Value: "/pizza"},
+		"unknown nested key": {Path: "corsConfiguration.enabled", Value: "true"},
+		"index out of range": {Path: "uriTemplates[10].throttlingTier", Value: "Gold"},
+		"invalid index":      {Path: "uriTemplates.first.throttlingTier", Value: "Gold"},
+		"invalid boolean":    {Path: "isDefaultVersion", Value: "yes please"},
+		"invalid number":     {Path: "cacheTimeout", Value: "ten"},
+		"list expected":      {Path: "tags", Value: "pizza"},
+		"path into a string": {Path: "context.value", Value: "/pizza"},
+		"unknown new key":    {Path: "endpointConfig.sandbox_endpoint.url", Value: "http://localhost"},
+	}
+	for name, override := range tests {
+		err := applyOverrides(projectDir, reflect.TypeOf(v2.APIDefinition{}), []Override{override})
+		assert.NotNil(t, err, name+" should return an error")
+	}
+	assert.False(t, utils.IsFileExist(filepath.Join(projectDir, "Meta-information", "api.yaml")),
+		"api.yaml should not be written when overrides fail")
+}
+
+func TestApplyOverridesAbsentFields(t *testing.T) {
+	projectDir, err := ioutil.TempDir("", "project")
+	assert.Nil(t, err)
+	defer os.RemoveAll(projectDir)
+	metaDir := filepath.Join(projectDir, "Meta-information")
+	assert.Nil(t, os.MkdirAll(metaDir, os.ModePerm))
+	// api.yaml of a new project, without a description, CORS configuration or sandbox endpoints
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(metaDir, "api.yaml"), []byte(`id:
+  providerName: admin
+  apiName: PizzaShackAPI
+  version: 1.0.0
+context: /pizzashack/1.0.0
+endpointConfig: '{"endpoint_type":"http","production_endpoints":{"url":"http://localhost:9443"}}'
+`), 0644))
+
+	err = applyOverrides(projectDir, reflect.TypeOf(v2.APIDefinition{}), []Override{
+		{Path: "description", Value: "Pizza ordering API"},
+		{Path: "businessOwner", Value: "Jane Roe", FromFile: true},
+		{Path: "cacheTimeout", Value: "300"},
+		{Path: "corsConfiguration.corsConfigurationEnabled", Value: "true"},
+		{Path: "corsConfiguration.accessControlAllowOrigins", Value: "[https://pizzashack.example.com]"},
+		{Path: "additionalProperties.build", Value: "42"},
+		{Path: "endpointConfig.sandbox_endpoints.url", Value: "http://sandbox.pizzashack.com"},
+		{Path: "endpointConfig.sandbox_endpoints.config.suspendDuration", Value: "40"},
+	})
+	assert.Nil(t, err, "Error should be nil")
+	api := readMergedAPI(t, projectDir)
+
+	assert.Equal(t, "Pizza ordering API", api.S("description").Data())
+	assert.Equal(t, "Jane Roe", api.S("businessOwner").Data())
+	assert.Equal(t, float64(300), api.S("cacheTimeout").Data())
+	assert.Equal(t, true, api.Path("corsConfiguration.corsConfigurationEnabled").Data())
+	assert.Equal(t, []interface{}{"https://pizzashack.example.com"},
+		api.Path("corsConfiguration.accessControlAllowOrigins").Data())
+	assert.Equal(t, "42", api.Path("additionalProperties.build").Data())
+
+	endpointConfig, err := gabs.ParseJSON([]byte(api.S("endpointConfig").Data().(string)))
+	assert.Nil(t, err)
+	assert.Equal(t, "http://localhost:9443", endpointConfig.Path("production_endpoints.url").Data())
+	assert.Equal(t, "http://sandbox.pizzashack.com", endpointConfig.Path("sandbox_endpoints.url").Data())
+	assert.Equal(t, "40", endpointConfig.Path("sandbox_endpoints.config.suspendDuration").Data(),
+		"Endpoint configs are strings")
+}
+
+func TestApplyOverridesWithoutEndpointConfig(t *testing.T) {
+	projectDir, err := ioutil.TempDir("", "project")
+	assert.Nil(t, err)
+	defer os.RemoveAll(projectDir)
+	metaDir := filepath.Join(projectDir, "Meta-information")
+	assert.Nil(t, os.MkdirAll(metaDir, os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(metaDir, "api.yaml"), []byte("context: /pizzashack/1.0.0\n"), 0644))
+
+	err = applyOverrides(projectDir, reflect.TypeOf(v2.APIDefinition{}), []Override{
+		{Path: "endpointConfig.endpoint_type", Value: "http"},
+		{Path: "endpointConfig.production_endpoints.url", Value: "http://localhost:9443"},
+	})
+	assert.Nil(t, err, "Error should be nil")
+	api := readMergedAPI(t, projectDir)
+	endpointConfig, err := gabs.ParseJSON([]byte(api.S("endpointConfig").Data().(string)))
+	assert.Nil(t, err, "endpointConfig should be created as a JSON string")
+	assert.Equal(t, "http", endpointConfig.S("endpoint_type").Data())
+	assert.Equal(t, "http://localhost:9443", endpointConfig.Path("production_endpoints.url").Data())
+
+	err = applyOverrides(projectDir, reflect.TypeOf(v2.APIDefinition{}), []Override{
+		{Path: "description.text", Value: "Pizza ordering API"},
+	})
+	assert.NotNil(t, err, "Paths into absent strings should return an error")
+}
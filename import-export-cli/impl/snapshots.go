@@ -0,0 +1,223 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// snapshotIDLayout is the time layout used to generate snapshot IDs. IDs sort in the order they were created
+const snapshotIDLayout = "20060102-150405.000"
+
+// APISnapshot holds information about a snapshot of an API taken before it was overwritten
+type APISnapshot struct {
+	ID          string
+	Environment string
+	Name        string
+	Version     string
+	Path        string
+	Created     time.Time
+}
+
+// getAPISnapshotsDir returns the directory holding snapshots of the API in the given environment. Snapshots are
+// stored in <name>/<version> since a separator in a single directory name could also appear in names or versions
+func getAPISnapshotsDir(snapshotsDir, environment, name, version string) string {
+	return filepath.Join(snapshotsDir, environment, utils.SnapshotApisDirName, name, version)
+}
+
+// CreateAPISnapshot exports the API in the environment and stores it in the local snapshot store.
+// Older snapshots are removed according to utils.SnapshotRetention, except the snapshot with the id pinnedID
+// @return snapshot taken
+func CreateAPISnapshot(accessOAuthToken, adminEndpoint, environment, name, version, provider,
+	pinnedID string) (*APISnapshot, error) {
+	return createAPISnapshot(utils.DefaultSnapshotsDirPath, accessOAuthToken, adminEndpoint, environment, name, version,
+		provider, pinnedID, utils.SnapshotRetention)
+}
+
+func createAPISnapshot(snapshotsDir, accessOAuthToken, adminEndpoint, environment, name, version, provider,
+	pinnedID string, retention int) (*APISnapshot, error) {
+	dir := getAPISnapshotsDir(snapshotsDir, environment, name, version)
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+
+	created := time.Now().UTC()
+	snapshot := &APISnapshot{
+		ID:          created.Format(snapshotIDLayout),
+		Environment: environment,
+		Name:        name,
+		Version:     version,
+		Created:     created,
+	}
+	snapshot.Path = filepath.Join(dir, snapshot.ID+".zip")
+	utils.Logln(utils.LogPrefixInfo+"Writing snapshot to", snapshot.Path)
+	err = ExportAPIToFile(name, version, provider, "", adminEndpoint, accessOAuthToken, true, snapshot.Path)
+	if err != nil {
+		return nil, fmt.Errorf("error exporting API for the snapshot: %v", err)
+	}
+
+	err = pruneAPISnapshots(snapshotsDir, environment, name, version, pinnedID, retention)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// pruneAPISnapshots removes the oldest snapshots of the API so that only retention number of snapshots are kept.
+// The snapshot with the id pinnedID is never removed
+func pruneAPISnapshots(snapshotsDir, environment, name, version, pinnedID string, retention int) error {
+	snapshots, err := listAPISnapshots(snapshotsDir, environment, name, version)
+	if err != nil {
+		return err
+	}
+	for i := retention; i < len(snapshots); i++ {
+		if snapshots[i].ID == pinnedID {
+			continue
+		}
+		utils.Logln(utils.LogPrefixInfo+"Removing old snapshot", snapshots[i].Path)
+		err = os.Remove(snapshots[i].Path)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListAPISnapshots returns snapshots in the environment, newest first.
+// When name and version are empty snapshots of all APIs are returned
+func ListAPISnapshots(environment, name, version string) ([]APISnapshot, error) {
+	return listAPISnapshots(utils.DefaultSnapshotsDirPath, environment, name, version)
+}
+
+func listAPISnapshots(snapshotsDir, environment, name, version string) ([]APISnapshot, error) {
+	apisDir := filepath.Join(snapshotsDir, environment, utils.SnapshotApisDirName)
+	nameDirs, err := readSnapshotDirs(apisDir, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []APISnapshot
+	for _, apiName := range nameDirs {
+		versionDirs, err := readSnapshotDirs(filepath.Join(apisDir, apiName), version)
+		if err != nil {
+			return nil, err
+		}
+		for _, apiVersion := range versionDirs {
+			dir := getAPISnapshotsDir(snapshotsDir, environment, apiName, apiVersion)
+			files, err := ioutil.ReadDir(dir)
+			if err != nil {
+				return nil, err
+			}
+			for _, file := range files {
+				id := strings.TrimSuffix(file.Name(), ".zip")
+				created, err := time.Parse(snapshotIDLayout, id)
+				if file.IsDir() || filepath.Ext(file.Name()) != ".zip" || err != nil {
+					continue
+				}
+				snapshots = append(snapshots, APISnapshot{
+					ID:          id,
+					Environment: environment,
+					Name:        apiName,
+					Version:     apiVersion,
+					Path:        filepath.Join(dir, file.Name()),
+					Created:     created,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Created.After(snapshots[j].Created)
+	})
+	return snapshots, nil
+}
+
+// readSnapshotDirs returns names of the directories in dir, only the directory named filter when it is not empty.
+// A missing dir has no directories
+func readSnapshotDirs(dir, filter string) ([]string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var dirs []string
+	for _, entry := range entries {
+		if entry.IsDir() && (filter == "" || filter == entry.Name()) {
+			dirs = append(dirs, entry.Name())
+		}
+	}
+	return dirs, nil
+}
+
+// findAPISnapshot returns the snapshot with the given id. The latest snapshot is returned when id is empty
+func findAPISnapshot(snapshotsDir, environment, name, version, id string) (*APISnapshot, error) {
+	snapshots, err := listAPISnapshots(snapshotsDir, environment, name, version)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("no snapshots found for API %s %s in environment %s", name, version, environment)
+	}
+	if id == "" {
+		return &snapshots[0], nil
+	}
+	for i := range snapshots {
+		if snapshots[i].ID == id {
+			return &snapshots[i], nil
+		}
+	}
+	return nil, errors.New("snapshot " + id + " not found for API " + name + " " + version)
+}
+
+// RollbackAPIToEnv function is used with rollback api command
+func RollbackAPIToEnv(accessOAuthToken, environment, name, version, snapshotID string) (*APISnapshot, error) {
+	adminEndpoint := utils.GetAdminEndpointOfEnv(environment, utils.MainConfigFilePath)
+	return RollbackAPI(accessOAuthToken, adminEndpoint, environment, name, version, snapshotID)
+}
+
+// RollbackAPI re-imports a snapshot of the API to the environment.
+// The current state of the API is snapshotted as well, so a rollback can be reverted. The restored snapshot is
+// pinned so that it is not pruned while it is being imported
+// @return snapshot that was restored
+func RollbackAPI(accessOAuthToken, adminEndpoint, environment, name, version, snapshotID string) (*APISnapshot,
+	error) {
+	snapshot, err := findAPISnapshot(utils.DefaultSnapshotsDirPath, environment, name, version, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	utils.Logln(utils.LogPrefixInfo+"Restoring snapshot", snapshot.Path)
+	err = ImportAPI(accessOAuthToken, adminEndpoint, environment, snapshot.Path, "",
+		ImportAPIOptions{Update: true, PreserveProvider: true, PinnedSnapshotID: snapshot.ID})
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,161 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func getSnapshotTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected '%s', got '%s' instead\n", http.MethodGet, r.Method)
+		}
+		assert.Equal(t, "/export/api", r.URL.Path)
+		assert.Equal(t, "PizzaShackAPI", r.URL.Query().Get("name"))
+		assert.Equal(t, "admin", r.URL.Query().Get("providerName"))
+		w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationZip)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("zip"))
+	}))
+}
+
+func TestCreateAPISnapshot(t *testing.T) {
+	server := getSnapshotTestServer(t)
+	defer server.Close()
+	snapshotsDir, err := ioutil.TempDir("", "snapshots")
+	assert.Nil(t, err)
+	defer os.RemoveAll(snapshotsDir)
+
+	snapshot, err := createAPISnapshot(snapshotsDir, "access_token", server.URL, "dev", "PizzaShackAPI", "1.0.0",
+		"admin", "", 5)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, filepath.Join(snapshotsDir, "dev", "apis", "PizzaShackAPI", "1.0.0", snapshot.ID+".zip"),
+		snapshot.Path)
+	content, err := ioutil.ReadFile(snapshot.Path)
+	assert.Nil(t, err)
+	assert.Equal(t, "zip", string(content))
+}
+
+func TestCreateAPISnapshotRetention(t *testing.T) {
+	server := getSnapshotTestServer(t)
+	defer server.Close()
+	snapshotsDir, err := ioutil.TempDir("", "snapshots")
+	assert.Nil(t, err)
+	defer os.RemoveAll(snapshotsDir)
+
+	var ids []string
+	for i := 0; i < 4; i++ {
+		snapshot, err := createAPISnapshot(snapshotsDir, "access_token", server.URL, "dev", "PizzaShackAPI",
+			"1.0.0", "admin", "", 2)
+		assert.Nil(t, err, "Error should be nil")
+		ids = append(ids, snapshot.ID)
+		// snapshot ids have millisecond precision
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	snapshots, err := listAPISnapshots(snapshotsDir, "dev", "PizzaShackAPI", "1.0.0")
+	assert.Nil(t, err)
+	assert.Len(t, snapshots, 2, "Only the latest snapshots should be retained")
+	assert.Equal(t, ids[3], snapshots[0].ID, "Latest snapshot should be listed first")
+	assert.Equal(t, ids[2], snapshots[1].ID)
+}
+
+func TestCreateAPISnapshotKeepsPinned(t *testing.T) {
+	server := getSnapshotTestServer(t)
+	defer server.Close()
+	snapshotsDir, err := ioutil.TempDir("", "snapshots")
+	assert.Nil(t, err)
+	defer os.RemoveAll(snapshotsDir)
+	dir := getAPISnapshotsDir(snapshotsDir, "dev", "PizzaShackAPI", "1.0.0")
+	assert.Nil(t, os.MkdirAll(dir, os.ModePerm))
+	for _, id := range []string{"20200101-100000.000", "20200102-100000.000"} {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, id+".zip"), []byte("zip"), 0644))
+	}
+
+	// rolling back to the oldest snapshot at the retention limit
+	snapshot, err := createAPISnapshot(snapshotsDir, "access_token", server.URL, "dev", "PizzaShackAPI", "1.0.0",
+		"admin", "20200101-100000.000", 1)
+	assert.Nil(t, err, "Error should be nil")
+
+	snapshots, err := listAPISnapshots(snapshotsDir, "dev", "PizzaShackAPI", "1.0.0")
+	assert.Nil(t, err)
+	var ids []string
+	for _, s := range snapshots {
+		ids = append(ids, s.ID)
+	}
+	assert.Equal(t, []string{snapshot.ID, "20200101-100000.000"}, ids,
+		"Pinned snapshot should be kept and older snapshots should be removed")
+}
+
+func TestFindAPISnapshot(t *testing.T) {
+	snapshotsDir, err := ioutil.TempDir("", "snapshots")
+	assert.Nil(t, err)
+	defer os.RemoveAll(snapshotsDir)
+	dir := getAPISnapshotsDir(snapshotsDir, "dev", "PizzaShackAPI", "1.0.0")
+	assert.Nil(t, os.MkdirAll(dir, os.ModePerm))
+	for _, id := range []string{"20200101-100000.000", "20200102-100000.000"} {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, id+".zip"), []byte("zip"), 0644))
+	}
+
+	snapshot, err := findAPISnapshot(snapshotsDir, "dev", "PizzaShackAPI", "1.0.0", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "20200102-100000.000", snapshot.ID, "Latest snapshot should be returned by default")
+
+	snapshot, err = findAPISnapshot(snapshotsDir, "dev", "PizzaShackAPI", "1.0.0", "20200101-100000.000")
+	assert.Nil(t, err)
+	assert.Equal(t, "20200101-100000.000", snapshot.ID)
+
+	_, err = findAPISnapshot(snapshotsDir, "dev", "PizzaShackAPI", "1.0.0", "20190101-100000.000")
+	assert.NotNil(t, err, "Unknown snapshot should return an error")
+
+	_, err = findAPISnapshot(snapshotsDir, "prod", "PizzaShackAPI", "1.0.0", "")
+	assert.NotNil(t, err, "Environment without snapshots should return an error")
+}
+
+func TestListAPISnapshotsWithUnderscores(t *testing.T) {
+	snapshotsDir, err := ioutil.TempDir("", "snapshots")
+	assert.Nil(t, err)
+	defer os.RemoveAll(snapshotsDir)
+	for _, api := range [][]string{{"PizzaShackAPI", "1.0_beta"}, {"PizzaShackAPI_1.0", "beta"}} {
+		dir := getAPISnapshotsDir(snapshotsDir, "dev", api[0], api[1])
+		assert.Nil(t, os.MkdirAll(dir, os.ModePerm))
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "20200101-100000.000.zip"), []byte("zip"), 0644))
+	}
+
+	snapshots, err := listAPISnapshots(snapshotsDir, "dev", "PizzaShackAPI", "1.0_beta")
+	assert.Nil(t, err)
+	if assert.Len(t, snapshots, 1, "Snapshots of other APIs should not be listed") {
+		assert.Equal(t, "PizzaShackAPI", snapshots[0].Name)
+		assert.Equal(t, "1.0_beta", snapshots[0].Version)
+	}
+
+	snapshots, err = listAPISnapshots(snapshotsDir, "dev", "", "")
+	assert.Nil(t, err)
+	assert.Len(t, snapshots, 2, "Snapshots of all APIs should be listed")
+}
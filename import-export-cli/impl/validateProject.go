@@ -0,0 +1,375 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// checks done by ValidateAPIProject
+const (
+	ProjectCheckDefinition = "definition"
+	ProjectCheckResources  = "resources"
+	ProjectCheckScopes     = "scopes"
+	ProjectCheckSequences  = "sequences"
+	ProjectCheckDocs       = "docs"
+	ProjectCheckContext    = "context"
+	ProjectCheckLifecycle  = "lifecycle"
+	ProjectCheckEndpoints  = "endpoints"
+)
+
+// swaggerVerbs are the operations of a path item in a swagger which map to resources
+var swaggerVerbs = []string{"get", "put", "post", "delete", "patch", "head", "options"}
+
+// defaultMediationSequences are the sequences shipped with API Manager, which are not part of API projects
+var defaultMediationSequences = map[string]bool{
+	"debug_in_flow": true, "debug_out_flow": true, "debug_json_fault": true, "json_fault": true,
+	"json_to_xml_in_message": true, "xml_to_json_out_message": true, "log_in_message": true,
+	"log_out_message": true, "preserve_accept_header": true, "apply_accept_header": true,
+	"disable_chunking": true, "json_validator": true, "regex_policy": true,
+}
+
+// ProjectIssue is a problem found in an API project. File is relative to the project
+type ProjectIssue struct {
+	Check   string `json:"check"`
+	File    string `json:"file"`
+	Message string `json:"message"`
+}
+
+// ProjectValidationResult is the result of validating an API project
+type ProjectValidationResult struct {
+	Project string         `json:"project"`
+	Valid   bool           `json:"valid"`
+	Issues  []ProjectIssue `json:"issues"`
+}
+
+// projectValidator collects the issues of an API project
+type projectValidator struct {
+	projectDir string
+	apiFile    string
+	issues     []ProjectIssue
+}
+
+func (v *projectValidator) addIssue(check, file, format string, args ...interface{}) {
+	v.issues = append(v.issues, ProjectIssue{Check: check, File: file, Message: fmt.Sprintf(format, args...)})
+}
+
+// relativePath returns p relative to the project, using forward slashes
+func (v *projectValidator) relativePath(p string) string {
+	if rel, err := filepath.Rel(v.projectDir, p); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return p
+}
+
+// ValidateAPIProject checks the API project in projectDir without connecting to API Manager. Resources are checked
+// against the swagger, scopes of the resources against the scopes of the API, sequences and documents against the
+// files of the project, the context against reserved paths, the lifecycle state against the valid initial states and
+// the endpoint configuration for malformed JSON.
+// An error is returned only when the API definition of the project can not be loaded
+func ValidateAPIProject(projectDir string) (*ProjectValidationResult, error) {
+	info, err := os.Stat(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not an API project directory", projectDir)
+	}
+	apiPath, content, err := resolveYamlOrJson(filepath.Join(projectDir, "Meta-information", "api"))
+	if err != nil {
+		return nil, err
+	}
+	def, err := extractAPIDefinition(content)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", apiPath, err)
+	}
+
+	v := &projectValidator{projectDir: projectDir}
+	v.apiFile = v.relativePath(apiPath)
+	if err := validateApiDefinition(def); err != nil {
+		v.addIssue(ProjectCheckDefinition, v.apiFile, "%v", err)
+	}
+	swagger := v.loadSwagger()
+	v.validateResources(def, swagger)
+	v.validateScopes(def, swagger)
+	v.validateSequences(def)
+	v.validateDocs()
+	v.validateContext(def)
+	v.validateLifecycle(def)
+	v.validateEndpointConfig(def)
+
+	return &ProjectValidationResult{Project: projectDir, Valid: len(v.issues) == 0, Issues: v.issues}, nil
+}
+
+// loadSwagger returns the swagger of the project, or nil if the project does not have a valid one
+func (v *projectValidator) loadSwagger() *gabs.Container {
+	swaggerPath, content, err := resolveYamlOrJson(filepath.Join(v.projectDir, "Meta-information", "swagger"))
+	if err != nil {
+		return nil
+	}
+	swagger, err := gabs.ParseJSON(content)
+	if err != nil {
+		v.addIssue(ProjectCheckResources, v.relativePath(swaggerPath), "malformed swagger: %v", err)
+		return nil
+	}
+	return swagger
+}
+
+// swaggerOperations calls fn for each operation of the swagger with its path and verb in upper case
+func swaggerOperations(swagger *gabs.Container, fn func(path, verb string, operation *gabs.Container)) {
+	paths, _ := swagger.S("paths").ChildrenMap()
+	for path, item := range paths {
+		for _, verb := range swaggerVerbs {
+			if item.Exists(verb) {
+				fn(path, strings.ToUpper(verb), item.S(verb))
+			}
+		}
+	}
+}
+
+// validateResources checks that the resources of the API and the operations of the swagger are the same. APIs
+// without resources are skipped as their resources are created from the swagger while importing
+func (v *projectValidator) validateResources(def *v2.APIDefinition, swagger *gabs.Container) {
+	if swagger == nil || len(def.URITemplates) == 0 {
+		return
+	}
+	switch def.Type {
+	case v2.APITypeGraphQL, v2.APITypeWS, v2.APITypeWebSub, v2.APITypeSSE:
+		return
+	}
+
+	resources := make(map[string]bool)
+	for _, uriTemplate := range def.URITemplates {
+		verbs := uriTemplate.HTTPVerbs
+		if len(verbs) == 0 && uriTemplate.HTTPVerb != "" {
+			verbs = []string{uriTemplate.HTTPVerb}
+		}
+		for _, verb := range verbs {
+			resources[strings.ToUpper(verb)+" "+uriTemplate.URITemplate] = true
+		}
+	}
+	operations := make(map[string]bool)
+	swaggerOperations(swagger, func(path, verb string, _ *gabs.Container) {
+		operations[verb+" "+path] = true
+	})
+
+	for _, resource := range sortedKeys(resources) {
+		if !operations[resource] {
+			v.addIssue(ProjectCheckResources, v.apiFile, "resource %s is not defined in the swagger", resource)
+		}
+	}
+	for _, operation := range sortedKeys(operations) {
+		if !resources[operation] {
+			v.addIssue(ProjectCheckResources, v.apiFile, "operation %s of the swagger is not a resource of the API",
+				operation)
+		}
+	}
+}
+
+// validateScopes checks that the scopes of the resources and the x-scope of the swagger operations are defined as
+// scopes of the API
+func (v *projectValidator) validateScopes(def *v2.APIDefinition, swagger *gabs.Container) {
+	defined := make(map[string]bool)
+	for _, scope := range def.Scopes {
+		if scope, ok := scope.(map[string]interface{}); ok {
+			if key, ok := scope["key"].(string); ok {
+				defined[key] = true
+			}
+		}
+	}
+
+	referenced := make(map[string]bool)
+	for _, uriTemplate := range def.URITemplates {
+		for _, scope := range uriTemplate.Scopes {
+			if scope != nil && scope.Key != "" && !defined[scope.Key] && !referenced[scope.Key] {
+				referenced[scope.Key] = true
+				v.addIssue(ProjectCheckScopes, v.apiFile, "scope %s of resource %s is not defined", scope.Key,
+					uriTemplate.URITemplate)
+			}
+		}
+	}
+	if swagger == nil {
+		return
+	}
+	var issues []string
+	swaggerOperations(swagger, func(path, verb string, operation *gabs.Container) {
+		if scope, ok := operation.S("x-scope").Data().(string); ok && scope != "" && !defined[scope] &&
+			!referenced[scope] {
+			referenced[scope] = true
+			issues = append(issues, fmt.Sprintf("scope %s of operation %s %s is not defined", scope, verb, path))
+		}
+	})
+	sort.Strings(issues)
+	for _, issue := range issues {
+		v.addIssue(ProjectCheckScopes, v.apiFile, "%s", issue)
+	}
+}
+
+// validateSequences checks that the in, out and fault sequences of the API exist in the Sequences directory, by
+// their file name or the name of the sequence. Default sequences of API Manager are not checked
+func (v *projectValidator) validateSequences(def *v2.APIDefinition) {
+	for _, sequence := range []struct{ name, dir string }{
+		{def.InSequence, "in-sequence"},
+		{def.OutSequence, "out-sequence"},
+		{def.FaultSequence, "fault-sequence"},
+	} {
+		if sequence.name == "" || defaultMediationSequences[sequence.name] {
+			continue
+		}
+		dir := filepath.Join(v.projectDir, "Sequences", sequence.dir)
+		if !sequenceExists(dir, sequence.name) && !sequenceExists(filepath.Join(dir, "Custom"), sequence.name) {
+			v.addIssue(ProjectCheckSequences, v.apiFile, "%s %s does not exist in Sequences/%s",
+				strings.Replace(sequence.dir, "-s", " s", 1), sequence.name, sequence.dir)
+		}
+	}
+}
+
+// sequenceExists returns true if dir has an XML file named name or a sequence with the name
+func sequenceExists(dir, name string) bool {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.EqualFold(filepath.Ext(file.Name()), ".xml") {
+			continue
+		}
+		if strings.TrimSuffix(file.Name(), filepath.Ext(file.Name())) == name {
+			return true
+		}
+		content, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			continue
+		}
+		var sequence struct {
+			Name string `xml:"name,attr"`
+		}
+		if xml.Unmarshal(content, &sequence) == nil && sequence.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validateDocs checks that the contents of inline and file documents exist and URL documents have an URL
+func (v *projectValidator) validateDocs() {
+	docsPath, content, err := resolveYamlOrJson(filepath.Join(v.projectDir, "Docs", "docs"))
+	if err != nil {
+		return
+	}
+	docsFile := v.relativePath(docsPath)
+	var docs []struct {
+		Name       string `json:"name"`
+		SourceType string `json:"sourceType"`
+		FilePath   string `json:"filePath"`
+		SourceURL  string `json:"sourceUrl"`
+	}
+	if err := json.Unmarshal(content, &docs); err != nil {
+		v.addIssue(ProjectCheckDocs, docsFile, "malformed documents: %v", err)
+		return
+	}
+	for _, doc := range docs {
+		switch strings.ToUpper(doc.SourceType) {
+		case "INLINE", "MARKDOWN":
+			if !fileExists(filepath.Join(v.projectDir, "Docs", "InlineContents", doc.Name)) {
+				v.addIssue(ProjectCheckDocs, docsFile, "content of document %s does not exist in Docs/InlineContents",
+					doc.Name)
+			}
+		case "FILE":
+			if doc.FilePath == "" {
+				v.addIssue(ProjectCheckDocs, docsFile, "document %s does not have a filePath", doc.Name)
+			} else if !fileExists(filepath.Join(v.projectDir, "Docs", "FileContents", doc.FilePath)) {
+				v.addIssue(ProjectCheckDocs, docsFile, "file %s of document %s does not exist in Docs/FileContents",
+					doc.FilePath, doc.Name)
+			}
+		case "URL":
+			if doc.SourceURL == "" {
+				v.addIssue(ProjectCheckDocs, docsFile, "document %s does not have a sourceUrl", doc.Name)
+			}
+		default:
+			v.addIssue(ProjectCheckDocs, docsFile, "sourceType %q of document %s should be either INLINE, "+
+				"MARKDOWN, FILE or URL", doc.SourceType, doc.Name)
+		}
+	}
+}
+
+// validateContext checks that the context of the API is not a path reserved by API Manager
+func (v *projectValidator) validateContext(def *v2.APIDefinition) {
+	for _, context := range []string{def.Context, def.ContextTemplate} {
+		lower := strings.ToLower(context)
+		for _, reserved := range utils.ReservedContexts {
+			if lower == reserved || strings.HasPrefix(lower, reserved+"/") {
+				v.addIssue(ProjectCheckContext, v.apiFile, "context %s clashes with the reserved path %s", context,
+					reserved)
+				return
+			}
+		}
+	}
+}
+
+// validateLifecycle checks that the lifecycle state of the API is a valid initial state
+func (v *projectValidator) validateLifecycle(def *v2.APIDefinition) {
+	if def.Status == "" {
+		return
+	}
+	for _, state := range utils.ValidInitialStates {
+		if def.Status == state {
+			return
+		}
+	}
+	v.addIssue(ProjectCheckLifecycle, v.apiFile, "status %s should be one of %v", def.Status,
+		utils.ValidInitialStates)
+}
+
+// validateEndpointConfig checks that the endpoint configuration of the API is a JSON object
+func (v *projectValidator) validateEndpointConfig(def *v2.APIDefinition) {
+	if def.EndpointConfig == nil || *def.EndpointConfig == "" {
+		return
+	}
+	var endpointConfig map[string]interface{}
+	if err := json.Unmarshal([]byte(*def.EndpointConfig), &endpointConfig); err != nil {
+		v.addIssue(ProjectCheckEndpoints, v.apiFile, "malformed endpointConfig: %v", err)
+	}
+}
+
+// fileExists returns true if p is a file
+func fileExists(p string) bool {
+	info, err := os.Stat(p)
+	return err == nil && !info.IsDir()
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,149 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const validateTestAPI = `id:
+  providerName: admin
+  apiName: PizzaShackAPI
+  version: 1.0.0
+context: /pizzashack/1.0.0
+contextTemplate: /pizzashack/{version}
+type: HTTP
+status: CREATED
+inSequence: add_header
+scopes:
+  - key: order:read
+    name: order:read
+uriTemplates:
+  - uriTemplate: /order/{orderId}
+    httpVerb: GET
+    httpVerbs: [GET, DELETE]
+    scopes:
+      - key: order:read
+      - null
+endpointConfig: '{"endpoint_type":"http","production_endpoints":{"url":"https://localhost:9443/pizzashack"}}'
+`
+
+const validateTestSwagger = `swagger: "2.0"
+paths:
+  /order/{orderId}:
+    get:
+      x-scope: order:read
+    delete: {}
+`
+
+// createValidateTestProject creates an API project with files, which are relative to the project and use
+// forward slashes
+func createValidateTestProject(t *testing.T, files map[string]string) string {
+	projectDir, err := ioutil.TempDir("", "validate")
+	assert.Nil(t, err)
+	for name, content := range files {
+		p := filepath.Join(projectDir, filepath.FromSlash(name))
+		assert.Nil(t, os.MkdirAll(filepath.Dir(p), os.ModePerm))
+		assert.Nil(t, ioutil.WriteFile(p, []byte(content), 0644))
+	}
+	return projectDir
+}
+
+func TestValidateAPIProject(t *testing.T) {
+	projectDir := createValidateTestProject(t, map[string]string{
+		"Meta-information/api.yaml":               validateTestAPI,
+		"Meta-information/swagger.yaml":           validateTestSwagger,
+		"Sequences/in-sequence/Custom/header.xml": `<sequence name="add_header"><log/></sequence>`,
+		"Docs/docs.json":                          `[{"name":"Guide","sourceType":"INLINE"}]`,
+		"Docs/InlineContents/Guide":               "How to order",
+	})
+	defer os.RemoveAll(projectDir)
+
+	result, err := ValidateAPIProject(projectDir)
+	assert.Nil(t, err)
+	assert.Empty(t, result.Issues)
+	assert.True(t, result.Valid)
+}
+
+func TestValidateAPIProjectWithIssues(t *testing.T) {
+	api := validateTestAPI +
+		"outSequence: strip_headers\nfaultSequence: json_fault\n"
+	api = strings.Replace(api, "context: /pizzashack/1.0.0", "context: /oauth2/pizzashack", 1)
+	api = strings.Replace(api, "status: CREATED", "status: DEPRECATED", 1)
+	api = strings.Replace(api, "      - key: order:read", "      - key: order:write", 1)
+	api = strings.Replace(api, "    httpVerbs: [GET, DELETE]", "    httpVerbs: [GET, PUT]", 1)
+	api = strings.Replace(api, `endpointConfig: '{"endpoint_type":"http",`, `endpointConfig: '{"endpoint_type":`, 1)
+	projectDir := createValidateTestProject(t, map[string]string{
+		"Meta-information/api.yaml":     api,
+		"Meta-information/swagger.yaml": validateTestSwagger,
+		"Docs/docs.yaml": `- name: Guide
+  sourceType: FILE
+  filePath: guide.pdf
+- name: Wiki
+  sourceType: URL
+`,
+	})
+	defer os.RemoveAll(projectDir)
+
+	result, err := ValidateAPIProject(projectDir)
+	assert.Nil(t, err)
+	assert.False(t, result.Valid)
+
+	messages := make(map[string][]string)
+	for _, issue := range result.Issues {
+		messages[issue.Check] = append(messages[issue.Check], issue.File+": "+issue.Message)
+	}
+	assert.Equal(t, []string{
+		"Meta-information/api.yaml: resource PUT /order/{orderId} is not defined in the swagger",
+		"Meta-information/api.yaml: operation DELETE /order/{orderId} of the swagger is not a resource of the API",
+	}, messages[ProjectCheckResources])
+	assert.Equal(t, []string{
+		"Meta-information/api.yaml: scope order:write of resource /order/{orderId} is not defined",
+	}, messages[ProjectCheckScopes])
+	assert.Equal(t, []string{
+		"Meta-information/api.yaml: in sequence add_header does not exist in Sequences/in-sequence",
+		"Meta-information/api.yaml: out sequence strip_headers does not exist in Sequences/out-sequence",
+	}, messages[ProjectCheckSequences])
+	assert.Equal(t, []string{
+		"Docs/docs.yaml: file guide.pdf of document Guide does not exist in Docs/FileContents",
+		"Docs/docs.yaml: document Wiki does not have a sourceUrl",
+	}, messages[ProjectCheckDocs])
+	assert.Equal(t, []string{
+		"Meta-information/api.yaml: context /oauth2/pizzashack clashes with the reserved path /oauth2",
+	}, messages[ProjectCheckContext])
+	assert.Equal(t, []string{
+		"Meta-information/api.yaml: status DEPRECATED should be one of [CREATED PUBLISHED]",
+	}, messages[ProjectCheckLifecycle])
+	assert.Len(t, messages[ProjectCheckEndpoints], 1)
+	assert.Empty(t, messages[ProjectCheckDefinition])
+}
+
+func TestValidateAPIProjectWithoutAPI(t *testing.T) {
+	projectDir := createValidateTestProject(t, map[string]string{"Docs/docs.json": "[]"})
+	defer os.RemoveAll(projectDir)
+
+	_, err := ValidateAPIProject(projectDir)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,402 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	yaml2 "gopkg.in/yaml.v2"
+)
+
+const (
+	soap11EnvelopeNamespace = "http://schemas.xmlsoap.org/soap/envelope/"
+	soap12EnvelopeNamespace = "http://www.w3.org/2003/05/soap-envelope"
+	soapToRestInDirectory   = "SoapToRest/in"
+	soapToRestOutDirectory  = "SoapToRest/out"
+)
+
+// wsdlLoader loads a WSDL together with the WSDLs and XSDs it imports
+type wsdlLoader struct {
+	definitions *v2.WSDLDefinitions
+	// files are the contents of the documents to be archived by their path relative to the root WSDL
+	files  map[string][]byte
+	loaded map[string]bool
+}
+
+// archivePath returns the path of the document imported by ref from the document in parent inside the archive.
+// Returns an empty string when ref is absolute, since such documents can be resolved without the archive
+func archivePath(parent, ref string) (string, error) {
+	if isURL(ref) || path.IsAbs(ref) || filepath.IsAbs(ref) {
+		return "", nil
+	}
+	p := path.Join(path.Dir(parent), filepath.ToSlash(ref))
+	if p == ".." || strings.HasPrefix(p, "../") {
+		return "", fmt.Errorf("%s is outside of the directory of the WSDL", ref)
+	}
+	return p, nil
+}
+
+// load reads the document in location and records it in the archive as name
+func (l *wsdlLoader) load(location, name string) ([]byte, error) {
+	content, err := readDocument(location)
+	if err != nil {
+		return nil, err
+	}
+	if name != "" {
+		l.files[name] = content
+	}
+	return content, nil
+}
+
+// loadWSDL loads the WSDL in location and merges it with the documents it imports
+func (l *wsdlLoader) loadWSDL(location, name string) error {
+	if l.loaded[location] {
+		return nil
+	}
+	l.loaded[location] = true
+	utils.Logln(utils.LogPrefixInfo + "Loading WSDL from " + location)
+	content, err := l.load(location, name)
+	if err != nil {
+		return err
+	}
+	definitions, err := v2.ParseWSDL(content)
+	if err != nil {
+		return fmt.Errorf("unable to parse %s: %v", location, err)
+	}
+	if l.definitions == nil {
+		l.definitions = definitions
+	} else {
+		l.definitions.Merge(definitions)
+	}
+
+	for _, imported := range definitions.Imports {
+		if imported.Location == "" {
+			continue
+		}
+		importedName, err := archivePath(name, imported.Location)
+		if err != nil {
+			return err
+		}
+		err = l.loadWSDL(resolveRefLocation(location, imported.Location), importedName)
+		if err != nil {
+			return err
+		}
+	}
+	for _, schema := range definitions.Schemas {
+		err = l.loadSchemaImports(location, name, &schema)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadSchemaImports loads the XSDs imported or included by schema, which is defined in the document in location
+func (l *wsdlLoader) loadSchemaImports(location, name string, schema *v2.XSDSchema) error {
+	for _, imported := range append(schema.Imports, schema.Includes...) {
+		if imported.SchemaLocation == "" {
+			continue
+		}
+		importedLocation := resolveRefLocation(location, imported.SchemaLocation)
+		if l.loaded[importedLocation] {
+			continue
+		}
+		l.loaded[importedLocation] = true
+		importedName, err := archivePath(name, imported.SchemaLocation)
+		if err != nil {
+			return err
+		}
+		utils.Logln(utils.LogPrefixInfo + "Loading XSD from " + importedLocation)
+		content, err := l.load(importedLocation, importedName)
+		if err != nil {
+			return err
+		}
+		importedSchema, err := v2.ParseXSD(content)
+		if err != nil {
+			return fmt.Errorf("unable to parse %s: %v", importedLocation, err)
+		}
+		if importedSchema.TargetNamespace == "" {
+			// included schemas take the namespace of the including schema
+			importedSchema.TargetNamespace = schema.TargetNamespace
+		}
+		l.definitions.Schemas = append(l.definitions.Schemas, *importedSchema)
+		err = l.loadSchemaImports(importedLocation, importedName, importedSchema)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadWSDL loads the WSDL in wsdlPath, which is either a file path or an URL, with the WSDLs and XSDs it imports.
+// Returns the merged definitions and the documents referred with relative locations by their path relative to the
+// WSDL, which are to be archived together
+func LoadWSDL(wsdlPath string) (*v2.WSDLDefinitions, map[string][]byte, error) {
+	location := wsdlPath
+	if !isURL(location) {
+		location = filepath.Clean(location)
+	}
+	name := path.Base(filepath.ToSlash(location))
+	if isURL(location) {
+		name = path.Base(strings.SplitN(location, "?", 2)[0])
+	}
+	loader := &wsdlLoader{files: make(map[string][]byte), loaded: make(map[string]bool)}
+	err := loader.loadWSDL(location, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return loader.definitions, loader.files, nil
+}
+
+// writeWSDLArchive writes the WSDL in file. When the WSDL imports other documents, file is a zip archive of all
+func writeWSDLArchive(file string, files map[string][]byte) error {
+	if len(files) == 1 {
+		for _, content := range files {
+			return ioutil.WriteFile(file, content, os.ModePerm)
+		}
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, name := range names {
+		w, err := archive.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err = w.Write(files[name]); err != nil {
+			return err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, buf.Bytes(), os.ModePerm)
+}
+
+// xmlEscape escapes s to be used in XML text and attribute values
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// soapToRestSequenceName returns the name of the mediation sequence of a SOAP to REST resource
+func soapToRestSequenceName(operation string) string {
+	return operation + "_post"
+}
+
+// buildSoapToRestInSequence generates the sequence which builds the SOAP request of operation from the JSON payload
+func buildSoapToRestInSequence(operation v2.SOAPOperationInfo) string {
+	envelopeNamespace, messageType := soap11EnvelopeNamespace, "text/xml"
+	if operation.SOAP12 {
+		envelopeNamespace, messageType = soap12EnvelopeNamespace, "application/soap+xml"
+	}
+	fieldPrefix := ""
+	if operation.QualifiedFields {
+		fieldPrefix = "web:"
+	}
+
+	var b strings.Builder
+	b.WriteString("<sequence xmlns=\"http://ws.apache.org/ns/synapse\" name=\"" +
+		xmlEscape(soapToRestSequenceName(operation.Name)) + "\">\n")
+	if operation.SOAPAction != "" {
+		b.WriteString("    <header name=\"SOAPAction\" scope=\"transport\" value=\"" +
+			xmlEscape(operation.SOAPAction) + "\"/>\n")
+	}
+	b.WriteString("    <property name=\"REST_URL_POSTFIX\" scope=\"axis2\" action=\"remove\"/>\n")
+	b.WriteString("    <payloadFactory media-type=\"xml\">\n")
+	b.WriteString("        <format>\n")
+	b.WriteString("            <soapenv:Envelope xmlns:soapenv=\"" + envelopeNamespace + "\">\n")
+	b.WriteString("                <soapenv:Header/>\n")
+	b.WriteString("                <soapenv:Body>\n")
+	b.WriteString("                    <web:" + operation.Element + " xmlns:web=\"" +
+		xmlEscape(operation.Namespace) + "\">\n")
+	for i, field := range operation.Fields {
+		b.WriteString(fmt.Sprintf("                        <%s%s>$%d</%s%s>\n", fieldPrefix, field, i+1,
+			fieldPrefix, field))
+	}
+	b.WriteString("                    </web:" + operation.Element + ">\n")
+	b.WriteString("                </soapenv:Body>\n")
+	b.WriteString("            </soapenv:Envelope>\n")
+	b.WriteString("        </format>\n")
+	b.WriteString("        <args>\n")
+	for _, field := range operation.Fields {
+		b.WriteString("            <arg evaluator=\"json\" expression=\"$." + field + "\"/>\n")
+	}
+	b.WriteString("        </args>\n")
+	b.WriteString("    </payloadFactory>\n")
+	b.WriteString("    <property name=\"messageType\" value=\"" + messageType + "\" scope=\"axis2\" type=\"STRING\"/>\n")
+	b.WriteString("    <property name=\"HTTP_METHOD\" value=\"POST\" scope=\"axis2\" type=\"STRING\"/>\n")
+	b.WriteString("</sequence>\n")
+	return b.String()
+}
+
+// buildSoapToRestOutSequence generates the sequence which converts the SOAP response of operation to JSON
+func buildSoapToRestOutSequence(operation v2.SOAPOperationInfo) string {
+	return "<sequence xmlns=\"http://ws.apache.org/ns/synapse\" name=\"" +
+		xmlEscape(soapToRestSequenceName(operation.Name)+"_out") + "\">\n" +
+		"    <property name=\"messageType\" value=\"application/json\" scope=\"axis2\" type=\"STRING\"/>\n" +
+		"</sequence>\n"
+}
+
+// writeSoapToRestSequences writes the in and out sequences of the operations to the SoapToRest directory of the
+// project in projectDir
+func writeSoapToRestSequences(projectDir string, operations []v2.SOAPOperationInfo) error {
+	for _, dir := range []string{soapToRestInDirectory, soapToRestOutDirectory} {
+		err := os.MkdirAll(filepath.Join(projectDir, filepath.FromSlash(dir)), os.ModePerm)
+		if err != nil {
+			return err
+		}
+	}
+	for _, operation := range operations {
+		fileName := soapToRestSequenceName(operation.Name) + ".xml"
+		inPath := filepath.Join(projectDir, filepath.FromSlash(soapToRestInDirectory), fileName)
+		utils.Logln(utils.LogPrefixInfo + "Writing " + inPath)
+		err := ioutil.WriteFile(inPath, []byte(buildSoapToRestInSequence(operation)), os.ModePerm)
+		if err != nil {
+			return err
+		}
+		outPath := filepath.Join(projectDir, filepath.FromSlash(soapToRestOutDirectory), fileName)
+		utils.Logln(utils.LogPrefixInfo + "Writing " + outPath)
+		err = ioutil.WriteFile(outPath, []byte(buildSoapToRestOutSequence(operation)), os.ModePerm)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildSOAPSwagger generates a Swagger 2.0 definition for the resources of a SOAP API. SOAP APIs accept the SOAP
+// request as the body while SOAP to REST APIs accept a JSON object with the fields of the request element
+func buildSOAPSwagger(def *v2.APIDefinition, operations []v2.SOAPOperationInfo) ([]byte, error) {
+	okResponse := map[string]interface{}{"200": map[string]interface{}{"description": "OK"}}
+	paths := make(map[string]interface{})
+	if def.Type == v2.APITypeSOAP {
+		paths["/*"] = map[string]interface{}{
+			"post": map[string]interface{}{
+				"consumes": []string{"text/xml", "application/soap+xml"},
+				"parameters": []interface{}{
+					map[string]interface{}{"in": "body", "name": "SOAP Request", "required": true,
+						"description": "SOAP request", "schema": map[string]interface{}{"type": "string"}},
+					map[string]interface{}{"in": "header", "name": "SOAPAction", "required": false,
+						"description": "SOAPAction header for SOAP 1.1 services", "type": "string"},
+				},
+				"responses": okResponse,
+			},
+		}
+	}
+	for _, operation := range operations {
+		properties := make(map[string]interface{})
+		for _, field := range operation.Fields {
+			properties[field] = map[string]interface{}{"type": "string"}
+		}
+		post := map[string]interface{}{
+			"operationId": operation.Name,
+			"consumes":    []string{"application/json"},
+			"produces":    []string{"application/json"},
+			"parameters": []interface{}{
+				map[string]interface{}{"in": "body", "name": "body", "required": true,
+					"schema": map[string]interface{}{"type": "object", "properties": properties}},
+			},
+			"responses": okResponse,
+		}
+		if operation.Documentation != "" {
+			post["description"] = operation.Documentation
+		}
+		paths["/"+operation.Name] = map[string]interface{}{"post": post}
+	}
+	return yaml2.Marshal(map[string]interface{}{
+		"swagger": "2.0",
+		"info": map[string]interface{}{
+			"title":   def.ID.APIName,
+			"version": def.ID.Version,
+		},
+		"paths": paths,
+	})
+}
+
+// InitAPIProjectFromWSDL populates def using the WSDL in wsdlPath and writes the WSDL, or a zip archive of it with
+// the documents it imports, to the WSDL directory of the project in projectDir together with a swagger for its
+// resources. In SOAP to REST mode the in and out sequences of the resources are written to the SoapToRest directory
+func InitAPIProjectFromWSDL(def *v2.APIDefinition, projectDir, wsdlPath string, soapToRest bool) error {
+	wsdl, files, err := LoadWSDL(wsdlPath)
+	if err != nil {
+		return err
+	}
+	err = v2.WSDLPopulate(def, wsdl, soapToRest)
+	if err != nil {
+		return err
+	}
+
+	wsdlDir := filepath.Join(projectDir, "WSDL")
+	err = os.MkdirAll(wsdlDir, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	extension := ".wsdl"
+	if len(files) > 1 {
+		extension = ".zip"
+	}
+	wsdlFile := filepath.Join(wsdlDir, def.ID.APIName+"-"+def.ID.Version+extension)
+	utils.Logln(utils.LogPrefixInfo + "Writing " + wsdlFile)
+	err = writeWSDLArchive(wsdlFile, files)
+	if err != nil {
+		return err
+	}
+
+	var operations []v2.SOAPOperationInfo
+	if soapToRest {
+		service, err := wsdl.Service()
+		if err != nil {
+			return err
+		}
+		operations, err = wsdl.Operations(service)
+		if err != nil {
+			return err
+		}
+		err = writeSoapToRestSequences(projectDir, operations)
+		if err != nil {
+			return err
+		}
+	}
+
+	swagger, err := buildSOAPSwagger(def, operations)
+	if err != nil {
+		return err
+	}
+	swaggerPath := filepath.Join(projectDir, filepath.FromSlash("Meta-information/swagger.yaml"))
+	utils.Logln(utils.LogPrefixInfo + "Writing " + swaggerPath)
+	return ioutil.WriteFile(swaggerPath, swagger, os.ModePerm)
+}
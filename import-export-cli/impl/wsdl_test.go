@@ -0,0 +1,127 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+)
+
+const wsdlTestDataPath = "../specs/v2/testdata/wsdl"
+
+func TestLoadWSDLWithIncludedSchema(t *testing.T) {
+	wsdl, files, err := LoadWSDL(filepath.Join(wsdlTestDataPath, "phoneverify.wsdl"))
+	assert.Nil(t, err)
+	assert.Len(t, files, 2)
+	assert.Contains(t, files, "phoneverify.wsdl")
+	assert.Contains(t, files, "xsd/types.xsd")
+
+	service, err := wsdl.Service()
+	assert.Nil(t, err)
+	operations, err := wsdl.Operations(service)
+	assert.Nil(t, err)
+	assert.Len(t, operations, 2)
+	// the named type of the element is resolved from the included schema
+	assert.Equal(t, []string{"PhoneNumbers", "LicenseKey"}, operations[1].Fields)
+	assert.Equal(t, "http://ws.cdyne.com/PhoneVerify/query", operations[1].Namespace)
+}
+
+func TestLoadWSDLRejectsImportsOutsideOfDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wsdl")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	writeBundleTestFiles(t, dir, map[string]string{
+		"service/service.wsdl": `<definitions xmlns="http://schemas.xmlsoap.org/wsdl/">
+  <import namespace="urn:common" location="../common.wsdl"/>
+</definitions>`,
+		"common.wsdl": `<definitions xmlns="http://schemas.xmlsoap.org/wsdl/"/>`,
+	})
+	_, _, err = LoadWSDL(filepath.Join(dir, "service", "service.wsdl"))
+	assert.NotNil(t, err)
+}
+
+func TestInitAPIProjectFromWSDL(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wsdl")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "Meta-information"), os.ModePerm))
+
+	def := &v2.APIDefinition{ID: v2.ID{Version: "1.0.0"}, Type: "HTTP"}
+	err = InitAPIProjectFromWSDL(def, dir, filepath.Join(wsdlTestDataPath, "calculator_rpc.wsdl"), false)
+	assert.Nil(t, err)
+	assert.Equal(t, v2.APITypeSOAP, def.Type)
+	assert.FileExists(t, filepath.Join(dir, "WSDL", "CalculatorService-1.0.0.wsdl"))
+	_, err = os.Stat(filepath.Join(dir, "SoapToRest"))
+	assert.True(t, os.IsNotExist(err), "SoapToRest should not be written for SOAP APIs")
+
+	swagger, err := ioutil.ReadFile(filepath.Join(dir, "Meta-information", "swagger.yaml"))
+	assert.Nil(t, err)
+	doc := parseBundled(t, swagger)
+	assert.True(t, doc.Exists("paths", "/*", "post"))
+}
+
+func TestInitAPIProjectFromWSDLSoapToRest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wsdl")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "Meta-information"), os.ModePerm))
+
+	def := &v2.APIDefinition{ID: v2.ID{Version: "1.0.0"}}
+	err = InitAPIProjectFromWSDL(def, dir, filepath.Join(wsdlTestDataPath, "phoneverify.wsdl"), true)
+	assert.Nil(t, err)
+	assert.Equal(t, v2.APITypeSOAPToREST, def.Type)
+	assert.Equal(t, "Phone number verification service", def.Description)
+	assert.Len(t, def.URITemplates, 2)
+	assert.Equal(t, "/CheckPhoneNumber", def.URITemplates[0].URITemplate)
+
+	// the WSDL is archived with the included schema
+	archive, err := zip.OpenReader(filepath.Join(dir, "WSDL", "PhoneVerify-1.0.0.zip"))
+	assert.Nil(t, err)
+	defer archive.Close()
+	var names []string
+	for _, f := range archive.File {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"phoneverify.wsdl", "xsd/types.xsd"}, names)
+
+	in, err := ioutil.ReadFile(filepath.Join(dir, "SoapToRest", "in", "CheckPhoneNumber_post.xml"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(in), `<header name="SOAPAction" scope="transport" `+
+		`value="http://ws.cdyne.com/PhoneVerify/query/CheckPhoneNumber"/>`)
+	assert.Contains(t, string(in), `<web:CheckPhoneNumber xmlns:web="http://ws.cdyne.com/PhoneVerify/query">`)
+	assert.Contains(t, string(in), `<web:PhoneNumber>$1</web:PhoneNumber>`)
+	assert.Contains(t, string(in), `<arg evaluator="json" expression="$.LicenseKey"/>`)
+	assert.Contains(t, string(in), `<property name="messageType" value="text/xml" scope="axis2" type="STRING"/>`)
+
+	out, err := ioutil.ReadFile(filepath.Join(dir, "SoapToRest", "out", "CheckPhoneNumbers_post.xml"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(out), `value="application/json"`)
+
+	swagger, err := ioutil.ReadFile(filepath.Join(dir, "Meta-information", "swagger.yaml"))
+	assert.Nil(t, err)
+	doc := parseBundled(t, swagger)
+	assert.True(t, doc.Exists("paths", "/CheckPhoneNumbers", "post"))
+	assert.False(t, doc.Exists("paths", "/*"))
+}
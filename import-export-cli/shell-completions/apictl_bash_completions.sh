@@ -358,6 +358,110 @@ _apictl_add-env()
     noun_aliases=()
 }
 
+_apictl_backup()
+{
+    last_command="apictl_backup"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment=")
+    flags+=("--format=")
+    local_nonpersistent_flags+=("--format=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    flags+=("--tenant=")
+    local_nonpersistent_flags+=("--tenant=")
+    flags+=("--withKeys")
+    local_nonpersistent_flags+=("--withKeys")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_certs_inspect()
+{
+    last_command="apictl_certs_inspect"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment=")
+    flags+=("--file=")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file=")
+    flags+=("--format=")
+    local_nonpersistent_flags+=("--format=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    flags+=("--params=")
+    local_nonpersistent_flags+=("--params=")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_flag+=("--file=")
+    must_have_one_flag+=("-f")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_certs()
+{
+    last_command="apictl_certs"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("inspect")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
 _apictl_change_registry()
 {
     last_command="apictl_change_registry"
@@ -655,6 +759,43 @@ _apictl_delete()
     noun_aliases=()
 }
 
+_apictl_env-vars()
+{
+    last_command="apictl_env-vars"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--env-file=")
+    local_nonpersistent_flags+=("--env-file=")
+    flags+=("--file=")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file=")
+    flags+=("--format=")
+    local_nonpersistent_flags+=("--format=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    flags+=("--params=")
+    local_nonpersistent_flags+=("--params=")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--file=")
+    must_have_one_flag+=("-f")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
 _apictl_export_api-product()
 {
     last_command="apictl_export_api-product"
@@ -909,6 +1050,8 @@ _apictl_import_api-product()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--env-file=")
+    local_nonpersistent_flags+=("--env-file=")
     flags+=("--environment=")
     two_word_flags+=("-e")
     local_nonpersistent_flags+=("--environment=")
@@ -920,8 +1063,14 @@ _apictl_import_api-product()
     local_nonpersistent_flags+=("--help")
     flags+=("--import-apis")
     local_nonpersistent_flags+=("--import-apis")
+    flags+=("--params=")
+    local_nonpersistent_flags+=("--params=")
     flags+=("--preserve-provider")
     local_nonpersistent_flags+=("--preserve-provider")
+    flags+=("--set=")
+    local_nonpersistent_flags+=("--set=")
+    flags+=("--set-file=")
+    local_nonpersistent_flags+=("--set-file=")
     flags+=("--skipCleanup")
     local_nonpersistent_flags+=("--skipCleanup")
     flags+=("--update-api-product")
@@ -982,6 +1131,10 @@ _apictl_import-api()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--context=")
+    local_nonpersistent_flags+=("--context=")
+    flags+=("--env-file=")
+    local_nonpersistent_flags+=("--env-file=")
     flags+=("--environment=")
     two_word_flags+=("-e")
     local_nonpersistent_flags+=("--environment=")
@@ -991,14 +1144,30 @@ _apictl_import-api()
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
+    flags+=("--lint")
+    local_nonpersistent_flags+=("--lint")
+    flags+=("--lint-rules=")
+    local_nonpersistent_flags+=("--lint-rules=")
+    flags+=("--name=")
+    local_nonpersistent_flags+=("--name=")
+    flags+=("--no-bundle")
+    local_nonpersistent_flags+=("--no-bundle")
+    flags+=("--oas=")
+    local_nonpersistent_flags+=("--oas=")
     flags+=("--params=")
     local_nonpersistent_flags+=("--params=")
     flags+=("--preserve-provider")
     local_nonpersistent_flags+=("--preserve-provider")
+    flags+=("--set=")
+    local_nonpersistent_flags+=("--set=")
+    flags+=("--set-file=")
+    local_nonpersistent_flags+=("--set-file=")
     flags+=("--skipCleanup")
     local_nonpersistent_flags+=("--skipCleanup")
     flags+=("--update")
     local_nonpersistent_flags+=("--update")
+    flags+=("--version=")
+    local_nonpersistent_flags+=("--version=")
     flags+=("--insecure")
     flags+=("-k")
     flags+=("--verbose")
@@ -1006,8 +1175,6 @@ _apictl_import-api()
     must_have_one_flag=()
     must_have_one_flag+=("--environment=")
     must_have_one_flag+=("-e")
-    must_have_one_flag+=("--file=")
-    must_have_one_flag+=("-f")
     must_have_one_noun=()
     noun_aliases=()
 }
@@ -1026,6 +1193,8 @@ _apictl_import-app()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--env-file=")
+    local_nonpersistent_flags+=("--env-file=")
     flags+=("--environment=")
     two_word_flags+=("-e")
     local_nonpersistent_flags+=("--environment=")
@@ -1038,6 +1207,8 @@ _apictl_import-app()
     flags+=("--owner=")
     two_word_flags+=("-o")
     local_nonpersistent_flags+=("--owner=")
+    flags+=("--params=")
+    local_nonpersistent_flags+=("--params=")
     flags+=("--preserveOwner")
     local_nonpersistent_flags+=("--preserveOwner")
     flags+=("--skipKeys")
@@ -1074,19 +1245,35 @@ _apictl_init()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--asyncapi=")
+    local_nonpersistent_flags+=("--asyncapi=")
     flags+=("--definition=")
     two_word_flags+=("-d")
     local_nonpersistent_flags+=("--definition=")
+    flags+=("--endpoint=")
+    local_nonpersistent_flags+=("--endpoint=")
     flags+=("--force")
     flags+=("-f")
     local_nonpersistent_flags+=("--force")
+    flags+=("--graphql=")
+    local_nonpersistent_flags+=("--graphql=")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
     flags+=("--initial-state=")
     local_nonpersistent_flags+=("--initial-state=")
+    flags+=("--no-bundle")
+    local_nonpersistent_flags+=("--no-bundle")
     flags+=("--oas=")
     local_nonpersistent_flags+=("--oas=")
+    flags+=("--soap-to-rest")
+    local_nonpersistent_flags+=("--soap-to-rest")
+    flags+=("--template=")
+    local_nonpersistent_flags+=("--template=")
+    flags+=("--var=")
+    local_nonpersistent_flags+=("--var=")
+    flags+=("--wsdl=")
+    local_nonpersistent_flags+=("--wsdl=")
     flags+=("--insecure")
     flags+=("-k")
     flags+=("--verbose")
@@ -1199,6 +1386,42 @@ _apictl_install()
     noun_aliases=()
 }
 
+_apictl_lint()
+{
+    last_command="apictl_lint"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--file=")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file=")
+    flags+=("--format=")
+    local_nonpersistent_flags+=("--format=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    flags+=("--rules=")
+    two_word_flags+=("-r")
+    local_nonpersistent_flags+=("--rules=")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--file=")
+    must_have_one_flag+=("-f")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
 _apictl_list_api-products()
 {
     last_command="apictl_list_api-products"
@@ -1344,6 +1567,45 @@ _apictl_list_envs()
     noun_aliases=()
 }
 
+_apictl_list_snapshots()
+{
+    last_command="apictl_list_snapshots"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment=")
+    flags+=("--format=")
+    local_nonpersistent_flags+=("--format=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    flags+=("--name=")
+    two_word_flags+=("-n")
+    local_nonpersistent_flags+=("--name=")
+    flags+=("--version=")
+    two_word_flags+=("-v")
+    local_nonpersistent_flags+=("--version=")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
 _apictl_list()
 {
     last_command="apictl_list"
@@ -1355,6 +1617,7 @@ _apictl_list()
     commands+=("apis")
     commands+=("apps")
     commands+=("envs")
+    commands+=("snapshots")
 
     flags=()
     two_word_flags=()
@@ -1434,9 +1697,9 @@ _apictl_logout()
     noun_aliases=()
 }
 
-_apictl_remove_env()
+_apictl_mock()
 {
-    last_command="apictl_remove_env"
+    last_command="apictl_mock"
 
     command_aliases=()
 
@@ -1448,26 +1711,38 @@ _apictl_remove_env()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--file=")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file=")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
+    flags+=("--host=")
+    local_nonpersistent_flags+=("--host=")
+    flags+=("--params=")
+    local_nonpersistent_flags+=("--params=")
+    flags+=("--port=")
+    local_nonpersistent_flags+=("--port=")
+    flags+=("--sandbox-env=")
+    local_nonpersistent_flags+=("--sandbox-env=")
     flags+=("--insecure")
     flags+=("-k")
     flags+=("--verbose")
 
     must_have_one_flag=()
+    must_have_one_flag+=("--file=")
+    must_have_one_flag+=("-f")
     must_have_one_noun=()
     noun_aliases=()
 }
 
-_apictl_remove()
+_apictl_new-version()
 {
-    last_command="apictl_remove"
+    last_command="apictl_new-version"
 
     command_aliases=()
 
     commands=()
-    commands+=("env")
 
     flags=()
     two_word_flags=()
@@ -1475,21 +1750,45 @@ _apictl_remove()
     flags_with_completion=()
     flags_completion=()
 
+    flags+=("--default")
+    local_nonpersistent_flags+=("--default")
+    flags+=("--deprecate-old")
+    local_nonpersistent_flags+=("--deprecate-old")
+    flags+=("--environment=")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment=")
+    flags+=("--file=")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file=")
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
+    flags+=("--name=")
+    two_word_flags+=("-n")
+    local_nonpersistent_flags+=("--name=")
+    flags+=("--new-version=")
+    local_nonpersistent_flags+=("--new-version=")
+    flags+=("--provider=")
+    two_word_flags+=("-r")
+    local_nonpersistent_flags+=("--provider=")
+    flags+=("--publish")
+    local_nonpersistent_flags+=("--publish")
+    flags+=("--version=")
+    two_word_flags+=("-v")
+    local_nonpersistent_flags+=("--version=")
     flags+=("--insecure")
     flags+=("-k")
     flags+=("--verbose")
 
     must_have_one_flag=()
+    must_have_one_flag+=("--new-version=")
     must_have_one_noun=()
     noun_aliases=()
 }
 
-_apictl_set()
+_apictl_params_render()
 {
-    last_command="apictl_set"
+    last_command="apictl_params_render"
 
     command_aliases=()
 
@@ -1501,19 +1800,408 @@ _apictl_set()
     flags_with_completion=()
     flags_completion=()
 
-    flags+=("--export-directory=")
-    local_nonpersistent_flags+=("--export-directory=")
+    flags+=("--environment=")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment=")
+    flags+=("--file=")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_params_validate()
+{
+    last_command="apictl_params_validate"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--file=")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    flags+=("--project=")
+    local_nonpersistent_flags+=("--project=")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_params()
+{
+    last_command="apictl_params"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("render")
+    commands+=("validate")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_remove_env()
+{
+    last_command="apictl_remove_env"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_remove()
+{
+    last_command="apictl_remove"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("env")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_restore()
+{
+    last_command="apictl_restore"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    flags+=("--skipKeys")
+    local_nonpersistent_flags+=("--skipKeys")
+    flags+=("--update")
+    local_nonpersistent_flags+=("--update")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_rollback_api()
+{
+    last_command="apictl_rollback_api"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--environment=")
+    two_word_flags+=("-e")
+    local_nonpersistent_flags+=("--environment=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    flags+=("--name=")
+    two_word_flags+=("-n")
+    local_nonpersistent_flags+=("--name=")
+    flags+=("--to=")
+    local_nonpersistent_flags+=("--to=")
+    flags+=("--version=")
+    two_word_flags+=("-v")
+    local_nonpersistent_flags+=("--version=")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--environment=")
+    must_have_one_flag+=("-e")
+    must_have_one_flag+=("--name=")
+    must_have_one_flag+=("-n")
+    must_have_one_flag+=("--version=")
+    must_have_one_flag+=("-v")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_rollback()
+{
+    last_command="apictl_rollback"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("api")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_secret_decrypt()
+{
+    last_command="apictl_secret_decrypt"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--key-file=")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_secret_encrypt()
+{
+    last_command="apictl_secret_encrypt"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--key-file=")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_secret()
+{
+    last_command="apictl_secret"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("decrypt")
+    commands+=("encrypt")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    flags+=("--key-file=")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_set()
+{
+    last_command="apictl_set"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--export-directory=")
+    local_nonpersistent_flags+=("--export-directory=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    flags+=("--http-request-timeout=")
+    local_nonpersistent_flags+=("--http-request-timeout=")
+    flags+=("--mode=")
+    two_word_flags+=("-m")
+    local_nonpersistent_flags+=("--mode=")
+    flags+=("--token-type=")
+    two_word_flags+=("-t")
+    local_nonpersistent_flags+=("--token-type=")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_template_list()
+{
+    last_command="apictl_template_list"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--format=")
+    local_nonpersistent_flags+=("--format=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
+_apictl_template()
+{
+    last_command="apictl_template"
+
+    command_aliases=()
+
+    commands=()
+    commands+=("list")
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
     flags+=("--help")
     flags+=("-h")
     local_nonpersistent_flags+=("--help")
-    flags+=("--http-request-timeout=")
-    local_nonpersistent_flags+=("--http-request-timeout=")
-    flags+=("--mode=")
-    two_word_flags+=("-m")
-    local_nonpersistent_flags+=("--mode=")
-    flags+=("--token-type=")
-    two_word_flags+=("-t")
-    local_nonpersistent_flags+=("--token-type=")
     flags+=("--insecure")
     flags+=("-k")
     flags+=("--verbose")
@@ -1676,6 +2364,39 @@ _apictl_update()
     noun_aliases=()
 }
 
+_apictl_validate()
+{
+    last_command="apictl_validate"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--file=")
+    two_word_flags+=("-f")
+    local_nonpersistent_flags+=("--file=")
+    flags+=("--format=")
+    local_nonpersistent_flags+=("--format=")
+    flags+=("--help")
+    flags+=("-h")
+    local_nonpersistent_flags+=("--help")
+    flags+=("--insecure")
+    flags+=("-k")
+    flags+=("--verbose")
+
+    must_have_one_flag=()
+    must_have_one_flag+=("--file=")
+    must_have_one_flag+=("-f")
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
 _apictl_version()
 {
     last_command="apictl_version"
@@ -1711,9 +2432,12 @@ _apictl_root_command()
     commands=()
     commands+=("add")
     commands+=("add-env")
+    commands+=("backup")
+    commands+=("certs")
     commands+=("change")
     commands+=("change-status")
     commands+=("delete")
+    commands+=("env-vars")
     commands+=("export")
     commands+=("export-api")
     commands+=("export-apis")
@@ -1724,13 +2448,22 @@ _apictl_root_command()
     commands+=("import-app")
     commands+=("init")
     commands+=("install")
+    commands+=("lint")
     commands+=("list")
     commands+=("login")
     commands+=("logout")
+    commands+=("mock")
+    commands+=("new-version")
+    commands+=("params")
     commands+=("remove")
+    commands+=("restore")
+    commands+=("rollback")
+    commands+=("secret")
     commands+=("set")
+    commands+=("template")
     commands+=("uninstall")
     commands+=("update")
+    commands+=("validate")
     commands+=("version")
 
     flags=()
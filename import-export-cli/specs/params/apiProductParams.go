@@ -0,0 +1,62 @@
+package params
+
+import (
+	"gopkg.in/yaml.v2"
+)
+
+// APIProductPolicies contains the policies of an API Product
+type APIProductPolicies struct {
+	// Subscription tiers available for the API Product
+	Subscription []string `yaml:"subscription"`
+}
+
+// APIProductEnvironment represents an API Product environment
+type APIProductEnvironment struct {
+	// Name of the environment
+	Name string `yaml:"name"`
+	// GatewayEnvironments contains environments that used to deploy the API Product
+	GatewayEnvironments []string `yaml:"gatewayEnvironments"`
+	// Policies of the API Product
+	Policies *APIProductPolicies `yaml:"policies"`
+	// Visibility of the API Product in the store (public, private or restricted)
+	Visibility string `yaml:"visibility"`
+	// VisibleRoles are the roles allowed to view a restricted API Product
+	VisibleRoles []string `yaml:"visibleRoles"`
+	// BusinessInformation contains the business and technical owner details
+	BusinessInformation *BusinessInformation `yaml:"businessInformation"`
+	// AdditionalProperties are merged into the additional properties of the API Product
+	AdditionalProperties map[string]string `yaml:"additionalProperties"`
+}
+
+// APIProductParams represents environments defined in an API Product configuration file
+type APIProductParams struct {
+	// Environments contains all environments in a configuration
+	Environments []APIProductEnvironment `yaml:"environments"`
+}
+
+// LoadAPIProductParamsFromFile loads an API Product configuration YAML file located in path.
+// It returns an error or a valid APIProductParams
+func LoadAPIProductParamsFromFile(path string) (*APIProductParams, error) {
+	fileContent, err := getEnvSubstitutedFileContent(path)
+	if err != nil {
+		return nil, err
+	}
+
+	apiProductParams := &APIProductParams{}
+	err = yaml.Unmarshal([]byte(fileContent), &apiProductParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiProductParams, err
+}
+
+// GetEnv returns the APIProductEnvironment associated for key in the APIProductParams, if not found returns nil
+func (config APIProductParams) GetEnv(key string) *APIProductEnvironment {
+	for index, env := range config.Environments {
+		if env.Name == key {
+			return &config.Environments[index]
+		}
+	}
+	return nil
+}
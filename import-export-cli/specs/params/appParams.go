@@ -0,0 +1,87 @@
+package params
+
+import (
+	"gopkg.in/yaml.v2"
+)
+
+// AppKeyParams contains the parameters of the keys of an application
+type AppKeyParams struct {
+	// CallbackUrl of the OAuth application
+	CallbackUrl *string `yaml:"callbackUrl"`
+	// GrantTypes supported by the OAuth application
+	GrantTypes []string `yaml:"grantTypes"`
+}
+
+// AppKeys contains the parameters of production and sandbox keys of an application
+type AppKeys struct {
+	// Production keys
+	Production *AppKeyParams `yaml:"production"`
+	// Sandbox keys
+	Sandbox *AppKeyParams `yaml:"sandbox"`
+}
+
+// SubscriptionParams remaps a subscription of an application. Subscriptions are matched by API name and, when
+// given, by version and provider
+type SubscriptionParams struct {
+	// Name of the subscribed API
+	Name string `yaml:"name"`
+	// Version of the subscribed API, all versions are matched when empty
+	Version string `yaml:"version"`
+	// Provider of the subscribed API, all providers are matched when empty
+	Provider string `yaml:"provider"`
+	// TargetVersion is the version of the API to subscribe in the environment
+	TargetVersion string `yaml:"targetVersion"`
+	// TargetProvider is the provider of the API to subscribe in the environment
+	TargetProvider string `yaml:"targetProvider"`
+	// ThrottlingPolicy is the subscription tier in the environment
+	ThrottlingPolicy string `yaml:"throttlingPolicy"`
+}
+
+// AppEnvironment represents an application environment
+type AppEnvironment struct {
+	// Name of the environment
+	Name string `yaml:"name"`
+	// ThrottlingPolicy of the application
+	ThrottlingPolicy string `yaml:"throttlingPolicy"`
+	// TokenType of the application (JWT or OAUTH)
+	TokenType string `yaml:"tokenType"`
+	// Keys contains the parameters of the keys
+	Keys *AppKeys `yaml:"keys"`
+	// Attributes are merged into the attributes of the application
+	Attributes map[string]string `yaml:"attributes"`
+	// Subscriptions remap the subscriptions of the application
+	Subscriptions []SubscriptionParams `yaml:"subscriptions"`
+}
+
+// AppParams represents environments defined in an application configuration file
+type AppParams struct {
+	// Environments contains all environments in a configuration
+	Environments []AppEnvironment `yaml:"environments"`
+}
+
+// LoadAppParamsFromFile loads an application configuration YAML file located in path.
+// It returns an error or a valid AppParams
+func LoadAppParamsFromFile(path string) (*AppParams, error) {
+	fileContent, err := getEnvSubstitutedFileContent(path)
+	if err != nil {
+		return nil, err
+	}
+
+	appParams := &AppParams{}
+	err = yaml.Unmarshal([]byte(fileContent), &appParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return appParams, err
+}
+
+// GetEnv returns the AppEnvironment associated for key in the AppParams, if not found returns nil
+func (config AppParams) GetEnv(key string) *AppEnvironment {
+	for index, env := range config.Environments {
+		if env.Name == key {
+			return &config.Environments[index]
+		}
+	}
+	return nil
+}
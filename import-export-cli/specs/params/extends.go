@@ -0,0 +1,168 @@
+package params
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// defaultsKey is the top level section which is inherited by all environments
+	defaultsKey = "defaults"
+	// extendsKey is the field of an environment which names the environment it inherits from
+	extendsKey = "extends"
+)
+
+// rawParams is a params file before defaults and extends are resolved
+type rawParams struct {
+	Defaults     map[interface{}]interface{}   `yaml:"defaults"`
+	Environments []map[interface{}]interface{} `yaml:"environments"`
+}
+
+// resolveEnvironments deep merges the defaults section and the environments named by extends into each
+// environment of the params file content. Maps are merged key by key, any other value of an environment replaces
+// the inherited one.
+// It returns the content with resolved environments, or an error if extends refers an unknown environment or
+// environments extend each other
+func resolveEnvironments(content []byte) ([]byte, error) {
+	raw := &rawParams{}
+	if err := yaml.Unmarshal(content, raw); err != nil {
+		return nil, err
+	}
+	if raw.Defaults == nil && !hasExtends(raw.Environments) {
+		return content, nil
+	}
+
+	environments := make(map[string]map[interface{}]interface{})
+	for _, env := range raw.Environments {
+		if name, ok := env["name"].(string); ok {
+			environments[name] = env
+		}
+	}
+
+	resolved := make(map[string]map[interface{}]interface{})
+	var resolve func(name string, chain []string) (map[interface{}]interface{}, error)
+	resolve = func(name string, chain []string) (map[interface{}]interface{}, error) {
+		if env, ok := resolved[name]; ok {
+			return env, nil
+		}
+		for _, visited := range chain {
+			if visited == name {
+				return nil, fmt.Errorf("environments extend each other: %s", strings.Join(append(chain, name),
+					" -> "))
+			}
+		}
+		env, ok := environments[name]
+		if !ok {
+			return nil, fmt.Errorf("environment %s extends an undefined environment %s", chain[len(chain)-1],
+				name)
+		}
+
+		base := deepCopy(raw.Defaults).(map[interface{}]interface{})
+		if parent, ok := env[extendsKey]; ok {
+			parentName, ok := parent.(string)
+			if !ok {
+				return nil, fmt.Errorf("extends of environment %s should be an environment name", name)
+			}
+			parentEnv, err := resolve(parentName, append(chain, name))
+			if err != nil {
+				return nil, err
+			}
+			base = deepCopy(parentEnv).(map[interface{}]interface{})
+		}
+		merged := deepMerge(base, env).(map[interface{}]interface{})
+		merged["name"] = name
+		delete(merged, extendsKey)
+		resolved[name] = merged
+		return merged, nil
+	}
+
+	result := make([]map[interface{}]interface{}, 0, len(raw.Environments))
+	for _, env := range raw.Environments {
+		name, ok := env["name"].(string)
+		if !ok {
+			// environments without a name can not be extended, they only inherit defaults
+			merged := deepMerge(deepCopy(raw.Defaults), env).(map[interface{}]interface{})
+			delete(merged, extendsKey)
+			result = append(result, merged)
+			continue
+		}
+		merged, err := resolve(name, nil)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, merged)
+	}
+	return yaml.Marshal(map[string]interface{}{"environments": result})
+}
+
+// hasExtends returns true if an environment extends another
+func hasExtends(environments []map[interface{}]interface{}) bool {
+	for _, env := range environments {
+		if _, ok := env[extendsKey]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// deepMerge merges override into base and returns the result. base is modified
+func deepMerge(base, override interface{}) interface{} {
+	baseMap, baseIsMap := base.(map[interface{}]interface{})
+	overrideMap, overrideIsMap := override.(map[interface{}]interface{})
+	if !baseIsMap || !overrideIsMap {
+		return deepCopy(override)
+	}
+	for key, value := range overrideMap {
+		if existing, ok := baseMap[key]; ok {
+			baseMap[key] = deepMerge(existing, value)
+		} else {
+			baseMap[key] = deepCopy(value)
+		}
+	}
+	return baseMap
+}
+
+// deepCopy copies maps and slices of a YAML value, nil maps are copied as empty maps
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		copied := make(map[interface{}]interface{}, len(v))
+		for key, child := range v {
+			copied[key] = deepCopy(child)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for index, child := range v {
+			copied[index] = deepCopy(child)
+		}
+		return copied
+	default:
+		return v
+	}
+}
+
+// RenderApiParamsEnv returns the environment named envName of the API params file in path as YAML, after
+// defaults and extends are resolved. Encrypted values are not decrypted
+func RenderApiParamsEnv(path, envName string) ([]byte, error) {
+	fileContent, err := getEnvSubstitutedEncryptedFileContent(path)
+	if err != nil {
+		return nil, err
+	}
+	content, err := resolveEnvironments([]byte(fileContent))
+	if err != nil {
+		return nil, err
+	}
+	raw := &rawParams{}
+	if err := yaml.Unmarshal(content, raw); err != nil {
+		return nil, err
+	}
+	for _, env := range raw.Environments {
+		if env["name"] == envName {
+			return yaml.Marshal(env)
+		}
+	}
+	return nil, fmt.Errorf("environment %s is not defined in %s", envName, path)
+}
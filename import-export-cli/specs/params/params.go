@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 	"gopkg.in/yaml.v2"
 )
@@ -25,10 +26,23 @@ type Endpoint struct {
 	Url *string `yaml:"url" json:"url"`
 	// Config of endpoint
 	Config *Configuration `yaml:"config" json:"config"`
+	// Urls of load balanced endpoints, or of the primary endpoint followed by failover endpoints
+	Urls []string `yaml:"urls" json:"-"`
+	// FailoverUrls are used when the primary endpoint fails
+	FailoverUrls []string `yaml:"failoverUrls" json:"-"`
 }
 
 // EndpointData contains details about endpoints
 type EndpointData struct {
+	// Type of the endpoints (http, address, ws, load_balance or failover). When set, the endpoint configuration of
+	// the API is replaced instead of merged
+	Type string `yaml:"type" json:"-"`
+	// Algorithm used to balance the load among endpoints
+	Algorithm string `yaml:"algorithm" json:"-"`
+	// SessionManagement of load balanced endpoints (i.e. transport, soap, simpleClientSession)
+	SessionManagement string `yaml:"sessionManagement" json:"-"`
+	// SessionTimeOut of load balanced endpoints in seconds
+	SessionTimeOut *int `yaml:"sessionTimeOut" json:"-"`
 	// Production endpoint
 	Production *Endpoint `yaml:"production" json:"production_endpoints,omitempty"`
 	// Sandbox endpoint
@@ -71,6 +85,59 @@ type Environment struct {
 	GatewayEnvironments []string `yaml:"gatewayEnvironments"`
 	// Certs for environment
 	Certs []Cert `yaml:"certs"`
+	// Policies contains subscription and resource level throttling policies
+	Policies *Policies `yaml:"policies"`
+	// Cors contains the CORS configuration of the API
+	Cors *CorsConfig `yaml:"cors"`
+	// Visibility of the API in the store (public, private or restricted)
+	Visibility string `yaml:"visibility"`
+	// VisibleRoles are the roles allowed to view a restricted API
+	VisibleRoles []string `yaml:"visibleRoles"`
+	// Transports supported by the API (http, https)
+	Transports []string `yaml:"transports"`
+	// Tags of the API
+	Tags []string `yaml:"tags"`
+	// BusinessInformation contains the business and technical owner details
+	BusinessInformation *BusinessInformation `yaml:"businessInformation"`
+	// AdditionalProperties are merged into the additional properties of the API
+	AdditionalProperties map[string]string `yaml:"additionalProperties"`
+}
+
+// Policies contains the throttling policies of an API
+type Policies struct {
+	// Subscription tiers available for the API
+	Subscription []string `yaml:"subscription"`
+	// Resources contains throttling policies and scopes of the API resources
+	Resources []ResourcePolicy `yaml:"resources"`
+}
+
+// ResourcePolicy is the throttling policy and scope of an API resource
+type ResourcePolicy struct {
+	// Target of the resource (i.e. /order/{orderId}, or the operation name of GraphQL APIs)
+	Target string `yaml:"target"`
+	// Verb of the resource (i.e. GET, or QUERY, MUTATION and SUBSCRIPTION of GraphQL APIs)
+	Verb string `yaml:"verb"`
+	// ThrottlingPolicy applied to the resource
+	ThrottlingPolicy string `yaml:"throttlingPolicy"`
+	// Scope required to invoke the resource
+	Scope string `yaml:"scope"`
+}
+
+// CorsConfig contains the CORS configuration of an API. Fields which are not set keep the value in api.yaml
+type CorsConfig struct {
+	Enabled          *bool    `yaml:"enabled" json:"corsConfigurationEnabled,omitempty"`
+	AllowOrigins     []string `yaml:"allowOrigins" json:"accessControlAllowOrigins,omitempty"`
+	AllowCredentials *bool    `yaml:"allowCredentials" json:"accessControlAllowCredentials,omitempty"`
+	AllowHeaders     []string `yaml:"allowHeaders" json:"accessControlAllowHeaders,omitempty"`
+	AllowMethods     []string `yaml:"allowMethods" json:"accessControlAllowMethods,omitempty"`
+}
+
+// BusinessInformation contains the owners of an API. Fields which are not set keep the value in api.yaml
+type BusinessInformation struct {
+	BusinessOwner       string `yaml:"businessOwner" json:"businessOwner,omitempty"`
+	BusinessOwnerEmail  string `yaml:"businessOwnerEmail" json:"businessOwnerEmail,omitempty"`
+	TechnicalOwner      string `yaml:"technicalOwner" json:"technicalOwner,omitempty"`
+	TechnicalOwnerEmail string `yaml:"technicalOwnerEmail" json:"technicalOwnerEmail,omitempty"`
 }
 
 // ApiParams represents environments defined in configuration file
@@ -85,9 +152,21 @@ type APIEndpointConfig struct {
 	EPConfig string `json:"endpointConfig"`
 }
 
-// loads the given file in path and substitutes environment variables that are defined as ${var} or $var in the file.
+// loads the given file in path, substitutes environment variables that are defined as ${var} or $var in the file and
+// decrypts encrypted values (enc:v1:...).
 //	returns the file as string.
 func getEnvSubstitutedFileContent(path string) (string, error) {
+	str, err := getEnvSubstitutedEncryptedFileContent(path)
+	if err != nil {
+		return "", err
+	}
+	return utils.DecryptSecrets(str)
+}
+
+// loads the given file in path and substitutes environment variables that are defined as ${var} or $var in the file.
+// Encrypted values are kept as they are.
+//	returns the file as string.
+func getEnvSubstitutedEncryptedFileContent(path string) (string, error) {
 	r, err := os.Open(path)
 	defer func() {
 		_ = r.Close()
@@ -109,20 +188,48 @@ func getEnvSubstitutedFileContent(path string) (string, error) {
 }
 
 // LoadApiParamsFromFile loads an API Project configuration YAML file located in path.
+// The defaults section and environments named by extends are merged into each environment. Unknown fields and
+// invalid values are returned as errors.
 //	It returns an error or a valid ApiParams
 func LoadApiParamsFromFile(path string) (*ApiParams, error) {
-	fileContent, err := getEnvSubstitutedFileContent(path)
+	fileContent, err := getEnvSubstitutedEncryptedFileContent(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// encrypted values are decrypted after validation, so that errors do not contain them
+	err = decodeStrict(path, []byte(fileContent))
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := resolveEnvironments([]byte(fileContent))
+	if err != nil {
+		return nil, err
+	}
+
+	decryptedContent, err := utils.DecryptSecrets(string(content))
 	if err != nil {
 		return nil, err
 	}
 
 	apiParams := &ApiParams{}
-	err = yaml.Unmarshal([]byte(fileContent), &apiParams)
+	err = yaml.Unmarshal([]byte(decryptedContent), &apiParams)
 	if err != nil {
 		return nil, err
 	}
 
-	return apiParams, err
+	var errorResults error
+	for index := range apiParams.Environments {
+		if err := apiParams.Environments[index].validate(path); err != nil {
+			errorResults = multierror.Append(errorResults, err)
+		}
+	}
+	if errorResults != nil {
+		return nil, errorResults
+	}
+
+	return apiParams, nil
 }
 
 // ExtractAPIEndpointConfig extracts API endpoint information from a slice of byte b
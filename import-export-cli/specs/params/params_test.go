@@ -1,6 +1,7 @@
 package params
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"github.com/Jeffail/gabs"
 	"github.com/stretchr/testify/assert"
@@ -106,3 +107,151 @@ func TestAPIConfig_ContainsEnv(t *testing.T) {
 	assert.Nil(t, configData.GetEnv("prod"), "Should not contain undefined environment")
 }
 
+
+func TestLoadApiParamsWithOverrides(t *testing.T) {
+	conf, err := LoadApiParamsFromFile("testdata/api_params-overrides.yml")
+	assert.Nil(t, err, "Should return nil for correctly parsed files")
+	env := conf.GetEnv("dev")
+	assert.Equal(t, []string{"Gold", "Unlimited"}, env.Policies.Subscription)
+	assert.Equal(t, ResourcePolicy{Target: "/order/{orderId}", Verb: "DELETE", ThrottlingPolicy: "10KPerMin"},
+		env.Policies.Resources[0])
+	assert.True(t, *env.Cors.Enabled)
+	assert.Nil(t, env.Cors.AllowCredentials, "Should return nil for fields which are not set")
+	assert.Equal(t, "restricted", env.Visibility)
+	assert.Equal(t, []string{"admin", "Internal/subscriber"}, env.VisibleRoles)
+	assert.Equal(t, []string{"https"}, env.Transports)
+	assert.Equal(t, "Dev Team", env.BusinessInformation.TechnicalOwner)
+	assert.Equal(t, "us-east", env.AdditionalProperties["region"])
+}
+
+func TestLoadAppParamsFromFile(t *testing.T) {
+	_ = os.Setenv("APP_CALLBACK_URL", "https://prod.example.com/callback")
+	conf, err := LoadAppParamsFromFile("testdata/app_params.yml")
+	assert.Nil(t, err, "Should return nil for correctly parsed files")
+	env := conf.GetEnv("prod")
+	assert.Equal(t, "50PerMin", env.ThrottlingPolicy)
+	assert.Equal(t, "https://prod.example.com/callback", *env.Keys.Production.CallbackUrl,
+		"Should substitute environment variables")
+	assert.Nil(t, env.Keys.Sandbox)
+	assert.Equal(t, []string{"client_credentials", "password"}, env.Keys.Production.GrantTypes)
+	assert.Equal(t, "prod-42", env.Attributes["External Reference Id"])
+	assert.Equal(t, "2.0.0", env.Subscriptions[0].TargetVersion)
+	assert.Nil(t, conf.GetEnv("dev"), "Should return nil for missing environments")
+}
+
+func TestLoadAPIProductParamsFromFile(t *testing.T) {
+	_ = os.Setenv("PRODUCT_GATEWAY", "Edge")
+	conf, err := LoadAPIProductParamsFromFile("testdata/api_product_params.yml")
+	assert.Nil(t, err, "Should return nil for correctly parsed files")
+	env := conf.GetEnv("prod")
+	assert.Equal(t, []string{"Production and Sandbox", "Edge"}, env.GatewayEnvironments,
+		"Should substitute environment variables")
+	assert.Equal(t, []string{"Gold", "Unlimited"}, env.Policies.Subscription)
+	assert.Equal(t, "restricted", env.Visibility)
+	assert.Equal(t, []string{"internal/subscriber"}, env.VisibleRoles)
+	assert.Equal(t, "Jane Roe", env.BusinessInformation.BusinessOwner)
+	assert.Equal(t, "", env.BusinessInformation.TechnicalOwner)
+	assert.Equal(t, "eu-west", env.AdditionalProperties["region"])
+	assert.Nil(t, conf.GetEnv("dev"), "Should return nil for missing environments")
+}
+
+func TestLoadApiParamsFromFileWithExtends(t *testing.T) {
+	conf, err := LoadApiParamsFromFile("testdata/api_params-extends.yml")
+	assert.Nil(t, err, "Should return nil for correctly parsed files")
+
+	dev := conf.GetEnv("dev")
+	assert.Equal(t, []string{"Production and Sandbox"}, dev.GatewayEnvironments, "Should inherit defaults")
+	assert.Equal(t, "admin", dev.Security.Username)
+	assert.Equal(t, "backend", dev.Certs[0].Alias)
+
+	prod := conf.GetEnv("prod")
+	assert.Equal(t, "https://staging.example.com", *prod.Endpoints.Production.Url, "Should inherit extended env")
+	assert.Equal(t, 10, *prod.Endpoints.Production.Config.RetryTimeOut, "Should deep merge maps")
+	assert.Equal(t, "prod-admin", prod.Security.Username, "Should override inherited values")
+	assert.Equal(t, "basic", prod.Security.Type)
+	assert.Equal(t, []string{"Production"}, prod.GatewayEnvironments, "Should replace inherited lists")
+	assert.Equal(t, "https://dev.example.com", *dev.Endpoints.Production.Url, "Should not modify extended env")
+}
+
+func TestLoadApiParamsFromFileWithCyclicExtends(t *testing.T) {
+	_, err := LoadApiParamsFromFile("testdata/api_params-extends-cycle.yml")
+	assert.NotNil(t, err, "Should return an error for cyclic extends")
+	assert.Contains(t, err.Error(), "dev -> prod -> staging -> dev")
+}
+
+func TestRenderApiParamsEnv(t *testing.T) {
+	content, err := RenderApiParamsEnv("testdata/api_params-extends.yml", "staging")
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), "url: https://staging.example.com")
+	assert.Contains(t, string(content), "username: admin")
+	assert.NotContains(t, string(content), "extends")
+
+	_, err = RenderApiParamsEnv("testdata/api_params-extends.yml", "qa")
+	assert.NotNil(t, err, "Should return an error for undefined environments")
+}
+
+func TestLoadApiParamsFromFileWithUnknownFields(t *testing.T) {
+	_, err := LoadApiParamsFromFile("testdata/api_params-unknown.yml")
+	assert.NotNil(t, err, "Should return an error for unknown fields")
+	assert.Contains(t, err.Error(), "testdata/api_params-unknown.yml:3:5: unknown field endpoint")
+	assert.Contains(t, err.Error(), "testdata/api_params-unknown.yml:9:9: unknown field uri")
+}
+
+func TestLoadApiParamsFromFileWithInvalidValues(t *testing.T) {
+	_, err := LoadApiParamsFromFile("testdata/api_params-invalid-values.yml")
+	assert.NotNil(t, err, "Should return an error for invalid values")
+	assert.Contains(t, err.Error(), `endpoints.production.url "dev.example.com" is not a valid URL`)
+	assert.NotContains(t, err.Error(), "endpoints.sandbox.url")
+	assert.Contains(t, err.Error(), `security.enabled "yes" should be true or false`)
+	assert.Contains(t, err.Error(), `security.type "oauth" should be either basic or digest`)
+}
+
+func TestValidateApiParamsFile(t *testing.T) {
+	err := ValidateApiParamsFile("testdata/api_params.yml", "testdata")
+	assert.Nil(t, err, "Should return nil for valid files")
+
+	err = ValidateApiParamsFile("testdata/api_params-certs.yml", "testdata")
+	assert.NotNil(t, err, "Should return an error for missing certificates")
+	assert.Contains(t, err.Error(), "certificate missing.crt does not exist")
+	assert.NotContains(t, err.Error(), "api.json")
+}
+
+func TestLoadApiParamsFromFileWithEndpointTypes(t *testing.T) {
+	_, err := LoadApiParamsFromFile("testdata/api_params-endpoints.yml")
+	assert.NotNil(t, err, "Should return an error for failover URLs without failover type")
+	assert.Contains(t, err.Error(), "environment invalid: endpoints.production.failoverUrls requires "+
+		"endpoints.type failover")
+	assert.Contains(t, err.Error(), "environment multiple: endpoints.production should have a single url with "+
+		"endpoints.type http")
+	assert.Contains(t, err.Error(), "environment multiple: endpoints.sandbox should have a single url with "+
+		"endpoints.type http")
+	assert.NotContains(t, err.Error(), "environment http")
+	assert.NotContains(t, err.Error(), "environment lb")
+	assert.NotContains(t, err.Error(), "environment fo")
+}
+
+func TestLoadApiParamsFromFileWithEncryptedValues(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	token, err := utils.EncryptSecret(key, "admin123")
+	assert.Nil(t, err)
+	dir, err := ioutil.TempDir("", "params")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	paramsPath := filepath.Join(dir, "api_params.yaml")
+	content := "environments:\n  - name: dev\n    security:\n      enabled: true\n      type: basic\n" +
+		"      username: admin\n      password: " + token + "\n"
+	assert.Nil(t, ioutil.WriteFile(paramsPath, []byte(content), 0644))
+
+	_, err = LoadApiParamsFromFile(paramsPath)
+	assert.NotNil(t, err, "Should return an error when the secret key is not set")
+
+	_ = os.Setenv(utils.SecretKeyEnvName, base64.StdEncoding.EncodeToString(key))
+	defer os.Unsetenv(utils.SecretKeyEnvName)
+	conf, err := LoadApiParamsFromFile(paramsPath)
+	assert.Nil(t, err)
+	assert.Equal(t, "admin123", conf.GetEnv("dev").Security.Password, "Should decrypt encrypted values")
+
+	rendered, err := RenderApiParamsEnv(paramsPath, "dev")
+	assert.Nil(t, err)
+	assert.Contains(t, string(rendered), token, "Should not decrypt rendered values")
+}
@@ -0,0 +1,199 @@
+package params
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	// yamlErrorLine matches the line prefix of errors returned by the YAML decoder
+	yamlErrorLine = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+	// yamlUnknownField matches unknown field errors returned by the YAML decoder
+	yamlUnknownField = regexp.MustCompile(`^field (\S+) not found in type \S+$`)
+)
+
+// ValidationError is an error in a params file. Line and Column are zero when the position is unknown
+type ValidationError struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %s", e.File, e.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Message)
+}
+
+// strictEnvironment is an environment as written in a params file
+type strictEnvironment struct {
+	Environment `yaml:",inline"`
+	// Extends is the name of the environment this environment inherits from
+	Extends string `yaml:"extends"`
+}
+
+// strictApiParams is an API params file as written, used to find unknown fields
+type strictApiParams struct {
+	Defaults     *Environment        `yaml:"defaults"`
+	Environments []strictEnvironment `yaml:"environments"`
+}
+
+// decodeStrict decodes content of the params file in path and returns errors for unknown fields and values of
+// wrong types with their position in the file
+func decodeStrict(path string, content []byte) error {
+	err := yaml.UnmarshalStrict(content, &strictApiParams{})
+	if err == nil {
+		return nil
+	}
+	lines := strings.Split(string(content), "\n")
+
+	var messages []string
+	if typeError, ok := err.(*yaml.TypeError); ok {
+		messages = typeError.Errors
+	} else {
+		messages = []string{err.Error()}
+	}
+
+	var errorResults error
+	for _, message := range messages {
+		validationError := &ValidationError{File: path, Message: message}
+		if match := yamlErrorLine.FindStringSubmatch(strings.TrimSpace(message)); match != nil {
+			validationError.Line, _ = strconv.Atoi(match[1])
+			validationError.Message = match[2]
+			validationError.Column = 1
+			if validationError.Line > 0 && validationError.Line <= len(lines) {
+				line := lines[validationError.Line-1]
+				validationError.Column = len(line) - len(strings.TrimLeft(line, " \t-")) + 1
+				if field := yamlUnknownField.FindStringSubmatch(match[2]); field != nil {
+					validationError.Message = "unknown field " + field[1]
+					if index := strings.Index(line, field[1]); index >= 0 {
+						validationError.Column = index + 1
+					}
+				}
+			}
+		}
+		errorResults = multierror.Append(errorResults, validationError)
+	}
+	return errorResults
+}
+
+// validate checks the values of the environment which can be checked without an API project
+func (env *Environment) validate(path string) error {
+	var errorResults error
+	addError := func(format string, args ...interface{}) {
+		errorResults = multierror.Append(errorResults, &ValidationError{File: path,
+			Message: fmt.Sprintf("environment %s: ", env.Name) + fmt.Sprintf(format, args...)})
+	}
+
+	if env.Endpoints != nil {
+		switch env.Endpoints.Type {
+		case "", "http", "address", "ws", "load_balance", "failover":
+		default:
+			addError("endpoints.type %q should be either http, address, ws, load_balance or failover",
+				env.Endpoints.Type)
+		}
+		for name, endpoint := range map[string]*Endpoint{"production": env.Endpoints.Production,
+			"sandbox": env.Endpoints.Sandbox} {
+			if endpoint == nil {
+				continue
+			}
+			if endpoint.Url != nil && !isValidURL(*endpoint.Url) {
+				addError("endpoints.%s.url %q is not a valid URL", name, *endpoint.Url)
+			}
+			for _, u := range endpoint.Urls {
+				if !isValidURL(u) {
+					addError("endpoints.%s.urls %q is not a valid URL", name, u)
+				}
+			}
+			for _, u := range endpoint.FailoverUrls {
+				if !isValidURL(u) {
+					addError("endpoints.%s.failoverUrls %q is not a valid URL", name, u)
+				}
+			}
+			if len(endpoint.Urls) > 0 && env.Endpoints.Type == "" {
+				addError("endpoints.%s.urls requires endpoints.type", name)
+			}
+			switch env.Endpoints.Type {
+			case "http", "address", "ws":
+				count := len(endpoint.Urls)
+				if endpoint.Url != nil {
+					count++
+				}
+				if count > 1 {
+					addError("endpoints.%s should have a single url with endpoints.type %s", name,
+						env.Endpoints.Type)
+				}
+			}
+			if len(endpoint.FailoverUrls) > 0 && env.Endpoints.Type != "failover" {
+				addError("endpoints.%s.failoverUrls requires endpoints.type failover", name)
+			}
+		}
+	}
+	if env.Security != nil {
+		if env.Security.Enabled != "" {
+			if _, err := strconv.ParseBool(env.Security.Enabled); err != nil {
+				addError("security.enabled %q should be true or false", env.Security.Enabled)
+			}
+		}
+		if env.Security.Type != "" && env.Security.Type != "basic" && env.Security.Type != "digest" {
+			addError("security.type %q should be either basic or digest", env.Security.Type)
+		}
+	}
+	return errorResults
+}
+
+// isValidURL returns true if u is an absolute URL with a host
+func isValidURL(u string) bool {
+	parsed, err := url.Parse(u)
+	return err == nil && parsed.Scheme != "" && parsed.Host != ""
+}
+
+// ValidateApiParamsFile validates the API params file in path without importing an API. Unknown fields, malformed
+// endpoint URLs, security settings and certificates which do not exist relative to projectDir, or as an
+// absolute path, are reported
+func ValidateApiParamsFile(path, projectDir string) error {
+	apiParams, err := LoadApiParamsFromFile(path)
+	if err != nil {
+		return err
+	}
+
+	var errorResults error
+	for _, env := range apiParams.Environments {
+		for _, cert := range env.Certs {
+			if cert.Path == "" {
+				errorResults = multierror.Append(errorResults, &ValidationError{File: path,
+					Message: fmt.Sprintf("environment %s: path of certificate %s is empty", env.Name, cert.Alias)})
+				continue
+			}
+			if !certExists(projectDir, cert.Path) {
+				errorResults = multierror.Append(errorResults, &ValidationError{File: path,
+					Message: fmt.Sprintf("environment %s: certificate %s does not exist", env.Name, cert.Path)})
+			}
+		}
+	}
+	return errorResults
+}
+
+// certExists returns true if the certificate in certPath exists in projectDir or as an absolute path
+func certExists(projectDir, certPath string) bool {
+	if info, err := os.Stat(filepath.Join(projectDir, certPath)); err == nil && !info.IsDir() {
+		return true
+	}
+	p, err := homedir.Expand(filepath.Clean(certPath))
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(p)
+	return err == nil && !info.IsDir()
+}
@@ -22,6 +22,10 @@ const (
 	EpHttp        = "http"
 	EpLoadbalance = "load_balance"
 	EpFailover    = "failover"
+	// EpAddress is the endpoint type of SOAP endpoints
+	EpAddress = "address"
+	// EpWS is the endpoint type of WebSocket endpoints
+	EpWS = "ws"
 )
 
 // APIDefinition represents an API artifact in APIM
@@ -0,0 +1,263 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// types of streaming APIs
+const (
+	APITypeWS     = "WS"
+	APITypeWebSub = "WEBSUB"
+	APITypeSSE    = "SSE"
+)
+
+// verbs of the URI templates of streaming APIs
+const (
+	AsyncAPISubscribe = "SUBSCRIBE"
+	AsyncAPIPublish   = "PUBLISH"
+)
+
+// AsyncAPIDocument is an AsyncAPI 2.x document
+type AsyncAPIDocument struct {
+	AsyncAPI string                     `json:"asyncapi"`
+	Info     AsyncAPIInfo               `json:"info"`
+	Servers  map[string]AsyncAPIServer  `json:"servers"`
+	Channels map[string]AsyncAPIChannel `json:"channels"`
+}
+
+type AsyncAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+}
+
+type AsyncAPIServer struct {
+	URL         string                            `json:"url"`
+	Protocol    string                            `json:"protocol"`
+	Description string                            `json:"description"`
+	Variables   map[string]AsyncAPIServerVariable `json:"variables"`
+}
+
+type AsyncAPIServerVariable struct {
+	Default string `json:"default"`
+}
+
+type AsyncAPIChannel struct {
+	Description string                 `json:"description"`
+	Subscribe   *AsyncAPIOperation     `json:"subscribe"`
+	Publish     *AsyncAPIOperation     `json:"publish"`
+	Bindings    map[string]interface{} `json:"bindings"`
+}
+
+type AsyncAPIOperation struct {
+	OperationID string                 `json:"operationId"`
+	Summary     string                 `json:"summary"`
+	Bindings    map[string]interface{} `json:"bindings"`
+}
+
+// ParseAsyncAPI parses an AsyncAPI 2.x document written in YAML or JSON
+func ParseAsyncAPI(content []byte) (*AsyncAPIDocument, error) {
+	jsonContent, err := utils.YamlToJson(content)
+	if err != nil {
+		return nil, err
+	}
+	var doc AsyncAPIDocument
+	if err := json.Unmarshal(jsonContent, &doc); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(doc.AsyncAPI, "2.") {
+		return nil, fmt.Errorf("unsupported AsyncAPI version %q, only 2.x documents are supported", doc.AsyncAPI)
+	}
+	if doc.Info.Title == "" || doc.Info.Version == "" {
+		return nil, fmt.Errorf("info.title and info.version are required")
+	}
+	if len(doc.Channels) == 0 {
+		return nil, fmt.Errorf("no channels found in the AsyncAPI document")
+	}
+	return &doc, nil
+}
+
+// asyncAPIProtocolTypes maps protocols and binding names to the API types
+var asyncAPIProtocolTypes = map[string]string{
+	"ws":     APITypeWS,
+	"wss":    APITypeWS,
+	"websub": APITypeWebSub,
+	"http":   APITypeWebSub,
+	"https":  APITypeWebSub,
+	"sse":    APITypeSSE,
+}
+
+// Type returns the API type of the document. Bindings of the channels and their operations take precedence over the
+// protocols of the servers. Returns an error if the document uses protocols of different API types
+func (doc *AsyncAPIDocument) Type() (string, error) {
+	types := make(map[string]bool)
+	for _, channel := range doc.Channels {
+		for name := range channel.Bindings {
+			if t, ok := asyncAPIProtocolTypes[name]; ok {
+				types[t] = true
+			}
+		}
+		for _, operation := range []*AsyncAPIOperation{channel.Subscribe, channel.Publish} {
+			if operation == nil {
+				continue
+			}
+			for name := range operation.Bindings {
+				if t, ok := asyncAPIProtocolTypes[name]; ok {
+					types[t] = true
+				}
+			}
+		}
+	}
+	if len(types) == 0 {
+		for _, server := range doc.Servers {
+			if t, ok := asyncAPIProtocolTypes[strings.ToLower(server.Protocol)]; ok {
+				types[t] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(types))
+	for t := range types {
+		names = append(names, t)
+	}
+	sort.Strings(names)
+	switch len(names) {
+	case 0:
+		return "", fmt.Errorf("unable to find the API type, use ws, wss, websub, http, https or sse protocols or " +
+			"bindings")
+	case 1:
+		return names[0], nil
+	}
+	return "", fmt.Errorf("protocols of different API types found: %s", strings.Join(names, ", "))
+}
+
+// serverURL returns the URL of server with its variables replaced by their default values. The protocol is added
+// when the URL does not have a scheme
+func (server AsyncAPIServer) serverURL() string {
+	u := server.URL
+	for name, variable := range server.Variables {
+		u = strings.ReplaceAll(u, "{"+name+"}", variable.Default)
+	}
+	if !strings.Contains(u, "://") && server.Protocol != "" {
+		protocol := strings.ToLower(server.Protocol)
+		if protocol == "websub" || protocol == "sse" {
+			protocol = "https"
+		}
+		u = protocol + "://" + u
+	}
+	return u
+}
+
+// Endpoints maps the servers to production and sandbox endpoints. The first server with sandbox in its name or
+// description, in the order of the names, is used as the sandbox endpoint and the first of the others as production
+func (doc *AsyncAPIDocument) Endpoints() (*Endpoints, *Endpoints) {
+	names := make([]string, 0, len(doc.Servers))
+	for name := range doc.Servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	prodEp, sandboxEp := &Endpoints{}, &Endpoints{}
+	for _, name := range names {
+		server := doc.Servers[name]
+		sandbox := strings.Contains(strings.ToLower(name+" "+server.Description), "sandbox")
+		if sandbox && len(sandboxEp.Urls) == 0 {
+			sandboxEp.Urls = []string{server.serverURL()}
+		} else if !sandbox && len(prodEp.Urls) == 0 {
+			prodEp.Urls = []string{server.serverURL()}
+		}
+	}
+	return prodEp, sandboxEp
+}
+
+// AsyncAPIPopulate populates def using the AsyncAPI document. Each channel becomes an URI template with a verb for
+// each of its operations and the servers are used as endpoints
+func AsyncAPIPopulate(def *APIDefinition, doc *AsyncAPIDocument) error {
+	apiType, err := doc.Type()
+	if err != nil {
+		return err
+	}
+	def.Type = apiType
+	def.ID.APIName = utils.ToPascalCase(doc.Info.Title)
+	def.ID.Version = doc.Info.Version
+	def.Description = doc.Info.Description
+	def.Context = fmt.Sprintf("/%s/%s", def.ID.APIName, def.ID.Version)
+	def.ContextTemplate = fmt.Sprintf("/%s/{version}", def.ID.APIName)
+
+	if prodEp, sandboxEp := doc.Endpoints(); len(prodEp.Urls) > 0 || len(sandboxEp.Urls) > 0 {
+		ep, err := BuildAPIMEndpoints(prodEp, sandboxEp)
+		if err != nil {
+			return err
+		}
+		if apiType == APITypeWS {
+			endpoint, err := gabs.ParseJSON([]byte(ep))
+			if err != nil {
+				return err
+			}
+			_, _ = endpoint.Set(EpWS, "endpoint_type")
+			ep = endpoint.String()
+		}
+		def.EndpointConfig = &ep
+		def.ProductionUrl = ""
+		def.SandboxUrl = ""
+	}
+
+	channels := make([]string, 0, len(doc.Channels))
+	for name := range doc.Channels {
+		channels = append(channels, name)
+	}
+	sort.Strings(channels)
+	def.URITemplates = make([]URITemplates, len(channels))
+	for i, name := range channels {
+		channel := doc.Channels[name]
+		var verbs []string
+		if channel.Subscribe != nil {
+			verbs = append(verbs, AsyncAPISubscribe)
+		}
+		if channel.Publish != nil {
+			verbs = append(verbs, AsyncAPIPublish)
+		}
+		if len(verbs) == 0 {
+			verbs = []string{AsyncAPISubscribe}
+		}
+		uriTemplate := URITemplates{
+			URITemplate:     "/" + strings.TrimPrefix(name, "/"),
+			HTTPVerb:        verbs[0],
+			HTTPVerbs:       verbs,
+			AuthType:        "Any",
+			AuthTypes:       make([]string, len(verbs)),
+			ThrottlingTier:  "Unlimited",
+			ThrottlingTiers: make([]string, len(verbs)),
+		}
+		for j := range verbs {
+			uriTemplate.AuthTypes[j] = "Any"
+			uriTemplate.ThrottlingTiers[j] = "Unlimited"
+		}
+		def.URITemplates[i] = uriTemplate
+	}
+	return nil
+}
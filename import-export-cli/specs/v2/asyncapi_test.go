@@ -0,0 +1,114 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readAsyncAPI(t *testing.T, path string) *AsyncAPIDocument {
+	content, err := ioutil.ReadFile(path)
+	assert.Nil(t, err, "err should be nil")
+	doc, err := ParseAsyncAPI(content)
+	assert.Nil(t, err, "err should be nil")
+	return doc
+}
+
+func TestAsyncAPIPopulateWS(t *testing.T) {
+	doc := readAsyncAPI(t, "testdata/asyncapi/chat_ws.yaml")
+	def := &APIDefinition{Type: "HTTP", ProductionUrl: "http://localhost"}
+	err := AsyncAPIPopulate(def, doc)
+	assert.Nil(t, err, "err should be nil")
+
+	assert.Equal(t, APITypeWS, def.Type)
+	assert.Equal(t, "ChatRooms", def.ID.APIName)
+	assert.Equal(t, "/ChatRooms/1.0.0", def.Context)
+	assert.Equal(t, "Chat rooms over WebSocket", def.Description)
+	assert.Empty(t, def.ProductionUrl)
+	assert.Contains(t, *def.EndpointConfig, `"endpoint_type":"ws"`)
+	assert.Contains(t, *def.EndpointConfig, `"production_endpoints":{"config":null,"url":"wss://chat.example.com:443/ws"}`)
+	assert.Contains(t, *def.EndpointConfig,
+		`"sandbox_endpoints":{"config":null,"url":"wss://sandbox.chat.example.com/ws"}`)
+
+	assert.Len(t, def.URITemplates, 2)
+	assert.Equal(t, "/notifications", def.URITemplates[0].URITemplate)
+	assert.Equal(t, []string{AsyncAPISubscribe}, def.URITemplates[0].HTTPVerbs)
+	assert.Equal(t, "/rooms/{roomId}", def.URITemplates[1].URITemplate)
+	assert.Equal(t, []string{AsyncAPISubscribe, AsyncAPIPublish}, def.URITemplates[1].HTTPVerbs)
+	assert.Equal(t, []string{"Unlimited", "Unlimited"}, def.URITemplates[1].ThrottlingTiers)
+}
+
+func TestAsyncAPIPopulateWebSub(t *testing.T) {
+	doc := readAsyncAPI(t, "testdata/asyncapi/orders_websub.json")
+	def := &APIDefinition{}
+	err := AsyncAPIPopulate(def, doc)
+	assert.Nil(t, err, "err should be nil")
+
+	assert.Equal(t, APITypeWebSub, def.Type)
+	assert.Contains(t, *def.EndpointConfig, `"endpoint_type":"http"`)
+	assert.Contains(t, *def.EndpointConfig, `"url":"https://hub.example.com/orders"`)
+	assert.NotContains(t, *def.EndpointConfig, "sandbox_endpoints")
+	assert.Len(t, def.URITemplates, 2)
+	assert.Equal(t, "/created", def.URITemplates[0].URITemplate)
+}
+
+func TestAsyncAPIType(t *testing.T) {
+	tests := []struct {
+		spec    string
+		apiType string
+		message string
+	}{
+		{`servers: {a: {url: example.com, protocol: sse}}`, APITypeSSE, ""},
+		{`servers: {a: {url: example.com, protocol: kafka}}`, "", "unable to find the API type"},
+		{`servers: {a: {url: example.com, protocol: ws}, b: {url: example.com, protocol: sse}}`, "",
+			"protocols of different API types found: SSE, WS"},
+	}
+	for _, test := range tests {
+		doc, err := ParseAsyncAPI([]byte("asyncapi: 2.0.0\ninfo: {title: a, version: v1}\n" +
+			"channels: {c: {subscribe: {}}}\n" + test.spec))
+		assert.Nil(t, err, test.spec)
+		apiType, err := doc.Type()
+		if test.message == "" {
+			assert.Nil(t, err, test.spec)
+			assert.Equal(t, test.apiType, apiType, test.spec)
+		} else if assert.NotNil(t, err, test.spec) {
+			assert.Contains(t, err.Error(), test.message, test.spec)
+		}
+	}
+}
+
+func TestParseAsyncAPIErrors(t *testing.T) {
+	tests := []struct {
+		spec    string
+		message string
+	}{
+		{"asyncapi: 1.2.0\ninfo: {title: a, version: v1}\nchannels: {c: {}}", "unsupported AsyncAPI version \"1.2.0\""},
+		{"asyncapi: 2.0.0\ninfo: {title: a}\nchannels: {c: {}}", "info.title and info.version are required"},
+		{"asyncapi: 2.0.0\ninfo: {title: a, version: v1}", "no channels found"},
+	}
+	for _, test := range tests {
+		_, err := ParseAsyncAPI([]byte(test.spec))
+		if assert.NotNil(t, err, test.spec) {
+			assert.Contains(t, err.Error(), test.message, test.spec)
+		}
+	}
+}
@@ -0,0 +1,788 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+const APITypeGraphQL = "GRAPHQL"
+
+// GraphQL operation types, used as the verbs of the URI templates of GraphQL APIs
+const (
+	GraphQLQuery        = "QUERY"
+	GraphQLMutation     = "MUTATION"
+	GraphQLSubscription = "SUBSCRIPTION"
+)
+
+// kinds of the GraphQL named types
+const (
+	graphQLScalar    = "scalar"
+	graphQLObject    = "type"
+	graphQLInterface = "interface"
+	graphQLUnion     = "union"
+	graphQLEnum      = "enum"
+	graphQLInput     = "input"
+)
+
+var graphQLBuiltInScalars = []string{"Int", "Float", "String", "Boolean", "ID"}
+
+var graphQLBuiltInDirectives = []string{"skip", "include", "deprecated", "specifiedBy"}
+
+// GraphQLSchema is a GraphQL schema parsed from its SDL
+type GraphQLSchema struct {
+	// RootTypes are the names of the root types by operation type
+	RootTypes map[string]string
+	// types are the named types by name
+	types map[string]*graphQLType
+}
+
+// graphQLType is a named type of a GraphQL schema
+type graphQLType struct {
+	kind, name string
+	// fields of object, interface and input types, or the values of enums
+	fields              []graphQLField
+	interfaces, members []string
+	// line is 0 for built-in types
+	line, col int
+}
+
+// graphQLField is a field of an object, interface or input type, or an argument of a field
+type graphQLField struct {
+	name        string
+	description string
+	// typeName is the name of the type of the field, without list and non-null wrappers
+	typeName  string
+	args      []graphQLField
+	line, col int
+}
+
+// graphQLReference is a name referred to in the document, such as an applied directive
+type graphQLReference struct {
+	name      string
+	line, col int
+}
+
+// GraphQLOperation is a field of a root type
+type GraphQLOperation struct {
+	Type        string
+	Name        string
+	Description string
+}
+
+// graphQLToken kinds
+const (
+	gqlEOF = iota
+	gqlPunctuator
+	gqlName
+	gqlNumber
+	gqlString
+)
+
+type graphQLToken struct {
+	kind      int
+	value     string
+	line, col int
+}
+
+// graphQLParser parses the type system definitions of a GraphQL SDL document
+type graphQLParser struct {
+	src       string
+	pos       int
+	line, col int
+	token     graphQLToken
+	schema    *GraphQLSchema
+	// types defined in the document in their order, and type extensions which are applied after all the types
+	// are defined
+	types, extensions []*graphQLType
+	// definedDirectives are the lines of the directive definitions by name, 0 for built-in directives
+	definedDirectives map[string]int
+	// directiveArgs are the directive definitions with their arguments
+	directiveArgs []graphQLField
+	// applied are the directives applied in the document
+	applied []graphQLReference
+}
+
+func (p *graphQLParser) errorf(line, col int, format string, args ...interface{}) error {
+	return fmt.Errorf("line %d:%d: %s", line, col, fmt.Sprintf(format, args...))
+}
+
+// advance moves the position forward by n bytes of the current line
+func (p *graphQLParser) advance(n int) {
+	p.pos += n
+	p.col += n
+}
+
+// skipIgnored skips white space, line terminators, commas and comments
+func (p *graphQLParser) skipIgnored() {
+	for p.pos < len(p.src) {
+		switch c := p.src[p.pos]; {
+		case c == '\n':
+			p.pos++
+			p.line++
+			p.col = 1
+		case c == ' ' || c == '\t' || c == '\r' || c == ',':
+			p.advance(1)
+		case c == '#':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		case strings.HasPrefix(p.src[p.pos:], "\uFEFF"):
+			// byte order mark
+			p.pos += len("\uFEFF")
+		default:
+			return
+		}
+	}
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameContinue(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
+
+// next reads the next token
+func (p *graphQLParser) next() error {
+	p.skipIgnored()
+	line, col := p.line, p.col
+	if p.pos >= len(p.src) {
+		p.token = graphQLToken{kind: gqlEOF, line: line, col: col}
+		return nil
+	}
+	start := p.pos
+	c := p.src[p.pos]
+	switch {
+	case strings.IndexByte("!$&()*:=@[]{}|", c) >= 0:
+		p.advance(1)
+		p.token = graphQLToken{kind: gqlPunctuator, value: string(c), line: line, col: col}
+	case isNameStart(c):
+		for p.pos < len(p.src) && isNameContinue(p.src[p.pos]) {
+			p.advance(1)
+		}
+		p.token = graphQLToken{kind: gqlName, value: p.src[start:p.pos], line: line, col: col}
+	case c == '-' || (c >= '0' && c <= '9'):
+		p.advance(1)
+		for p.pos < len(p.src) && strings.IndexByte("0123456789.eE+-", p.src[p.pos]) >= 0 {
+			p.advance(1)
+		}
+		p.token = graphQLToken{kind: gqlNumber, value: p.src[start:p.pos], line: line, col: col}
+	case strings.HasPrefix(p.src[p.pos:], `"""`):
+		end := strings.Index(p.src[p.pos+3:], `"""`)
+		if end < 0 {
+			return p.errorf(line, col, "unterminated block string")
+		}
+		value := p.src[p.pos+3 : p.pos+3+end]
+		for _, r := range p.src[p.pos : p.pos+end+6] {
+			if r == '\n' {
+				p.line++
+				p.col = 1
+			} else {
+				p.col++
+			}
+		}
+		p.pos += end + 6
+		p.token = graphQLToken{kind: gqlString, value: strings.TrimSpace(value), line: line, col: col}
+	case c == '"':
+		p.advance(1)
+		var value strings.Builder
+		for {
+			if p.pos >= len(p.src) || p.src[p.pos] == '\n' {
+				return p.errorf(line, col, "unterminated string")
+			}
+			if p.src[p.pos] == '"' {
+				p.advance(1)
+				break
+			}
+			if p.src[p.pos] == '\\' && p.pos+1 < len(p.src) {
+				value.WriteByte(p.src[p.pos+1])
+				p.advance(2)
+				continue
+			}
+			r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+			value.WriteRune(r)
+			p.advance(size)
+		}
+		p.token = graphQLToken{kind: gqlString, value: value.String(), line: line, col: col}
+	default:
+		r, _ := utf8.DecodeRuneInString(p.src[p.pos:])
+		return p.errorf(line, col, "unexpected character %q", r)
+	}
+	return nil
+}
+
+// describe returns the current token for error messages
+func (p *graphQLParser) describe() string {
+	if p.token.kind == gqlEOF {
+		return "end of document"
+	}
+	return fmt.Sprintf("%q", p.token.value)
+}
+
+// peek returns true if the current token is the punctuator or keyword value
+func (p *graphQLParser) peek(value string) bool {
+	return (p.token.kind == gqlPunctuator || p.token.kind == gqlName) && p.token.value == value
+}
+
+// expect reads the punctuator or keyword value
+func (p *graphQLParser) expect(value string) error {
+	if !p.peek(value) {
+		return p.errorf(p.token.line, p.token.col, "expected %q, found %s", value, p.describe())
+	}
+	return p.next()
+}
+
+// name reads a name
+func (p *graphQLParser) name() (string, error) {
+	if p.token.kind != gqlName {
+		return "", p.errorf(p.token.line, p.token.col, "expected a name, found %s", p.describe())
+	}
+	name := p.token.value
+	return name, p.next()
+}
+
+// skip reads the punctuator or keyword value if it is the current token and returns true if it was read
+func (p *graphQLParser) skip(value string) (bool, error) {
+	if !p.peek(value) {
+		return false, nil
+	}
+	return true, p.next()
+}
+
+// description reads the description of a definition if present
+func (p *graphQLParser) description() (string, error) {
+	if p.token.kind != gqlString {
+		return "", nil
+	}
+	description := p.token.value
+	return description, p.next()
+}
+
+// names reads names separated by separator, such as implemented interfaces or union members. A leading separator
+// is allowed
+func (p *graphQLParser) names(separator string) ([]string, error) {
+	if _, err := p.skip(separator); err != nil {
+		return nil, err
+	}
+	var names []string
+	for {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+		if ok, err := p.skip(separator); err != nil || !ok {
+			return names, err
+		}
+	}
+}
+
+// typeReference reads a type reference such as [String!]! and returns the name of the type
+func (p *graphQLParser) typeReference() (string, error) {
+	var name string
+	if ok, err := p.skip("["); err != nil {
+		return "", err
+	} else if ok {
+		if name, err = p.typeReference(); err != nil {
+			return "", err
+		}
+		if err := p.expect("]"); err != nil {
+			return "", err
+		}
+	} else if name, err = p.name(); err != nil {
+		return "", err
+	}
+	_, err := p.skip("!")
+	return name, err
+}
+
+// value reads a constant value
+func (p *graphQLParser) value() error {
+	switch {
+	case p.token.kind == gqlNumber || p.token.kind == gqlString || p.token.kind == gqlName:
+		return p.next()
+	case p.peek("[") || p.peek("{"):
+		object, closing := p.peek("{"), "]"
+		if object {
+			closing = "}"
+		}
+		if err := p.next(); err != nil {
+			return err
+		}
+		for !p.peek(closing) {
+			if p.token.kind == gqlEOF {
+				return p.errorf(p.token.line, p.token.col, "expected %q, found %s", closing, p.describe())
+			}
+			if object {
+				if _, err := p.name(); err != nil {
+					return err
+				}
+				if err := p.expect(":"); err != nil {
+					return err
+				}
+			}
+			if err := p.value(); err != nil {
+				return err
+			}
+		}
+		return p.next()
+	case p.peek("$"):
+		return p.errorf(p.token.line, p.token.col, "variables are not allowed in a schema")
+	}
+	return p.errorf(p.token.line, p.token.col, "expected a value, found %s", p.describe())
+}
+
+// directives reads the directives applied to a definition
+func (p *graphQLParser) directives() error {
+	for p.peek("@") {
+		if err := p.next(); err != nil {
+			return err
+		}
+		directive := graphQLReference{line: p.token.line, col: p.token.col}
+		var err error
+		if directive.name, err = p.name(); err != nil {
+			return err
+		}
+		p.applied = append(p.applied, directive)
+		if ok, err := p.skip("("); err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+		for !p.peek(")") {
+			if _, err := p.name(); err != nil {
+				return err
+			}
+			if err := p.expect(":"); err != nil {
+				return err
+			}
+			if err := p.value(); err != nil {
+				return err
+			}
+		}
+		if err := p.next(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldDefinitions reads field, argument or input field definitions until the closing punctuator. Arguments and
+// input fields are input values, which can have a default value
+func (p *graphQLParser) fieldDefinitions(closing string, inputValues bool) ([]graphQLField, error) {
+	var fields []graphQLField
+	for !p.peek(closing) {
+		description, err := p.description()
+		if err != nil {
+			return nil, err
+		}
+		field := graphQLField{description: description, line: p.token.line, col: p.token.col}
+		if field.name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if !inputValues {
+			if ok, err := p.skip("("); err != nil {
+				return nil, err
+			} else if ok {
+				if field.args, err = p.fieldDefinitions(")", true); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if field.typeName, err = p.typeReference(); err != nil {
+			return nil, err
+		}
+		if inputValues {
+			if ok, err := p.skip("="); err != nil {
+				return nil, err
+			} else if ok {
+				if err := p.value(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if err := p.directives(); err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	return fields, p.next()
+}
+
+// typeDefinition reads the definition of a named type after its keyword
+func (p *graphQLParser) typeDefinition(kind string) (*graphQLType, error) {
+	t := &graphQLType{kind: kind, line: p.token.line, col: p.token.col}
+	var err error
+	if t.name, err = p.name(); err != nil {
+		return nil, err
+	}
+	if kind == graphQLObject || kind == graphQLInterface {
+		if ok, err := p.skip("implements"); err != nil {
+			return nil, err
+		} else if ok {
+			if t.interfaces, err = p.names("&"); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := p.directives(); err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case graphQLObject, graphQLInterface, graphQLInput:
+		if ok, err := p.skip("{"); err != nil || !ok {
+			return t, err
+		}
+		t.fields, err = p.fieldDefinitions("}", kind == graphQLInput)
+	case graphQLEnum:
+		if ok, err := p.skip("{"); err != nil || !ok {
+			return t, err
+		}
+		for !p.peek("}") {
+			if _, err := p.description(); err != nil {
+				return nil, err
+			}
+			value := graphQLField{line: p.token.line, col: p.token.col}
+			if value.name, err = p.name(); err != nil {
+				return nil, err
+			}
+			if err := p.directives(); err != nil {
+				return nil, err
+			}
+			t.fields = append(t.fields, value)
+		}
+		err = p.next()
+	case graphQLUnion:
+		if ok, err := p.skip("="); err != nil || !ok {
+			return t, err
+		}
+		t.members, err = p.names("|")
+	}
+	return t, err
+}
+
+// schemaDefinition reads the root operation types of a schema definition or extension
+func (p *graphQLParser) schemaDefinition() error {
+	if err := p.directives(); err != nil {
+		return err
+	}
+	if ok, err := p.skip("{"); err != nil || !ok {
+		return err
+	}
+	for !p.peek("}") {
+		line, col := p.token.line, p.token.col
+		operation, err := p.name()
+		if err != nil {
+			return err
+		}
+		operation = strings.ToUpper(operation)
+		if operation != GraphQLQuery && operation != GraphQLMutation && operation != GraphQLSubscription {
+			return p.errorf(line, col, "unknown operation type %s", strings.ToLower(operation))
+		}
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		if p.schema.RootTypes[operation], err = p.name(); err != nil {
+			return err
+		}
+	}
+	return p.next()
+}
+
+// directiveDefinition reads a directive definition after its keyword
+func (p *graphQLParser) directiveDefinition() error {
+	if err := p.expect("@"); err != nil {
+		return err
+	}
+	line, col := p.token.line, p.token.col
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if defined, ok := p.definedDirectives[name]; ok {
+		if defined == 0 {
+			return p.errorf(line, col, "built-in directive @%s can not be redefined", name)
+		}
+		return p.errorf(line, col, "directive @%s is already defined at line %d", name, defined)
+	}
+	p.definedDirectives[name] = line
+	if ok, err := p.skip("("); err != nil {
+		return err
+	} else if ok {
+		args, err := p.fieldDefinitions(")", true)
+		if err != nil {
+			return err
+		}
+		p.directiveArgs = append(p.directiveArgs, graphQLField{name: name, args: args})
+	}
+	if _, err := p.skip("repeatable"); err != nil {
+		return err
+	}
+	if err := p.expect("on"); err != nil {
+		return err
+	}
+	_, err = p.names("|")
+	return err
+}
+
+// definition reads a type system definition or extension
+func (p *graphQLParser) definition() error {
+	if _, err := p.description(); err != nil {
+		return err
+	}
+	line, col := p.token.line, p.token.col
+	keyword, err := p.name()
+	if err != nil {
+		if p.peek("{") {
+			return p.errorf(line, col, "executable definitions are not allowed in a schema")
+		}
+		return err
+	}
+	extend := keyword == "extend"
+	if extend {
+		if keyword, err = p.name(); err != nil {
+			return err
+		}
+	}
+	switch keyword {
+	case "schema":
+		return p.schemaDefinition()
+	case "directive":
+		if extend {
+			return p.errorf(line, col, "directives can not be extended")
+		}
+		return p.directiveDefinition()
+	case graphQLScalar, graphQLObject, graphQLInterface, graphQLUnion, graphQLEnum, graphQLInput:
+		t, err := p.typeDefinition(keyword)
+		if err != nil {
+			return err
+		}
+		if extend {
+			p.extensions = append(p.extensions, t)
+			return nil
+		}
+		if existing, ok := p.schema.types[t.name]; ok {
+			if existing.line == 0 {
+				return p.errorf(t.line, t.col, "built-in type %s can not be redefined", t.name)
+			}
+			return p.errorf(t.line, t.col, "type %s is already defined at line %d", t.name, existing.line)
+		}
+		p.schema.types[t.name] = t
+		p.types = append(p.types, t)
+		return nil
+	case "query", "mutation", "subscription", "fragment":
+		return p.errorf(line, col, "executable definitions are not allowed in a schema")
+	}
+	return p.errorf(line, col, "unexpected %q", keyword)
+}
+
+// isInputType returns true if the named type can be used for arguments and input fields
+func (s *GraphQLSchema) isInputType(t *graphQLType) bool {
+	return t.kind == graphQLScalar || t.kind == graphQLEnum || t.kind == graphQLInput
+}
+
+// validateFields checks that the fields of t are unique and their types and the types of their arguments are
+// defined and can be used for them
+func (p *graphQLParser) validateFields(t *graphQLType, addError func(line, col int, format string,
+	args ...interface{})) {
+	s := p.schema
+	member := "field"
+	if t.kind == graphQLEnum {
+		member = "value"
+	}
+	seen := make(map[string]bool)
+	for _, field := range t.fields {
+		if seen[field.name] {
+			addError(field.line, field.col, "%s %s.%s is defined more than once", member, t.name, field.name)
+		}
+		seen[field.name] = true
+		if t.kind == graphQLEnum {
+			continue
+		}
+		fieldType, ok := s.types[field.typeName]
+		switch {
+		case !ok:
+			addError(field.line, field.col, "type %s of %s.%s is not defined", field.typeName, t.name, field.name)
+		case t.kind == graphQLInput && !s.isInputType(fieldType):
+			addError(field.line, field.col, "input field %s.%s can not be of %s %s", t.name, field.name,
+				fieldType.kind, fieldType.name)
+		case t.kind != graphQLInput && fieldType.kind == graphQLInput:
+			addError(field.line, field.col, "field %s.%s can not be of input %s", t.name, field.name,
+				fieldType.name)
+		}
+		p.validateArgs(t.name+"."+field.name, field.args, addError)
+	}
+}
+
+// validateArgs checks that the types of the arguments of owner are defined input types
+func (p *graphQLParser) validateArgs(owner string, args []graphQLField, addError func(line, col int,
+	format string, args ...interface{})) {
+	for _, arg := range args {
+		argType, ok := p.schema.types[arg.typeName]
+		if !ok {
+			addError(arg.line, arg.col, "type %s of argument %s of %s is not defined", arg.typeName, arg.name,
+				owner)
+		} else if !p.schema.isInputType(argType) {
+			addError(arg.line, arg.col, "argument %s of %s can not be of %s %s", arg.name, owner, argType.kind,
+				argType.name)
+		}
+	}
+}
+
+// validate checks that the types and directives referred to in the schema are defined and can be used where they
+// are referred to
+func (p *graphQLParser) validate() error {
+	s := p.schema
+	var errorResults error
+	addError := func(line, col int, format string, args ...interface{}) {
+		errorResults = multierror.Append(errorResults, p.errorf(line, col, format, args...))
+	}
+
+	for _, extension := range p.extensions {
+		t, ok := s.types[extension.name]
+		if !ok || t.line == 0 {
+			addError(extension.line, extension.col, "extended type %s is not defined", extension.name)
+			continue
+		}
+		if t.kind != extension.kind {
+			addError(extension.line, extension.col, "%s %s can not be extended as %s", t.kind, t.name,
+				extension.kind)
+			continue
+		}
+		t.fields = append(t.fields, extension.fields...)
+		t.interfaces = append(t.interfaces, extension.interfaces...)
+		t.members = append(t.members, extension.members...)
+	}
+
+	for _, t := range p.types {
+		p.validateFields(t, addError)
+		for _, name := range t.interfaces {
+			if i, ok := s.types[name]; !ok || i.kind != graphQLInterface {
+				addError(t.line, t.col, "%s implements %s which is not an interface", t.name, name)
+			}
+		}
+		for _, name := range t.members {
+			if m, ok := s.types[name]; !ok || m.kind != graphQLObject {
+				addError(t.line, t.col, "member %s of union %s is not an object type", name, t.name)
+			}
+		}
+	}
+	for _, directive := range p.directiveArgs {
+		p.validateArgs("@"+directive.name, directive.args, addError)
+	}
+	for _, directive := range p.applied {
+		if _, ok := p.definedDirectives[directive.name]; !ok {
+			addError(directive.line, directive.col, "directive @%s is not defined", directive.name)
+		}
+	}
+
+	for _, operation := range []string{GraphQLQuery, GraphQLMutation, GraphQLSubscription} {
+		name, ok := s.RootTypes[operation]
+		if !ok {
+			continue
+		}
+		if t, ok := s.types[name]; !ok || t.kind != graphQLObject {
+			errorResults = multierror.Append(errorResults, fmt.Errorf("%s root type %s is not an object type",
+				strings.ToLower(operation), name))
+		}
+	}
+	if _, ok := s.RootTypes[GraphQLQuery]; !ok {
+		errorResults = multierror.Append(errorResults, fmt.Errorf("schema does not define a Query type"))
+	}
+	return errorResults
+}
+
+// ParseGraphQLSchema parses and validates the type system definitions of a GraphQL SDL document
+func ParseGraphQLSchema(sdl string) (*GraphQLSchema, error) {
+	schema := &GraphQLSchema{RootTypes: make(map[string]string), types: make(map[string]*graphQLType)}
+	p := &graphQLParser{src: sdl, line: 1, col: 1, schema: schema, definedDirectives: make(map[string]int)}
+	for _, name := range graphQLBuiltInScalars {
+		schema.types[name] = &graphQLType{kind: graphQLScalar, name: name}
+	}
+	for _, name := range graphQLBuiltInDirectives {
+		p.definedDirectives[name] = 0
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	for p.token.kind != gqlEOF {
+		if err := p.definition(); err != nil {
+			return nil, err
+		}
+	}
+
+	// default root types are used when there is no schema definition
+	if len(schema.RootTypes) == 0 {
+		for operation, name := range map[string]string{GraphQLQuery: "Query", GraphQLMutation: "Mutation",
+			GraphQLSubscription: "Subscription"} {
+			if _, ok := schema.types[name]; ok {
+				schema.RootTypes[operation] = name
+			}
+		}
+	}
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// Operations returns the fields of the query, mutation and subscription root types
+func (s *GraphQLSchema) Operations() []GraphQLOperation {
+	var operations []GraphQLOperation
+	for _, operation := range []string{GraphQLQuery, GraphQLMutation, GraphQLSubscription} {
+		name, ok := s.RootTypes[operation]
+		if !ok {
+			continue
+		}
+		for _, field := range s.types[name].fields {
+			operations = append(operations, GraphQLOperation{Type: operation, Name: field.name,
+				Description: field.description})
+		}
+	}
+	return operations
+}
+
+// GraphQLPopulate populates def with an URI template for each operation of the schema. The name of the operation is
+// used as the URI template and its type as the verb
+func GraphQLPopulate(def *APIDefinition, schema *GraphQLSchema) {
+	def.Type = APITypeGraphQL
+	operations := schema.Operations()
+	def.URITemplates = make([]URITemplates, len(operations))
+	for i, operation := range operations {
+		def.URITemplates[i] = URITemplates{
+			URITemplate:     operation.Name,
+			HTTPVerb:        operation.Type,
+			HTTPVerbs:       []string{operation.Type},
+			AuthType:        "Any",
+			AuthTypes:       []string{"Any"},
+			ThrottlingTier:  "Unlimited",
+			ThrottlingTiers: []string{"Unlimited"},
+		}
+	}
+}
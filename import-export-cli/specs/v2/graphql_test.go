@@ -0,0 +1,129 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGraphQLSchema(t *testing.T) {
+	content, err := ioutil.ReadFile("testdata/graphql/starwars.graphql")
+	assert.Nil(t, err, "err should be nil")
+	schema, err := ParseGraphQLSchema(string(content))
+	assert.Nil(t, err, "err should be nil")
+
+	operations := schema.Operations()
+	assert.Equal(t, []GraphQLOperation{
+		{Type: GraphQLQuery, Name: "hero", Description: "Hero of an episode"},
+		{Type: GraphQLQuery, Name: "search"},
+		{Type: GraphQLQuery, Name: "droid"},
+		{Type: GraphQLMutation, Name: "createReview"},
+		{Type: GraphQLSubscription, Name: "reviewAdded"},
+	}, operations)
+}
+
+func TestParseGraphQLSchemaDefaultRootTypes(t *testing.T) {
+	schema, err := ParseGraphQLSchema(`type Query { users: [User] } type User { id: ID }`)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, map[string]string{GraphQLQuery: "Query"}, schema.RootTypes)
+}
+
+func TestParseGraphQLSchemaOtherDefinitions(t *testing.T) {
+	schema, err := ParseGraphQLSchema(`
+"A date" scalar Date @specifiedBy(url: "https://example.com/{date}")
+directive @auth(roles: [String] = ["admin"]) repeatable on FIELD_DEFINITION | OBJECT
+input Filter { after: Date = "2020-01-01", tags: [String!] = [] }
+type Query @auth(roles: ["user"]) {
+  """
+  Events { after } the date
+  """
+  events(filter: Filter = {after: "2020-01-01"}): [Event!]! @auth
+}
+union Event = Query
+extend type Query { count: Int }`)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []GraphQLOperation{
+		{Type: GraphQLQuery, Name: "events", Description: "Events { after } the date"},
+		{Type: GraphQLQuery, Name: "count"},
+	}, schema.Operations())
+}
+
+func TestParseGraphQLSchemaErrors(t *testing.T) {
+	tests := []struct {
+		sdl     string
+		message string
+	}{
+		{"type Query {\n  user: User\n}", "line 2:3: type User of Query.user is not defined"},
+		{"type Query {\n  user(id: ID!: User\n}", "line 2:15: expected a name, found \":\""},
+		{"type Query {\n  user User\n}", "line 2:8: expected \":\", found \"User\""},
+		{"type User { id: ID }", "schema does not define a Query type"},
+		{"query { hero { name } }", "executable definitions are not allowed in a schema"},
+		{"{ hero { name } }", "executable definitions are not allowed in a schema"},
+		{"type Query { a: \"unterminated }", "unterminated string"},
+		{"schema { query: Q }\ntype Query { a: Int }", "query root type Q is not an object type"},
+		{"type Query { a: Int }\ntype Query { b: Int }", "line 2:6: type Query is already defined at line 1"},
+		{"scalar String\ntype Query { a: Int }", "line 1:8: built-in type String can not be redefined"},
+		{"type Query { a: Int, a: String }", "line 1:22: field Query.a is defined more than once"},
+		{"type Query { a: Int }\nenum E { A B A }", "line 2:14: value E.A is defined more than once"},
+		{"type Query { add(input: Query): Int }", "argument input of Query.add can not be of type Query"},
+		{"type Query { add(input: In): Int }", "type In of argument input of Query.add is not defined"},
+		{"input In { a: Int }\ntype Query { a: In }", "field Query.a can not be of input In"},
+		{"type Query { a: Int }\ninput In { q: Query }", "input field In.q can not be of type Query"},
+		{"type Query { a: Int }\ninput In { b: Missing }", "line 2:12: type Missing of In.b is not defined"},
+		{"type Query { a: Int }\ninterface Node { id: Missing }",
+			"line 2:18: type Missing of Node.id is not defined"},
+		{"type Query implements Node { a: Int }", "Query implements Node which is not an interface"},
+		{"type Query { a: Int }\nunion U = Query | String", "member String of union U is not an object type"},
+		{"type Query { a: Int }\nunion U = Query | Missing", "member Missing of union U is not an object type"},
+		{"type Query { a: Int }\nextend type Mutation { b: Int }", "extended type Mutation is not defined"},
+		{"type Query { a: Int }\nscalar S\nextend type S @deprecated", "scalar S can not be extended as type"},
+		{"directive @auth(role: Query) on FIELD_DEFINITION\ntype Query { a: Int }",
+			"argument role of @auth can not be of type Query"},
+		{"directive @a on OBJECT\ndirective @a on OBJECT\ntype Query { a: Int }",
+			"line 2:12: directive @a is already defined at line 1"},
+		{"type Query { a: Int @auth }", "line 1:22: directive @auth is not defined"},
+		{"type Query { a: Int @deprecated(reason: ) }", "line 1:41: expected a value, found \")\""},
+		{"type Query { a: Int @deprecated(reason \"old\") }", "expected \":\", found \"old\""},
+		{"type Query { a: Int @deprecated(reason: [\"old\") }", "line 1:47: expected a value, found \")\""},
+		{"type Query { a(b: Int = $c): Int }", "variables are not allowed in a schema"},
+	}
+	for _, test := range tests {
+		_, err := ParseGraphQLSchema(test.sdl)
+		if assert.NotNil(t, err, test.sdl) {
+			assert.Contains(t, err.Error(), test.message, test.sdl)
+		}
+	}
+}
+
+func TestGraphQLPopulate(t *testing.T) {
+	schema, err := ParseGraphQLSchema(`type Query { hero: String } type Mutation { like(id: ID!): Int }`)
+	assert.Nil(t, err, "err should be nil")
+	def := &APIDefinition{Type: "HTTP"}
+	GraphQLPopulate(def, schema)
+	assert.Equal(t, APITypeGraphQL, def.Type)
+	assert.Len(t, def.URITemplates, 2)
+	assert.Equal(t, "hero", def.URITemplates[0].URITemplate)
+	assert.Equal(t, GraphQLQuery, def.URITemplates[0].HTTPVerb)
+	assert.Equal(t, "like", def.URITemplates[1].URITemplate)
+	assert.Equal(t, []string{GraphQLMutation}, def.URITemplates[1].HTTPVerbs)
+	assert.Equal(t, []string{"Unlimited"}, def.URITemplates[1].ThrottlingTiers)
+}
@@ -21,6 +21,9 @@ package v2
 import (
 	"encoding/json"
 	"fmt"
+	"path"
+	"sort"
+	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
@@ -138,17 +141,166 @@ func oai3GetHttpVerbs(item *openapi3.PathItem) (verbs []string) {
 	return
 }
 
-// generateFieldsFromSwagger3 using swagger
-func OpenAPI3Populate(def *APIDefinition, swagger *openapi3.Swagger) {
+// oai3ServerURL returns the URL of server with its variables replaced by their default values
+func oai3ServerURL(server *openapi3.Server) string {
+	u := server.URL
+	for name, variable := range server.Variables {
+		if variable != nil && variable.Default != nil {
+			u = strings.ReplaceAll(u, "{"+name+"}", fmt.Sprint(variable.Default))
+		}
+	}
+	return u
+}
+
+// oai3ServerEndpoints maps servers to production and sandbox endpoints. The first server with sandbox in its
+// description is used as the sandbox endpoint and the first of the other servers as the production endpoint.
+// Relative server URLs are skipped since they can not be used as endpoints
+func oai3ServerEndpoints(servers openapi3.Servers) (*Endpoints, *Endpoints) {
+	prodEp, sandboxEp := &Endpoints{}, &Endpoints{}
+	for _, server := range servers {
+		if server == nil {
+			continue
+		}
+		u := oai3ServerURL(server)
+		if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+			continue
+		}
+		if strings.Contains(strings.ToLower(server.Description), "sandbox") {
+			if len(sandboxEp.Urls) == 0 {
+				sandboxEp.Urls = []string{u}
+			}
+		} else if len(prodEp.Urls) == 0 {
+			prodEp.Urls = []string{u}
+		}
+	}
+	return prodEp, sandboxEp
+}
+
+// oai3OAuth2Scopes returns descriptions of the scopes of each OAuth2 security scheme in components by the name of
+// the security scheme
+func oai3OAuth2Scopes(components openapi3.Components) map[string]map[string]string {
+	schemes := make(map[string]map[string]string)
+	for name, ref := range components.SecuritySchemes {
+		if ref == nil || ref.Value == nil || ref.Value.Type != "oauth2" || ref.Value.Flows == nil {
+			continue
+		}
+		scopes := make(map[string]string)
+		flows := ref.Value.Flows
+		for _, flow := range []*openapi3.OAuthFlow{flows.Implicit, flows.Password, flows.ClientCredentials,
+			flows.AuthorizationCode} {
+			if flow == nil {
+				continue
+			}
+			for scope, description := range flow.Scopes {
+				scopes[scope] = description
+			}
+		}
+		schemes[name] = scopes
+	}
+	return schemes
+}
+
+// oai3OperationScope returns the first OAuth2 scope required by operation, or by the document when the operation
+// does not define security. Returns nil if no OAuth2 scope is required
+func oai3OperationScope(operation *openapi3.Operation, security openapi3.SecurityRequirements,
+	schemes map[string]map[string]string) *Scopes {
+	if operation != nil && operation.Security != nil {
+		security = *operation.Security
+	}
+	for _, requirement := range security {
+		names := make([]string, 0, len(requirement))
+		for name := range requirement {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			scopes, ok := schemes[name]
+			if !ok || len(requirement[name]) == 0 {
+				continue
+			}
+			scope := requirement[name][0]
+			return &Scopes{Key: scope, Name: scope, Description: scopes[scope]}
+		}
+	}
+	return nil
+}
+
+// OpenAPI3Populate populates def using the OpenAPI 3 document swagger
+func OpenAPI3Populate(def *APIDefinition, swagger *openapi3.Swagger) error {
 	def.ID.APIName = utils.ToPascalCase(swagger.Info.Title)
 	def.ID.Version = swagger.Info.Version
 	def.Description = swagger.Info.Description
 	def.Context = fmt.Sprintf("/%s/%s", def.ID.APIName, def.ID.Version)
 	def.ContextTemplate = fmt.Sprintf("/%s/{version}", def.ID.APIName)
+	if tags := oai3Tags(swagger.Extensions); tags != nil {
+		def.Tags = tags
+	}
 
-	// TODO implement xwso2 vendor extensions, since swagger 2.0 parser works out of the box this was not implemented now
+	// override basepath if wso2 extension provided
+	basepath, ok, err := oai3WSO2Basepath(swagger.Extensions)
+	if err != nil {
+		return err
+	}
+	if ok {
+		if !strings.Contains(basepath, "{version}") {
+			def.Context = path.Clean(basepath + "/" + def.ID.Version)
+			def.ContextTemplate = path.Clean(basepath + "/{version}")
+			def.IsDefaultVersion = true
+		} else {
+			def.ContextTemplate = path.Clean(basepath)
+			def.Context = path.Clean(strings.ReplaceAll(basepath, "{version}", def.ID.Version))
+		}
+	}
+
+	cors, _, err := oai3XWSO2Cors(swagger.Extensions)
+	if err != nil {
+		return err
+	}
+	if cors != nil {
+		def.CorsConfiguration = cors
+	}
+
+	prodEp, _, err := oai3XWSO2ProductionEndpoints(swagger.Extensions)
+	if err != nil {
+		return err
+	}
+	sandboxEp, _, err := oai3XWso2SandboxEndpoints(swagger.Extensions)
+	if err != nil {
+		return err
+	}
+	if prodEp != nil || sandboxEp != nil {
+		if prodEp == nil {
+			prodEp = &Endpoints{}
+		}
+		if sandboxEp == nil {
+			sandboxEp = &Endpoints{}
+		}
+		ep, err := BuildAPIMEndpoints(prodEp, sandboxEp)
+		if err != nil {
+			return err
+		}
+		def.EndpointConfig = &ep
+	} else if prodEp, sandboxEp := oai3ServerEndpoints(swagger.Servers); len(prodEp.Urls) > 0 ||
+		len(sandboxEp.Urls) > 0 {
+		// use servers as endpoints when vendor extensions are not provided
+		ep, err := BuildAPIMEndpoints(prodEp, sandboxEp)
+		if err != nil {
+			return err
+		}
+		def.EndpointConfig = &ep
+	}
+
+	uris := make([]string, 0, len(swagger.Paths))
+	for uri := range swagger.Paths {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+
+	schemes := oai3OAuth2Scopes(swagger.Components)
+	apiScopes := make(map[string]*Scopes)
 	var uriTemplates []URITemplates
-	for uri, info := range swagger.Paths {
+	for _, uri := range uris {
+		info := swagger.Paths[uri]
 		uriTemplate := URITemplates{}
 		uriTemplate.URITemplate = uri
 		verbs := oai3GetHttpVerbs(info)
@@ -167,7 +319,27 @@ func OpenAPI3Populate(def *APIDefinition, swagger *openapi3.Swagger) {
 		uriTemplate.ThrottlingTier = "Unlimited"
 		uriTemplate.ThrottlingTiers = throttlingTiers
 		uriTemplate.Scopes = make([]*Scopes, len(verbs))
+		for i, verb := range verbs {
+			scope := oai3OperationScope(info.GetOperation(verb), swagger.Security, schemes)
+			uriTemplate.Scopes[i] = scope
+			if scope != nil {
+				apiScopes[scope.Key] = scope
+			}
+		}
 		uriTemplates = append(uriTemplates, uriTemplate)
 	}
 	def.URITemplates = uriTemplates
+
+	if len(apiScopes) > 0 {
+		keys := make([]string, 0, len(apiScopes))
+		for key := range apiScopes {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		def.Scopes = make([]interface{}, len(keys))
+		for i, key := range keys {
+			def.Scopes[i] = apiScopes[key]
+		}
+	}
+	return nil
 }
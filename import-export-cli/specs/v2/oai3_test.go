@@ -71,3 +71,53 @@ func Test_oai3WSO2Cors(t *testing.T) {
 	assert.ElementsMatch(t, []string{"GET", "PUT", "POST"}, cors.AccessControlAllowMethods, "should have same elements for access control")
 	assert.ElementsMatch(t, []string{"test.com", "example.com"}, cors.AccessControlAllowOrigins, "should have same elements for origins")
 }
+
+func TestOpenAPI3Populate(t *testing.T) {
+	sw, err := openapi3.NewSwaggerLoader().LoadSwaggerFromFile("testdata/petstore_basic.yaml")
+	assert.Nil(t, err, "err should be nil")
+	def := &APIDefinition{}
+	err = OpenAPI3Populate(def, sw)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "SwaggerPetstoreNew", def.ID.APIName, "should derive name from title")
+	assert.Equal(t, "1.0.0", def.ID.Version, "should derive version from info")
+	assert.Equal(t, "/petstore/v1/1.0.0", def.Context, "should use x-wso2-basePath as context")
+	assert.True(t, def.IsDefaultVersion, "basepath without version should be a default version")
+	assert.NotNil(t, def.EndpointConfig, "should build endpoint config from vendor extensions")
+	assert.Contains(t, *def.EndpointConfig, "https://petstore.swagger.io/v2/1", "should contain endpoint urls")
+	assert.True(t, def.CorsConfiguration.CorsConfigurationEnabled, "should enable CORS")
+	assert.ElementsMatch(t, []string{"pet", "user", "store"}, def.Tags, "should have same tags")
+}
+
+func TestOpenAPI3PopulateWithServersAndScopes(t *testing.T) {
+	sw, err := openapi3.NewSwaggerLoader().LoadSwaggerFromFile("testdata/petstore_oauth2.yaml")
+	assert.Nil(t, err, "err should be nil")
+	def := &APIDefinition{}
+	err = OpenAPI3Populate(def, sw)
+	assert.Nil(t, err, "err should be nil")
+
+	assert.NotNil(t, def.EndpointConfig, "should build endpoint config from servers")
+	assert.Contains(t, *def.EndpointConfig, "https://eu.petstore.example.com/v2",
+		"should use server variable defaults")
+	assert.Contains(t, *def.EndpointConfig, `"sandbox_endpoints":{"url":"https://sandbox.petstore.example.com/v2"`,
+		"should use sandbox server as sandbox endpoint")
+	assert.NotContains(t, *def.EndpointConfig, "/relative", "should skip relative servers")
+
+	assert.Equal(t, 2, len(def.URITemplates))
+	pets := def.URITemplates[0]
+	assert.Equal(t, "/pets", pets.URITemplate)
+	assert.Equal(t, []string{"GET", "POST"}, pets.HTTPVerbs, "should keep requestBody operations")
+	assert.Equal(t, "read:pets", pets.Scopes[0].Key, "should use document security")
+	assert.Equal(t, "write:pets", pets.Scopes[1].Key, "should use operation security")
+	assert.Equal(t, "modify pets in your account", pets.Scopes[1].Description)
+	assert.Nil(t, def.URITemplates[1].Scopes[0], "empty operation security should not have a scope")
+	assert.Equal(t, 2, len(def.Scopes), "should list scopes of the API")
+}
+
+func TestOpenAPI3PopulateExtensionsOverrideServers(t *testing.T) {
+	sw, err := openapi3.NewSwaggerLoader().LoadSwaggerFromFile("testdata/petstore_basic.yaml")
+	assert.Nil(t, err, "err should be nil")
+	def := &APIDefinition{}
+	err = OpenAPI3Populate(def, sw)
+	assert.Nil(t, err, "err should be nil")
+	assert.NotContains(t, *def.EndpointConfig, "http://petstore.swagger.io/v2", "should not use servers")
+}
@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 
 	"github.com/Jeffail/gabs"
 	"github.com/go-openapi/loads"
@@ -108,76 +109,82 @@ func BuildAPIMEndpoints(production, sandbox *Endpoints) (string, error) {
 		endpoint := buildHttpEndpoint(production, sandbox)
 		return endpoint, nil
 	case EpLoadbalance:
-		endpoint := buildLoadBalancedEndpoints(production, sandbox)
+		endpoint := BuildLoadBalancedEndpoints(urlEndpoints(production.Urls), urlEndpoints(sandbox.Urls),
+			EndpointOptions{})
 		return endpoint, nil
 	case EpFailover:
-		endpoint := buildFailOver(production, sandbox)
+		endpoint := BuildFailOver(urlEndpoints(production.Urls), urlEndpoints(sandbox.Urls), EndpointOptions{})
 		return endpoint, nil
 	default:
 		return "", fmt.Errorf("unknown endpoint type")
 	}
 }
 
-func buildFailOver(production *Endpoints, sandbox *Endpoints) string {
-	jsonObj, _ := gabs.ParseJSON([]byte(`
-					{
-						"endpoint_type": "failover",
-		    			"algoCombo": "org.apache.synapse.endpoints.algorithms.RoundRobin",
-		    			"algoClassName": "",
-						"sessionManagement": "",
-		    			"sessionTimeOut": "",
-		    			"failOver": "True"
-					}
-				`))
-	if len(production.Urls) > 0 {
+// DefaultLoadBalanceAlgorithm is used when load balanced endpoints do not specify an algorithm
+const DefaultLoadBalanceAlgorithm = "org.apache.synapse.endpoints.algorithms.RoundRobin"
+
+// EndpointOptions are the options of load balanced and failover endpoints. Empty options use the defaults
+type EndpointOptions struct {
+	// Algorithm used to balance the load, round robin by default
+	Algorithm         string
+	SessionManagement string
+	SessionTimeOut    string
+}
+
+// urlEndpoints returns an endpoint for each url
+func urlEndpoints(urls []string) []params.Endpoint {
+	endpoints := make([]params.Endpoint, len(urls))
+	for i := range urls {
+		endpoints[i] = params.Endpoint{Url: &urls[i]}
+	}
+	return endpoints
+}
+
+// BuildFailOver builds a failover endpointConfig. The first production and sandbox endpoints are the primary
+// endpoints and the rest are their failovers
+func BuildFailOver(production, sandbox []params.Endpoint, options EndpointOptions) string {
+	jsonObj := gabs.New()
+	_, _ = jsonObj.Set(EpFailover, "endpoint_type")
+	_, _ = jsonObj.Set(DefaultLoadBalanceAlgorithm, "algoCombo")
+	_, _ = jsonObj.Set("", "algoClassName")
+	_, _ = jsonObj.Set("True", "failOver")
+	_, _ = jsonObj.Set(options.SessionManagement, "sessionManagement")
+	_, _ = jsonObj.Set(options.SessionTimeOut, "sessionTimeOut")
+	if len(production) > 0 {
 		buildFailOverUrls(jsonObj, production, "production")
 	}
-	if len(sandbox.Urls) > 0 {
+	if len(sandbox) > 0 {
 		buildFailOverUrls(jsonObj, sandbox, "sandbox")
 	}
 	return jsonObj.String()
 }
 
-func buildFailOverUrls(jsonObj *gabs.Container, endpoints *Endpoints, eptype string) {
-	_, _ = jsonObj.Set(params.Endpoint{Url: &endpoints.Urls[0]}, fmt.Sprintf("%s_endpoints", eptype))
-	rest := endpoints.Urls[1:]
-	if len(rest) > 0 {
-		fo := make([]params.Endpoint, len(rest))
-		for i := 0; i < len(fo); i++ {
-			fo[i] = params.Endpoint{Url: &rest[i]}
-		}
-		if len(fo) > 0 {
-			_, _ = jsonObj.Set(fo, fmt.Sprintf("%s_failovers", eptype))
-		}
+func buildFailOverUrls(jsonObj *gabs.Container, endpoints []params.Endpoint, eptype string) {
+	_, _ = jsonObj.Set(endpoints[0], fmt.Sprintf("%s_endpoints", eptype))
+	if rest := endpoints[1:]; len(rest) > 0 {
+		_, _ = jsonObj.Set(rest, fmt.Sprintf("%s_failovers", eptype))
 	}
 }
 
-func buildLoadBalancedEndpoints(production *Endpoints, sandbox *Endpoints) string {
-	jsonObj, _ := gabs.ParseJSON([]byte(`
-		{
-			"endpoint_type": "load_balance",
-		    "algoCombo": "org.apache.synapse.endpoints.algorithms.RoundRobin",
-		    "algoClassName": "org.apache.synapse.endpoints.algorithms.RoundRobin",
-		    "sessionManagement": "",
-		    "sessionTimeOut": ""
-		}
-	`))
-	prodEps := make([]params.Endpoint, len(production.Urls))
-	for i := 0; i < len(prodEps); i++ {
-		prodEps[i] = params.Endpoint{Url: &production.Urls[i]}
+// BuildLoadBalancedEndpoints builds a load_balance endpointConfig balancing the load among the production endpoints
+// and among the sandbox endpoints
+func BuildLoadBalancedEndpoints(production, sandbox []params.Endpoint, options EndpointOptions) string {
+	algorithm := options.Algorithm
+	if algorithm == "" {
+		algorithm = DefaultLoadBalanceAlgorithm
 	}
-	if len(prodEps) > 0 {
-		_, _ = jsonObj.Set(prodEps, "production_endpoints")
-	}
-
-	sandboxEps := make([]params.Endpoint, len(sandbox.Urls))
-	for i := 0; i < len(sandboxEps); i++ {
-		sandboxEps[i] = params.Endpoint{Url: &sandbox.Urls[i]}
+	jsonObj := gabs.New()
+	_, _ = jsonObj.Set(EpLoadbalance, "endpoint_type")
+	_, _ = jsonObj.Set(algorithm, "algoCombo")
+	_, _ = jsonObj.Set(algorithm, "algoClassName")
+	_, _ = jsonObj.Set(options.SessionManagement, "sessionManagement")
+	_, _ = jsonObj.Set(options.SessionTimeOut, "sessionTimeOut")
+	if len(production) > 0 {
+		_, _ = jsonObj.Set(production, "production_endpoints")
 	}
-	if len(sandboxEps) > 0 {
-		_, _ = jsonObj.Set(sandboxEps, "sandbox_endpoints")
+	if len(sandbox) > 0 {
+		_, _ = jsonObj.Set(sandbox, "sandbox_endpoints")
 	}
-
 	return jsonObj.String()
 }
 
@@ -199,7 +206,7 @@ func buildHttpEndpoint(production *Endpoints, sandbox *Endpoints) string {
 
 // generateFieldsFromSwagger3 using swagger
 func Swagger2Populate(def *APIDefinition, document *loads.Document) error {
-	def.ID.APIName = document.Spec().Info.Title
+	def.ID.APIName = utils.ToPascalCase(document.Spec().Info.Title)
 	def.ID.Version = document.Spec().Info.Version
 	def.ID.ProviderName = "admin"
 	def.Description = document.Spec().Info.Description
@@ -67,7 +67,7 @@ func TestSwagger2Populate(t *testing.T) {
 	err = Swagger2Populate(&def, doc)
 	assert.Nil(t, err, "err should be nil")
 
-	assert.Equal(t, "Swagger Petstore", def.ID.APIName, "Should return correct api name")
+	assert.Equal(t, "SwaggerPetstore", def.ID.APIName, "Should return correct api name")
 	assert.Equal(t, "/petstore/v1/1.0.0", def.Context)
 }
 
@@ -0,0 +1,444 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	APITypeSOAP       = "SOAP"
+	APITypeSOAPToREST = "SOAPTOREST"
+)
+
+// WSDLDefinitions represents a WSDL 1.1 document. Definitions of imported documents are merged into it by Merge
+type WSDLDefinitions struct {
+	XMLName         xml.Name       `xml:"definitions"`
+	Name            string         `xml:"name,attr"`
+	TargetNamespace string         `xml:"targetNamespace,attr"`
+	Imports         []WSDLImport   `xml:"import"`
+	Schemas         []XSDSchema    `xml:"types>schema"`
+	Messages        []WSDLMessage  `xml:"message"`
+	PortTypes       []WSDLPortType `xml:"portType"`
+	Bindings        []WSDLBinding  `xml:"binding"`
+	Services        []WSDLService  `xml:"service"`
+}
+
+type WSDLImport struct {
+	Namespace string `xml:"namespace,attr"`
+	Location  string `xml:"location,attr"`
+}
+
+// XSDSchema represents a XML schema, either inside the types of a WSDL or imported from a XSD
+type XSDSchema struct {
+	TargetNamespace    string           `xml:"targetNamespace,attr"`
+	ElementFormDefault string           `xml:"elementFormDefault,attr"`
+	Imports            []XSDImport      `xml:"import"`
+	Includes           []XSDImport      `xml:"include"`
+	Elements           []XSDElement     `xml:"element"`
+	ComplexTypes       []XSDComplexType `xml:"complexType"`
+}
+
+type XSDImport struct {
+	Namespace      string `xml:"namespace,attr"`
+	SchemaLocation string `xml:"schemaLocation,attr"`
+}
+
+type XSDElement struct {
+	Name        string          `xml:"name,attr"`
+	Type        string          `xml:"type,attr"`
+	Ref         string          `xml:"ref,attr"`
+	ComplexType *XSDComplexType `xml:"complexType"`
+}
+
+type XSDComplexType struct {
+	Name     string       `xml:"name,attr"`
+	Sequence []XSDElement `xml:"sequence>element"`
+	All      []XSDElement `xml:"all>element"`
+}
+
+type WSDLMessage struct {
+	Name  string     `xml:"name,attr"`
+	Parts []WSDLPart `xml:"part"`
+}
+
+type WSDLPart struct {
+	Name    string `xml:"name,attr"`
+	Element string `xml:"element,attr"`
+	Type    string `xml:"type,attr"`
+}
+
+type WSDLPortType struct {
+	Name       string          `xml:"name,attr"`
+	Operations []WSDLOperation `xml:"operation"`
+}
+
+type WSDLOperation struct {
+	Name          string                `xml:"name,attr"`
+	Documentation string                `xml:"documentation"`
+	Input         *WSDLOperationMessage `xml:"input"`
+	Output        *WSDLOperationMessage `xml:"output"`
+}
+
+type WSDLOperationMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+type WSDLBinding struct {
+	Name        string                 `xml:"name,attr"`
+	Type        string                 `xml:"type,attr"`
+	SOAPBinding *SOAPBinding           `xml:"http://schemas.xmlsoap.org/wsdl/soap/ binding"`
+	SOAP12      *SOAPBinding           `xml:"http://schemas.xmlsoap.org/wsdl/soap12/ binding"`
+	Operations  []WSDLBindingOperation `xml:"operation"`
+}
+
+type SOAPBinding struct {
+	Style     string `xml:"style,attr"`
+	Transport string `xml:"transport,attr"`
+}
+
+type WSDLBindingOperation struct {
+	Name            string         `xml:"name,attr"`
+	SOAPOperation   *SOAPOperation `xml:"http://schemas.xmlsoap.org/wsdl/soap/ operation"`
+	SOAP12Operation *SOAPOperation `xml:"http://schemas.xmlsoap.org/wsdl/soap12/ operation"`
+	InputBody       *SOAPBody      `xml:"input>body"`
+}
+
+type SOAPOperation struct {
+	SOAPAction string `xml:"soapAction,attr"`
+	Style      string `xml:"style,attr"`
+}
+
+type SOAPBody struct {
+	Namespace string `xml:"namespace,attr"`
+}
+
+type WSDLService struct {
+	Name          string     `xml:"name,attr"`
+	Documentation string     `xml:"documentation"`
+	Ports         []WSDLPort `xml:"port"`
+}
+
+type WSDLPort struct {
+	Name          string       `xml:"name,attr"`
+	Binding       string       `xml:"binding,attr"`
+	SOAPAddress   *SOAPAddress `xml:"http://schemas.xmlsoap.org/wsdl/soap/ address"`
+	SOAP12Address *SOAPAddress `xml:"http://schemas.xmlsoap.org/wsdl/soap12/ address"`
+}
+
+type SOAPAddress struct {
+	Location string `xml:"location,attr"`
+}
+
+// SOAPService is the SOAP port of a WSDL service used as the endpoint of the API
+type SOAPService struct {
+	Name    string
+	Address string
+	SOAP12  bool
+	Binding *WSDLBinding
+}
+
+// SOAPOperationInfo describes an operation of the SOAP service with the request element to be built for it
+type SOAPOperationInfo struct {
+	Name          string
+	Documentation string
+	SOAPAction    string
+	SOAP12        bool
+	// Element is the name of the request element in the SOAP body and Namespace its namespace
+	Element   string
+	Namespace string
+	// Fields are the child elements of the request element. QualifiedFields is set when they are in Namespace
+	Fields          []string
+	QualifiedFields bool
+}
+
+// ParseWSDL parses a WSDL 1.1 document
+func ParseWSDL(content []byte) (*WSDLDefinitions, error) {
+	var definitions WSDLDefinitions
+	if err := xml.Unmarshal(content, &definitions); err != nil {
+		return nil, err
+	}
+	if definitions.XMLName.Local != "definitions" {
+		return nil, fmt.Errorf("not a WSDL 1.1 document")
+	}
+	return &definitions, nil
+}
+
+// ParseXSD parses a XML schema document
+func ParseXSD(content []byte) (*XSDSchema, error) {
+	var schema struct {
+		XMLName xml.Name
+		XSDSchema
+	}
+	if err := xml.Unmarshal(content, &schema); err != nil {
+		return nil, err
+	}
+	if schema.XMLName.Local != "schema" {
+		return nil, fmt.Errorf("not a XML schema document")
+	}
+	return &schema.XSDSchema, nil
+}
+
+// Merge adds the definitions of an imported WSDL document
+func (w *WSDLDefinitions) Merge(imported *WSDLDefinitions) {
+	w.Schemas = append(w.Schemas, imported.Schemas...)
+	w.Messages = append(w.Messages, imported.Messages...)
+	w.PortTypes = append(w.PortTypes, imported.PortTypes...)
+	w.Bindings = append(w.Bindings, imported.Bindings...)
+	w.Services = append(w.Services, imported.Services...)
+}
+
+// localName removes the namespace prefix of a qualified name
+func localName(qname string) string {
+	if i := strings.LastIndex(qname, ":"); i >= 0 {
+		return qname[i+1:]
+	}
+	return qname
+}
+
+func (w *WSDLDefinitions) binding(name string) *WSDLBinding {
+	for i := range w.Bindings {
+		if w.Bindings[i].Name == localName(name) {
+			return &w.Bindings[i]
+		}
+	}
+	return nil
+}
+
+func (w *WSDLDefinitions) portType(name string) *WSDLPortType {
+	for i := range w.PortTypes {
+		if w.PortTypes[i].Name == localName(name) {
+			return &w.PortTypes[i]
+		}
+	}
+	return nil
+}
+
+func (w *WSDLDefinitions) message(name string) *WSDLMessage {
+	for i := range w.Messages {
+		if w.Messages[i].Name == localName(name) {
+			return &w.Messages[i]
+		}
+	}
+	return nil
+}
+
+// element finds a global element of the schemas with the schema defining it
+func (w *WSDLDefinitions) element(name string) (*XSDElement, *XSDSchema) {
+	for i := range w.Schemas {
+		for j := range w.Schemas[i].Elements {
+			if w.Schemas[i].Elements[j].Name == localName(name) {
+				return &w.Schemas[i].Elements[j], &w.Schemas[i]
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (w *WSDLDefinitions) complexType(name string) *XSDComplexType {
+	for i := range w.Schemas {
+		for j := range w.Schemas[i].ComplexTypes {
+			if w.Schemas[i].ComplexTypes[j].Name == localName(name) {
+				return &w.Schemas[i].ComplexTypes[j]
+			}
+		}
+	}
+	return nil
+}
+
+// Service returns the first SOAP port of the services. SOAP 1.1 ports are preferred over SOAP 1.2 ports
+func (w *WSDLDefinitions) Service() (*SOAPService, error) {
+	var soap12 *SOAPService
+	for _, service := range w.Services {
+		for _, port := range service.Ports {
+			if port.SOAPAddress != nil {
+				return &SOAPService{Name: service.Name, Address: port.SOAPAddress.Location,
+					Binding: w.binding(port.Binding)}, nil
+			}
+			if port.SOAP12Address != nil && soap12 == nil {
+				soap12 = &SOAPService{Name: service.Name, Address: port.SOAP12Address.Location, SOAP12: true,
+					Binding: w.binding(port.Binding)}
+			}
+		}
+	}
+	if soap12 != nil {
+		return soap12, nil
+	}
+	return nil, fmt.Errorf("no SOAP service port found in the WSDL")
+}
+
+// complexTypeFields returns the child element names of a complex type
+func complexTypeFields(complexType *XSDComplexType) []string {
+	var fields []string
+	for _, e := range append(complexType.Sequence, complexType.All...) {
+		if e.Name != "" {
+			fields = append(fields, e.Name)
+		} else if e.Ref != "" {
+			fields = append(fields, localName(e.Ref))
+		}
+	}
+	return fields
+}
+
+// Operations returns the operations of the binding of service in the order of the port type
+func (w *WSDLDefinitions) Operations(service *SOAPService) ([]SOAPOperationInfo, error) {
+	if service.Binding == nil {
+		return nil, fmt.Errorf("binding of service %s not found in the WSDL", service.Name)
+	}
+	portType := w.portType(service.Binding.Type)
+	if portType == nil {
+		return nil, fmt.Errorf("port type %s not found in the WSDL", service.Binding.Type)
+	}
+	style := "document"
+	if b := service.Binding.SOAPBinding; b != nil && b.Style != "" {
+		style = b.Style
+	} else if b := service.Binding.SOAP12; b != nil && b.Style != "" {
+		style = b.Style
+	}
+
+	var operations []SOAPOperationInfo
+	for _, operation := range portType.Operations {
+		info := SOAPOperationInfo{
+			Name:          operation.Name,
+			Documentation: strings.TrimSpace(operation.Documentation),
+			SOAP12:        service.SOAP12,
+			Namespace:     w.TargetNamespace,
+		}
+		opStyle := style
+		for _, bindingOperation := range service.Binding.Operations {
+			if bindingOperation.Name != operation.Name {
+				continue
+			}
+			soapOperation := bindingOperation.SOAPOperation
+			if bindingOperation.SOAP12Operation != nil && (service.SOAP12 || soapOperation == nil) {
+				soapOperation = bindingOperation.SOAP12Operation
+			}
+			if soapOperation != nil {
+				info.SOAPAction = soapOperation.SOAPAction
+				if soapOperation.Style != "" {
+					opStyle = soapOperation.Style
+				}
+			}
+			if bindingOperation.InputBody != nil && bindingOperation.InputBody.Namespace != "" {
+				info.Namespace = bindingOperation.InputBody.Namespace
+			}
+		}
+
+		var message *WSDLMessage
+		if operation.Input != nil {
+			message = w.message(operation.Input.Message)
+		}
+		if opStyle == "rpc" {
+			// the request element is named after the operation and contains the parts
+			info.Element = operation.Name
+			if message != nil {
+				for _, part := range message.Parts {
+					info.Fields = append(info.Fields, part.Name)
+				}
+			}
+		} else if message != nil && len(message.Parts) > 0 && message.Parts[0].Element != "" {
+			info.Element = localName(message.Parts[0].Element)
+			if element, schema := w.element(message.Parts[0].Element); element != nil {
+				info.Namespace = schema.TargetNamespace
+				info.QualifiedFields = schema.ElementFormDefault == "qualified"
+				complexType := element.ComplexType
+				if complexType == nil && element.Type != "" {
+					complexType = w.complexType(element.Type)
+				}
+				if complexType != nil {
+					info.Fields = complexTypeFields(complexType)
+				}
+			}
+		} else {
+			info.Element = operation.Name
+		}
+		operations = append(operations, info)
+	}
+	return operations, nil
+}
+
+// soapURITemplate returns a POST resource for uri
+func soapURITemplate(uri string) URITemplates {
+	return URITemplates{
+		URITemplate:     uri,
+		HTTPVerb:        "POST",
+		HTTPVerbs:       []string{"POST"},
+		AuthType:        "Any",
+		AuthTypes:       []string{"Any"},
+		ThrottlingTier:  "Unlimited",
+		ThrottlingTiers: []string{"Unlimited"},
+	}
+}
+
+// WSDLPopulate populates def using the WSDL definitions. The address of the SOAP service is used as the endpoint.
+// SOAP APIs pass requests through a single resource while SOAP to REST APIs get a POST resource per operation
+func WSDLPopulate(def *APIDefinition, wsdl *WSDLDefinitions, soapToRest bool) error {
+	service, err := wsdl.Service()
+	if err != nil {
+		return err
+	}
+	name := service.Name
+	if name == "" {
+		name = wsdl.Name
+	}
+	def.ID.APIName = utils.ToPascalCase(name)
+	def.Context = fmt.Sprintf("/%s/%s", def.ID.APIName, def.ID.Version)
+	def.ContextTemplate = fmt.Sprintf("/%s/{version}", def.ID.APIName)
+	for _, s := range wsdl.Services {
+		if s.Name == service.Name && strings.TrimSpace(s.Documentation) != "" {
+			def.Description = strings.TrimSpace(s.Documentation)
+		}
+	}
+
+	endpoint := gabs.New()
+	_, _ = endpoint.Set(EpAddress, "endpoint_type")
+	_, _ = endpoint.Set(service.Address, "production_endpoints", "url")
+	ep := endpoint.String()
+	def.EndpointConfig = &ep
+	def.ProductionUrl = ""
+	def.SandboxUrl = ""
+
+	if !soapToRest {
+		def.Type = APITypeSOAP
+		def.URITemplates = []URITemplates{soapURITemplate("/*")}
+		return nil
+	}
+
+	def.Type = APITypeSOAPToREST
+	operations, err := wsdl.Operations(service)
+	if err != nil {
+		return err
+	}
+	uris := make([]string, len(operations))
+	for i, operation := range operations {
+		uris[i] = "/" + operation.Name
+	}
+	sort.Strings(uris)
+	def.URITemplates = make([]URITemplates, len(uris))
+	for i, uri := range uris {
+		def.URITemplates[i] = soapURITemplate(uri)
+	}
+	return nil
+}
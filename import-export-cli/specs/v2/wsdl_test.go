@@ -0,0 +1,109 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func loadTestWSDL(t *testing.T, file string) *WSDLDefinitions {
+	content, err := ioutil.ReadFile(file)
+	assert.Nil(t, err, "err should be nil")
+	wsdl, err := ParseWSDL(content)
+	assert.Nil(t, err, "err should be nil")
+	return wsdl
+}
+
+func TestWSDLServicePrefersSOAP11(t *testing.T) {
+	wsdl := loadTestWSDL(t, "testdata/wsdl/phoneverify.wsdl")
+	service, err := wsdl.Service()
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "PhoneVerify", service.Name)
+	assert.Equal(t, "http://ws.cdyne.com/phoneverify/phoneverify.asmx", service.Address)
+	assert.False(t, service.SOAP12, "SOAP 1.1 port should be used")
+	assert.Equal(t, "PhoneVerifySoap", service.Binding.Name)
+}
+
+func TestWSDLDocumentLiteralOperations(t *testing.T) {
+	wsdl := loadTestWSDL(t, "testdata/wsdl/phoneverify.wsdl")
+	service, err := wsdl.Service()
+	assert.Nil(t, err, "err should be nil")
+	operations, err := wsdl.Operations(service)
+	assert.Nil(t, err, "err should be nil")
+	assert.Len(t, operations, 2)
+
+	op := operations[0]
+	assert.Equal(t, "CheckPhoneNumber", op.Name)
+	assert.Equal(t, "Validates a phone number", op.Documentation)
+	assert.Equal(t, "http://ws.cdyne.com/PhoneVerify/query/CheckPhoneNumber", op.SOAPAction)
+	assert.Equal(t, "CheckPhoneNumber", op.Element)
+	assert.Equal(t, "http://ws.cdyne.com/PhoneVerify/query", op.Namespace)
+	assert.Equal(t, []string{"PhoneNumber", "LicenseKey"}, op.Fields)
+	assert.True(t, op.QualifiedFields, "fields should be qualified")
+
+	// the element of the second operation is defined in a schema which is not loaded
+	assert.Equal(t, "CheckPhoneNumbers", operations[1].Element)
+	assert.Empty(t, operations[1].Fields)
+}
+
+func TestWSDLRPCOperations(t *testing.T) {
+	wsdl := loadTestWSDL(t, "testdata/wsdl/calculator_rpc.wsdl")
+	service, err := wsdl.Service()
+	assert.Nil(t, err, "err should be nil")
+	operations, err := wsdl.Operations(service)
+	assert.Nil(t, err, "err should be nil")
+	assert.Len(t, operations, 1)
+	assert.Equal(t, "add", operations[0].Element)
+	assert.Equal(t, "urn:calculator:ops", operations[0].Namespace)
+	assert.Equal(t, "urn:calculator#add", operations[0].SOAPAction)
+	assert.Equal(t, []string{"a", "b"}, operations[0].Fields)
+	assert.False(t, operations[0].QualifiedFields, "parts should not be qualified")
+}
+
+func TestWSDLPopulate(t *testing.T) {
+	wsdl := loadTestWSDL(t, "testdata/wsdl/calculator_rpc.wsdl")
+	def := &APIDefinition{ID: ID{Version: "1.0.0"}, ProductionUrl: "http://localhost:8080"}
+	err := WSDLPopulate(def, wsdl, false)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "CalculatorService", def.ID.APIName)
+	assert.Equal(t, "/CalculatorService/1.0.0", def.Context)
+	assert.Equal(t, APITypeSOAP, def.Type)
+	assert.Empty(t, def.ProductionUrl)
+	assert.JSONEq(t, `{"endpoint_type":"address","production_endpoints":{"url":"https://calculator.example.com/soap"}}`,
+		*def.EndpointConfig)
+	assert.Len(t, def.URITemplates, 1)
+	assert.Equal(t, "/*", def.URITemplates[0].URITemplate)
+	assert.Equal(t, "POST", def.URITemplates[0].HTTPVerb)
+
+	err = WSDLPopulate(def, wsdl, true)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, APITypeSOAPToREST, def.Type)
+	assert.Len(t, def.URITemplates, 1)
+	assert.Equal(t, "/add", def.URITemplates[0].URITemplate)
+}
+
+func TestParseWSDLRejectsOtherDocuments(t *testing.T) {
+	_, err := ParseWSDL([]byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema"/>`))
+	assert.NotNil(t, err, "err should not be nil")
+	_, err = ParseXSD([]byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:x"/>`))
+	assert.Nil(t, err, "err should be nil")
+}
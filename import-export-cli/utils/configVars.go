@@ -29,6 +29,30 @@ var HttpRequestTimeout = DefaultHttpRequestTimeout
 var Insecure bool
 var ExportDirectory string
 
+// SnapshotRetention is the number of snapshots kept per API. Snapshots are disabled when it is negative
+var SnapshotRetention = DefaultSnapshotRetention
+
+// HttpRetryCount is the number of times a failed request is retried. Retries are disabled when it is negative
+var HttpRetryCount = DefaultHttpRetryCount
+
+// HttpRetryWaitTime is the backoff in milliseconds before the first retry
+var HttpRetryWaitTime = DefaultHttpRetryWaitTime
+
+// HttpRetryMaxWaitTime is the maximum backoff in milliseconds between retries
+var HttpRetryMaxWaitTime = DefaultHttpRetryMaxWaitTime
+
+// HttpRequestsPerSecond limits the requests sent to the API Manager. Requests are not limited when it is zero
+var HttpRequestsPerSecond float64
+
+// CertExpiryWarningDays is the number of days before the expiry of an endpoint certificate to warn about it
+var CertExpiryWarningDays = DefaultCertExpiryWarningDays
+
+// LintBeforeImport lints APIs before they are imported with import-api
+var LintBeforeImport bool
+
+// LintRules are the rule files used to lint APIs before they are imported, in addition to the default rules
+var LintRules []string
+
 // SetConfigVars
 // @param mainConfigFilePath : Path to file where Configuration details are stored
 // @return error
@@ -55,6 +79,38 @@ func SetConfigVars(mainConfigFilePath string) error {
 	ExportDirectory = mainConfig.Config.ExportDirectory
 	Logln(LogPrefixInfo + "Setting ExportDirectory " + mainConfig.Config.ExportDirectory)
 
+	// keep the default when snapshot_retention is not set
+	if mainConfig.Config.SnapshotRetention != 0 {
+		SnapshotRetention = mainConfig.Config.SnapshotRetention
+	}
+	Logln(LogPrefixInfo+"Setting SnapshotRetention to", SnapshotRetention)
+
+	// keep the defaults when the retry settings are not set
+	if mainConfig.Config.HttpRetryCount != 0 {
+		HttpRetryCount = mainConfig.Config.HttpRetryCount
+	}
+	if mainConfig.Config.HttpRetryWaitTime > 0 {
+		HttpRetryWaitTime = mainConfig.Config.HttpRetryWaitTime
+	}
+	if mainConfig.Config.HttpRetryMaxWaitTime > 0 {
+		HttpRetryMaxWaitTime = mainConfig.Config.HttpRetryMaxWaitTime
+	}
+	Logln(LogPrefixInfo+"Setting HttpRetryCount to", HttpRetryCount)
+
+	if mainConfig.Config.HttpRequestsPerSecond < 0 {
+		Logln(LogPrefixWarning + "value of HttpRequestsPerSecond in '" + mainConfigFilePath + "' is less than zero")
+	} else {
+		HttpRequestsPerSecond = mainConfig.Config.HttpRequestsPerSecond
+	}
+
+	// keep the default when cert_expiry_warning_days is not set
+	if mainConfig.Config.CertExpiryWarningDays != 0 {
+		CertExpiryWarningDays = mainConfig.Config.CertExpiryWarningDays
+	}
+
+	LintBeforeImport = mainConfig.Config.LintBeforeImport
+	LintRules = mainConfig.Config.LintRules
+
 	return nil
 }
 
@@ -51,9 +51,20 @@ const ExportedApisDirName = "apis"
 const ExportedApiProductsDirName = "api-products"
 const ExportedAppsDirName = "apps"
 const ExportedMigrationArtifactsDirName = "migration"
+const ExportedBackupsDirName = "backups"
 
 var DefaultExportDirPath = filepath.Join(ConfigDirPath, DefaultExportDirName)
 
+const DefaultSnapshotsDirName = "snapshots"
+const SnapshotApisDirName = "apis"
+
+var DefaultSnapshotsDirPath = filepath.Join(ConfigDirPath, DefaultSnapshotsDirName)
+
+const DefaultTemplatesDirName = "templates"
+const TemplateManifestFileName = "template.yaml"
+
+var DefaultTemplatesDirPath = filepath.Join(ConfigDirPath, DefaultTemplatesDirName)
+
 const defaultApiApplicationImportExportSuffix = "api/am/admin/v1"
 const defaultApiListEndpointSuffix = "api/am/publisher/v1/apis"
 const defaultApiProductListEndpointSuffix = "api/am/publisher/v1/api-products"
@@ -107,6 +118,11 @@ const UrlValidRegex = `^(?:http(s)?:\/\/)?[\w.-]+(?:\.[\w\.-]+)+[\w\-\._~:/?#[\]
 // Other
 const DefaultTokenValidityPeriod = 3600
 const DefaultHttpRequestTimeout = 10000
+const DefaultSnapshotRetention = 5
+const DefaultHttpRetryCount = 3
+const DefaultHttpRetryWaitTime = 500
+const DefaultHttpRetryMaxWaitTime = 30000
+const DefaultCertExpiryWarningDays = 30
 
 // Migration export
 const MaxAPIsToExportOnce = 20
@@ -121,6 +137,11 @@ const DefaultTokenType = "JWT"
 
 var ValidInitialStates = []string{"CREATED", "PUBLISHED"}
 
+// ReservedContexts are the paths served by API Manager itself, which can not be used as or under API contexts
+var ReservedContexts = []string{"/api/am", "/oauth2", "/token", "/revoke", "/authorize", "/userinfo", "/oidc",
+	"/carbon", "/services", "/publisher", "/devportal", "/admin", "/registry", "/commonauth", "/logincontext",
+	"/keymanager-operations"}
+
 var EnvReplaceFilePaths = []string{
 	"Docs" + string(os.PathSeparator) + "docs.yaml",
 	"Docs" + string(os.PathSeparator) + "InlineContents",
@@ -132,7 +153,9 @@ var EnvReplaceFilePaths = []string{
 
 // project param files
 const (
-	ParamFileAPI         = "api_params.yaml"
+	ParamFileAPI        = "api_params.yaml"
+	ParamFileApp        = "app_params.yaml"
+	ParamFileAPIProduct = "api_product_params.yaml"
 )
 
 const PrivateJetModeConst = "privateJet"
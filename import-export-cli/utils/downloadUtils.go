@@ -0,0 +1,171 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-resty/resty"
+)
+
+// PartialDownloadSuffix is appended to the destination while a download is in progress
+const PartialDownloadSuffix = ".part"
+
+// partialDownloadValidatorSuffix is appended to the destination to store the ETag or Last-Modified value of a
+// partial download. A download is only resumed when the server still serves the same content
+const partialDownloadValidatorSuffix = ".part.validator"
+
+// maxDownloadAttempts is the number of times an interrupted download is resumed before giving up
+const maxDownloadAttempts = 3
+
+// maxDownloadErrorBodySize limits the size of the response body read when the server returns an error
+const maxDownloadErrorBodySize = 1 << 20
+
+// DownloadError is returned by DownloadFile when the server does not return 200 OK or 206 Partial Content
+type DownloadError struct {
+	StatusCode int
+	Status     string
+	Body       []byte
+}
+
+func (e *DownloadError) Error() string {
+	return e.Status + "\n" + string(e.Body)
+}
+
+// DownloadFile invokes a http-get request and streams the response body to destination.
+// The body is written to destination + PartialDownloadSuffix and renamed once it is complete, so destination
+// never contains a partial file. The size of the download is verified against Content-Length.
+// When a previous download was interrupted and the server supports ranges, the download is resumed using
+// an HTTP Range request
+// @param url : URL to download
+// @param headers : Headers of the request
+// @param destination : Path of the downloaded file
+// @return error, *DownloadError if the server returned an unsuccessful response
+func DownloadFile(url string, headers map[string]string, destination string) error {
+	partPath := destination + PartialDownloadSuffix
+	validatorPath := destination + partialDownloadValidatorSuffix
+
+	var err error
+	for attempt := 1; attempt <= maxDownloadAttempts; attempt++ {
+		var resumable bool
+		resumable, err = downloadToPartialFile(url, headers, partPath, validatorPath)
+		if err == nil {
+			_ = os.Remove(validatorPath)
+			return os.Rename(partPath, destination)
+		}
+		if _, ok := err.(*DownloadError); ok || !resumable {
+			break
+		}
+		Logln(LogPrefixWarning+"Download interrupted, resuming:", err)
+	}
+	return err
+}
+
+// downloadToPartialFile downloads url to partPath, resuming when partPath already contains part of the content.
+// @return true if the download can be resumed after a failure
+func downloadToPartialFile(url string, headers map[string]string, partPath, validatorPath string) (bool, error) {
+	var offset int64
+	request := newDownloadRequest(headers)
+	if info, err := os.Stat(partPath); err == nil && info.Size() > 0 {
+		if validator, err := ioutil.ReadFile(validatorPath); err == nil && len(validator) > 0 {
+			offset = info.Size()
+			Logln(LogPrefixInfo+"Resuming download from byte", offset)
+			request.SetHeader("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+			// the server returns the full content if it changed since the partial download
+			request.SetHeader("If-Range", string(validator))
+		}
+	}
+
+	resp, err := request.Get(url)
+	if err != nil {
+		return false, err
+	}
+	body := resp.RawBody()
+	defer body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		offset = 0
+		flags |= os.O_TRUNC
+	case http.StatusPartialContent:
+		if !strings.HasPrefix(resp.Header().Get("Content-Range"), "bytes "+strconv.FormatInt(offset, 10)+"-") {
+			return false, fmt.Errorf("unexpected Content-Range %q", resp.Header().Get("Content-Range"))
+		}
+		flags |= os.O_APPEND
+	case http.StatusRequestedRangeNotSatisfiable:
+		// partial file cannot be resumed, start over
+		_ = os.Remove(partPath)
+		_ = os.Remove(validatorPath)
+		return true, fmt.Errorf("cannot resume download: %s", resp.Status())
+	default:
+		errorBody, _ := ioutil.ReadAll(io.LimitReader(body, maxDownloadErrorBodySize))
+		return false, &DownloadError{StatusCode: resp.StatusCode(), Status: resp.Status(), Body: errorBody}
+	}
+
+	resumable := resp.Header().Get("Accept-Ranges") == "bytes" || resp.StatusCode() == http.StatusPartialContent
+	if validator := downloadValidator(resp.Header()); resumable && validator != "" {
+		if err := ioutil.WriteFile(validatorPath, []byte(validator), 0644); err != nil {
+			return false, err
+		}
+	} else {
+		resumable = false
+		_ = os.Remove(validatorPath)
+	}
+
+	file, err := os.OpenFile(partPath, flags, 0644)
+	if err != nil {
+		return false, err
+	}
+	written, err := io.Copy(file, body)
+	closeErr := file.Close()
+	if err != nil {
+		return resumable, err
+	}
+	if closeErr != nil {
+		return false, closeErr
+	}
+
+	if contentLength := resp.RawResponse.ContentLength; contentLength >= 0 && written != contentLength {
+		return resumable, fmt.Errorf("incomplete download: expected %d bytes, received %d bytes",
+			contentLength, written)
+	}
+	Logln(LogPrefixInfo+"Downloaded", offset+written, "bytes")
+	return false, nil
+}
+
+// downloadValidator returns the value used in If-Range to make sure a resumed download has the same content
+func downloadValidator(header http.Header) string {
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return header.Get("Last-Modified")
+}
+
+// newDownloadRequest creates a go-resty request which does not buffer the response body
+func newDownloadRequest(headers map[string]string) *resty.Request {
+	setRestyTransport()
+	return resty.R().SetHeaders(headers).SetDoNotParseResponse(true)
+}
@@ -0,0 +1,143 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var downloadContent = bytes.Repeat([]byte("0123456789"), 1000)
+
+func newDownloadServer() *httptest.Server {
+	modified := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "api.zip", modified, bytes.NewReader(downloadContent))
+	}))
+}
+
+func TestDownloadFile(t *testing.T) {
+	server := newDownloadServer()
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	destination := filepath.Join(dir, "api.zip")
+	err = DownloadFile(server.URL, nil, destination)
+	assert.Nil(t, err, "Error should be nil")
+
+	content, err := ioutil.ReadFile(destination)
+	assert.Nil(t, err)
+	assert.Equal(t, downloadContent, content)
+	assert.False(t, IsFileExist(destination+PartialDownloadSuffix), "Partial file should be removed")
+	assert.False(t, IsFileExist(destination+partialDownloadValidatorSuffix), "Validator file should be removed")
+}
+
+func TestDownloadFileResume(t *testing.T) {
+	var ranges []string
+	modified := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		http.ServeContent(w, r, "api.zip", modified, bytes.NewReader(downloadContent))
+	}))
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	destination := filepath.Join(dir, "api.zip")
+	// simulate an interrupted download
+	assert.Nil(t, ioutil.WriteFile(destination+PartialDownloadSuffix, downloadContent[:4000], 0644))
+	assert.Nil(t, ioutil.WriteFile(destination+partialDownloadValidatorSuffix,
+		[]byte(modified.Format(http.TimeFormat)), 0644))
+
+	err = DownloadFile(server.URL, nil, destination)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, []string{"bytes=4000-"}, ranges)
+
+	content, err := ioutil.ReadFile(destination)
+	assert.Nil(t, err)
+	assert.Equal(t, downloadContent, content)
+}
+
+func TestDownloadFileChangedContent(t *testing.T) {
+	server := newDownloadServer()
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	destination := filepath.Join(dir, "api.zip")
+	// the partial download belongs to an older version of the content, so it must not be resumed
+	assert.Nil(t, ioutil.WriteFile(destination+PartialDownloadSuffix, []byte("stale"), 0644))
+	assert.Nil(t, ioutil.WriteFile(destination+partialDownloadValidatorSuffix,
+		[]byte(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat)), 0644))
+
+	err = DownloadFile(server.URL, nil, destination)
+	assert.Nil(t, err, "Error should be nil")
+
+	content, err := ioutil.ReadFile(destination)
+	assert.Nil(t, err)
+	assert.Equal(t, downloadContent, content)
+}
+
+func TestDownloadFileIncomplete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "100")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("short"))
+	}))
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	destination := filepath.Join(dir, "api.zip")
+	err = DownloadFile(server.URL, nil, destination)
+	assert.NotNil(t, err, "Incomplete download should return an error")
+	assert.False(t, IsFileExist(destination), "Destination should not be created for an incomplete download")
+}
+
+func TestDownloadFileErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("API not found"))
+	}))
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	destination := filepath.Join(dir, "api.zip")
+	err = DownloadFile(server.URL, nil, destination)
+	downloadErr, ok := err.(*DownloadError)
+	assert.True(t, ok, "Error should be a download error")
+	assert.Equal(t, http.StatusNotFound, downloadErr.StatusCode)
+	assert.Equal(t, "API not found", string(downloadErr.Body))
+	assert.False(t, IsFileExist(destination))
+}
@@ -7,76 +7,126 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
-// Match for $VAR or ${VAR} and capture VAR inside a group
-var re = regexp.MustCompile(`\${?(\w+)}?`)
+// Match for $${ (escaped ${), ${VAR}, ${VAR:-default}, ${VAR:?message} or $VAR. Groups capture VAR, the operator
+// and the default value or message of ${VAR...}, and VAR of $VAR
+var re = regexp.MustCompile(`\$\$(\{|\w)|\$\{(\w+)(?:(:-|:\?)([^}]*))?\}|\$(\w+)`)
 
-// Match for ${VAR} and capture VAR inside a group
-var recb = regexp.MustCompile(`\${(\w+)}`)
+// Match for $${ (escaped ${), ${VAR}, ${VAR:-default} or ${VAR:?message}
+var recb = regexp.MustCompile(`\$\$(\{)|\$\{(\w+)(?:(:-|:\?)([^}]*))?\}`)
 
 // ErrRequiredEnvKeyMissing represents error used for indicate environment key missing
 type ErrRequiredEnvKeyMissing struct {
 	// Key is the missing entity
 	Key string
+	// Message is given with ${VAR:?message}
+	Message string
 }
 
 func (e ErrRequiredEnvKeyMissing) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("%s is required: %s", e.Key, e.Message)
+	}
 	return fmt.Sprintf("%s is required, please set the environment variable", e.Key)
 }
 
-// EnvSubstitute substitutes variables from environment to the content. It uses regex to match variables and look up them in the
-// environment before processing.
-// returns an error if anything happen
-func EnvSubstitute(content string) (string, error) {
-	var errorResults error
-	missingEnvKeys := false
-	matches := re.FindAllStringSubmatch(content, -1) // matches is [][]string
-
-	for _, match := range matches {
-		Logln(LogPrefixInfo + "Looking for:", match[0])
-		if os.Getenv(match[1]) == "" {
-			missingEnvKeys = true
-			errorResults = multierror.Append(errorResults, &ErrRequiredEnvKeyMissing{Key: match[0]})
+// EnvVariableRef is a reference to an environment variable in a content
+type EnvVariableRef struct {
+	// Match is the reference as written in the content (i.e. ${VAR:-default})
+	Match string
+	// Name of the environment variable
+	Name string
+	// HasDefault is true when a default value is given with ${VAR:-default}
+	HasDefault bool
+	// Default value of the variable
+	Default string
+	// Message to show when the variable is not set, given with ${VAR:?message}
+	Message string
+}
+
+// newEnvVariableRef creates an EnvVariableRef from a match of re or recb. It returns nil for escaped references
+func newEnvVariableRef(match []string) *EnvVariableRef {
+	if match[1] != "" {
+		return nil
+	}
+	if len(match) > 5 && match[5] != "" {
+		return &EnvVariableRef{Match: match[0], Name: match[5]}
+	}
+	ref := &EnvVariableRef{Match: match[0], Name: match[2]}
+	switch match[3] {
+	case ":-":
+		ref.HasDefault = true
+		ref.Default = match[4]
+	case ":?":
+		ref.Message = match[4]
+	}
+	return ref
+}
+
+// FindEnvVariables returns the references to environment variables in the content. $VAR references are included
+// when curlyBracesOnly is false
+func FindEnvVariables(content string, curlyBracesOnly bool) []EnvVariableRef {
+	regex := re
+	if curlyBracesOnly {
+		regex = recb
+	}
+	var refs []EnvVariableRef
+	for _, match := range regex.FindAllStringSubmatch(content, -1) {
+		if ref := newEnvVariableRef(match); ref != nil {
+			refs = append(refs, *ref)
 		}
 	}
+	return refs
+}
 
-	if missingEnvKeys {
+// substitute replaces the matches of regex in the content with values from the environment. Variables which are
+// not set, or empty, are replaced with their defaults. It returns an error for variables without defaults which are
+// not set
+func substitute(content string, regex *regexp.Regexp) (string, error) {
+	var errorResults error
+	result := regex.ReplaceAllStringFunc(content, func(m string) string {
+		ref := newEnvVariableRef(regex.FindStringSubmatch(m))
+		if ref == nil {
+			// $${VAR} is kept as ${VAR}
+			return m[1:]
+		}
+		Logln(LogPrefixInfo+"Looking for:", ref.Match)
+		if value := os.Getenv(ref.Name); value != "" {
+			return value
+		}
+		if ref.HasDefault {
+			return ref.Default
+		}
+		errorResults = multierror.Append(errorResults, &ErrRequiredEnvKeyMissing{Key: ref.Match,
+			Message: ref.Message})
+		return m
+	})
+	if errorResults != nil {
 		return "", errorResults
 	}
+	return result, nil
+}
 
-	expanded := os.ExpandEnv(content)
-	return expanded, nil
+// EnvSubstitute substitutes variables from environment to the content. It uses regex to match variables as $VAR,
+// ${VAR}, ${VAR:-default} or ${VAR:?message} and look up them in the environment. $$ escapes a $.
+// returns an error if anything happen
+func EnvSubstitute(content string) (string, error) {
+	return substitute(content, re)
 }
 
 // EnvSubstituteForCurlyBraces substitutes variables from environment to the content.
-// It uses regex to match in ${var} format for variables and look up them in the environment before processing.
+// It uses regex to match in ${var}, ${VAR:-default} or ${VAR:?message} format for variables and look up them in
+// the environment. $${VAR} escapes a variable.
 // returns an error if anything happen
 func EnvSubstituteForCurlyBraces(content string) (string, error) {
-	var errorResults error
-	missingEnvKeys := false
-	matches := recb.FindAllStringSubmatch(content, -1) // matches is [][]string
-
-	for _, match := range matches {
-		Logln(LogPrefixInfo + "Looking for:", match[0])
-		if os.Getenv(match[1]) == "" {
-			missingEnvKeys = true
-			errorResults = multierror.Append(errorResults, &ErrRequiredEnvKeyMissing{Key: match[0]})
-		} else {
-			content = strings.ReplaceAll(content, match[0], os.Getenv(match[1]))
-		}
-	}
-
-	if missingEnvKeys {
-		return "", errorResults
-	}
-
-	return content, nil
+	return substitute(content, recb)
 }
 
 // Substitutes all the environment variables added in the file specified in the 'file' input and changes are
-// updated in the file.
+// updated in the file. Encrypted values (enc:v1:...) are decrypted, so the file should be in a temporary workspace.
 // If any required environment variable is not set will throw an error.
 func EnvSubstituteInFile(file string) error {
 	content, err := ioutil.ReadFile(file)
@@ -87,6 +137,10 @@ func EnvSubstituteInFile(file string) error {
 	if err != nil {
 		return err
 	}
+	substitutedContent, err = DecryptSecrets(substitutedContent)
+	if err != nil {
+		return fmt.Errorf("%s: %v", file, err)
+	}
 	err = ioutil.WriteFile(file, []byte(substitutedContent), 0644)
 	if err != nil {
 		return err
@@ -121,3 +175,64 @@ func EnvSubstituteInFolder(folderPath string) error {
 	}
 	return nil;
 }
+
+// Match for KEY=VALUE lines of env files, with an optional export prefix
+var reEnvFileLine = regexp.MustCompile(`^\s*(?:export\s+)?(\w+)\s*=\s*(.*?)\s*$`)
+
+// LoadEnvFile loads variables in the dotenv file in path to the environment. Variables which are already set in the
+// environment are not changed.
+// Lines are in KEY=VALUE format, values may be quoted with ' or " and lines starting with # are ignored
+func LoadEnvFile(path string) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	for index, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		match := reEnvFileLine.FindStringSubmatch(line)
+		if match == nil {
+			return fmt.Errorf("%s:%d: expected KEY=VALUE", path, index+1)
+		}
+		value, err := parseEnvFileValue(match[2])
+		if err != nil {
+			return fmt.Errorf("%s:%d: %v", path, index+1, err)
+		}
+		if _, ok := os.LookupEnv(match[1]); ok {
+			Logln(LogPrefixInfo+"Keeping", match[1], "set in the environment")
+			continue
+		}
+		if err := os.Setenv(match[1], value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseEnvFileValue unquotes a value of an env file. Unquoted values end at a " #" comment
+func parseEnvFileValue(value string) (string, error) {
+	if strings.HasPrefix(value, `"`) {
+		end := strings.LastIndex(value, `"`)
+		if end == 0 {
+			return "", fmt.Errorf("unterminated quoted value %s", value)
+		}
+		unquoted, err := strconv.Unquote(value[:end+1])
+		if err != nil {
+			return "", err
+		}
+		return unquoted, nil
+	}
+	if strings.HasPrefix(value, `'`) {
+		end := strings.LastIndex(value, `'`)
+		if end == 0 {
+			return "", fmt.Errorf("unterminated quoted value %s", value)
+		}
+		return value[1:end], nil
+	}
+	if index := strings.Index(value, " #"); index >= 0 {
+		value = strings.TrimSpace(value[:index])
+	}
+	return value, nil
+}
@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,3 +23,59 @@ func TestInjectEnvShouldPassWhenEnvPresents(t *testing.T) {
 	assert.Nil(t, err, "Error should be null")
 	assert.Equal(t, "myval", str, "Should correctly replace environment variable")
 }
+
+func TestEnvSubstituteWithDefaults(t *testing.T) {
+	_ = os.Unsetenv("MISSING_VAR")
+	_ = os.Setenv("PRESENT_VAR", "present")
+	str, err := EnvSubstitute(`${MISSING_VAR:-http://localhost:8080} ${PRESENT_VAR:-default} ${MISSING_VAR:-}`)
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, "http://localhost:8080 present ", str, "Should use defaults of missing variables")
+}
+
+func TestEnvSubstituteWithRequiredMessage(t *testing.T) {
+	_ = os.Unsetenv("MISSING_VAR")
+	_, err := EnvSubstitute(`url: ${MISSING_VAR:?set the backend URL}`)
+	assert.Error(t, err, "Should return an error")
+	assert.Contains(t, err.Error(), "${MISSING_VAR:?set the backend URL} is required: set the backend URL")
+}
+
+func TestEnvSubstituteWithEscapes(t *testing.T) {
+	_ = os.Setenv("MYVAR", "myval")
+	str, err := EnvSubstitute(`$${MYVAR} $$MYVAR $MYVAR`)
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, "${MYVAR} $MYVAR myval", str, "Should keep escaped variables")
+
+	str, err = EnvSubstituteForCurlyBraces(`$${MYVAR} ${MYVAR} $MYVAR`)
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, "${MYVAR} myval $MYVAR", str, "Should only substitute variables in curly braces")
+}
+
+func TestFindEnvVariables(t *testing.T) {
+	refs := FindEnvVariables(`$A ${B:-b} ${C:?c is required} $${D}`, false)
+	assert.Equal(t, []EnvVariableRef{
+		{Match: "$A", Name: "A"},
+		{Match: "${B:-b}", Name: "B", HasDefault: true, Default: "b"},
+		{Match: "${C:?c is required}", Name: "C", Message: "c is required"},
+	}, refs)
+	assert.Equal(t, 2, len(FindEnvVariables(`$A ${B:-b} ${C:?c is required} $${D}`, true)))
+}
+
+func TestLoadEnvFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "env")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	envFile := filepath.Join(dir, "dev.env")
+	content := "# backend\nexport ENV_FILE_URL=http://dev.example.com # comment\n\nENV_FILE_QUOTED=\"a b\\n\"\n" +
+		"ENV_FILE_SINGLE='c # d'\nENV_FILE_PRESET=file\n"
+	assert.Nil(t, ioutil.WriteFile(envFile, []byte(content), 0644))
+	_ = os.Setenv("ENV_FILE_PRESET", "environment")
+
+	assert.Nil(t, LoadEnvFile(envFile))
+	assert.Equal(t, "http://dev.example.com", os.Getenv("ENV_FILE_URL"))
+	assert.Equal(t, "a b\n", os.Getenv("ENV_FILE_QUOTED"))
+	assert.Equal(t, "c # d", os.Getenv("ENV_FILE_SINGLE"))
+	assert.Equal(t, "environment", os.Getenv("ENV_FILE_PRESET"), "Should not change variables in the environment")
+
+	assert.Nil(t, ioutil.WriteFile(envFile, []byte("INVALID LINE\n"), 0644))
+	assert.Error(t, LoadEnvFile(envFile), "Should return an error for invalid lines")
+}
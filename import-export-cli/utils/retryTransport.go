@@ -0,0 +1,302 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// retryableStatusCodes are retried for idempotent requests
+var retryableStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// rejectedStatusCodes are retried for every request. The server rejects the request with these codes before
+// processing it, while a gateway returning 502 or 504 may have forwarded the request to a backend which processed it
+var rejectedStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusServiceUnavailable: true,
+}
+
+// idempotentMethods are retried on any connection error and on retryableStatusCodes
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+// RetryPolicy defines how failed requests are retried
+type RetryPolicy struct {
+	// RetryCount is the number of retries after the first attempt
+	RetryCount int
+	// WaitTime is the backoff before the first retry. It is doubled for each retry
+	WaitTime time.Duration
+	// MaxWaitTime is the maximum backoff. Requests are not retried when Retry-After asks for a longer wait
+	MaxWaitTime time.Duration
+}
+
+// RateLimiter limits the number of requests sent per second. A nil RateLimiter does not limit requests
+type RateLimiter struct {
+	mutex    sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// NewRateLimiter creates a RateLimiter allowing requestsPerSecond requests per second.
+// @return nil if requestsPerSecond is not positive
+func NewRateLimiter(requestsPerSecond float64) *RateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return &RateLimiter{interval: time.Duration(float64(time.Second) / requestsPerSecond)}
+}
+
+// Wait blocks until the next request is allowed
+func (l *RateLimiter) Wait() {
+	if l == nil {
+		return
+	}
+	l.mutex.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mutex.Unlock()
+	time.Sleep(wait)
+}
+
+// RetryTransport is a http.RoundTripper which retries failed requests with exponential backoff and jitter.
+// Idempotent requests are retried on 429, 502, 503 and 504 responses and on connection errors. Other requests, such
+// as imports, are only retried on 429 and 503 responses and when the connection could not be established, so that
+// they are not sent twice. The Retry-After header of the response is honoured
+type RetryTransport struct {
+	// Transport sends the requests, http.DefaultTransport is used when nil
+	Transport http.RoundTripper
+	// Timeout of a single attempt, including reading the response body. No timeout when zero
+	Timeout time.Duration
+	Policy  RetryPolicy
+	Limiter *RateLimiter
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	// the body has to be sent again for each retry
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.WithContext(req.Context())
+			attemptReq.Body = body
+		}
+
+		t.Limiter.Wait()
+		resp, err := t.roundTripWithTimeout(transport, attemptReq)
+
+		if attempt >= t.Policy.RetryCount || !replayable || req.Context().Err() != nil {
+			return resp, err
+		}
+		var wait time.Duration
+		idempotent := idempotentMethods[req.Method]
+		if err != nil {
+			if !idempotent && !isDialError(err) {
+				return resp, err
+			}
+			wait = t.backoff(attempt)
+			Logln(LogPrefixWarning+"Request to "+req.URL.String()+" failed, retrying in", wait, ":", err)
+		} else {
+			if (idempotent && !retryableStatusCodes[resp.StatusCode]) ||
+				(!idempotent && !rejectedStatusCodes[resp.StatusCode]) {
+				return resp, err
+			}
+			var ok bool
+			if wait, ok = retryAfter(resp.Header.Get("Retry-After")); ok {
+				if wait > t.Policy.MaxWaitTime {
+					// the server will not be available in time
+					return resp, err
+				}
+			} else {
+				wait = t.backoff(attempt)
+			}
+			Logln(LogPrefixWarning+"Request to "+req.URL.String()+" returned "+resp.Status+", retrying in", wait)
+			_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxDownloadErrorBodySize))
+			_ = resp.Body.Close()
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// roundTripWithTimeout sends a single attempt, cancelling it when the timeout is exceeded
+func (t *RetryTransport) roundTripWithTimeout(transport http.RoundTripper, req *http.Request) (*http.Response,
+	error) {
+	if t.Timeout <= 0 {
+		return transport.RoundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), t.Timeout)
+	resp, err := transport.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// the timeout covers reading the body as well
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// isDialError returns true if the connection could not be established, e.g. it was refused, so the request was not
+// sent
+func isDialError(err error) bool {
+	opErr, ok := err.(*net.OpError)
+	return ok && opErr.Op == "dial"
+}
+
+// backoff returns the exponential backoff with jitter for a retry
+func (t *RetryTransport) backoff(attempt int) time.Duration {
+	wait := t.Policy.WaitTime << uint(attempt)
+	if wait <= 0 || wait > t.Policy.MaxWaitTime {
+		wait = t.Policy.MaxWaitTime
+	}
+	if wait <= 0 {
+		return 0
+	}
+	// wait between half and the full backoff so that parallel clients do not retry at the same time
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// retryAfter parses a Retry-After header, which is either a number of seconds or a HTTP date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+var baseTransports = map[bool]*http.Transport{}
+var baseTransportsMutex sync.Mutex
+
+// baseTransport sends requests with the shared transport matching the current Insecure setting
+type baseTransport struct{}
+
+// RoundTrip implements http.RoundTripper
+func (baseTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return getBaseTransport().RoundTrip(req)
+}
+
+// getBaseTransport returns a shared transport so that connections are reused between requests
+func getBaseTransport() *http.Transport {
+	baseTransportsMutex.Lock()
+	defer baseTransportsMutex.Unlock()
+	if transport, ok := baseTransports[Insecure]; ok {
+		return transport
+	}
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if Insecure {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} // To bypass errors in SSL certificates
+	} else {
+		transport.TLSClientConfig = GetTlsConfigWithCertificate()
+	}
+	baseTransports[Insecure] = transport
+	return transport
+}
+
+var requestRateLimiter *RateLimiter
+var requestRateLimiterOnce sync.Once
+
+// getRequestRateLimiter returns the rate limiter shared by all requests of the process
+func getRequestRateLimiter() *RateLimiter {
+	requestRateLimiterOnce.Do(func() {
+		requestRateLimiter = NewRateLimiter(HttpRequestsPerSecond)
+	})
+	return requestRateLimiter
+}
+
+// NewRetryTransport creates a RetryTransport using the retry and rate limit settings of the main config
+// @param timeout : Timeout of a single attempt
+func NewRetryTransport(timeout time.Duration) *RetryTransport {
+	retryCount := HttpRetryCount
+	if retryCount < 0 {
+		retryCount = 0
+	}
+	return &RetryTransport{
+		Transport: baseTransport{},
+		Timeout:   timeout,
+		Policy: RetryPolicy{
+			RetryCount:  retryCount,
+			WaitTime:    time.Duration(HttpRetryWaitTime) * time.Millisecond,
+			MaxWaitTime: time.Duration(HttpRetryMaxWaitTime) * time.Millisecond,
+		},
+		Limiter: getRequestRateLimiter(),
+	}
+}
+
+// NewHttpClient creates a http.Client which retries failed requests
+// @param timeout : Timeout of a single attempt
+func NewHttpClient(timeout time.Duration) *http.Client {
+	return &http.Client{Transport: NewRetryTransport(timeout)}
+}
@@ -0,0 +1,253 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testRetryPolicy = RetryPolicy{RetryCount: 3, WaitTime: time.Millisecond, MaxWaitTime: 2 * time.Second}
+
+// newFaultyServer creates a server which responds with the given status codes before returning 200 OK
+func newFaultyServer(attempts *int32, header http.Header, faults ...int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempt := atomic.AddInt32(attempts, 1)
+		if int(attempt) <= len(faults) {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(faults[attempt-1])
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+		w.Write(append([]byte("ok"), body...))
+	}))
+}
+
+func TestRetryTransportRetriesGatewayErrors(t *testing.T) {
+	var attempts int32
+	server := newFaultyServer(&attempts, nil, http.StatusBadGateway, http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout)
+	defer server.Close()
+
+	client := &http.Client{Transport: &RetryTransport{Policy: testRetryPolicy}}
+	resp, err := client.Get(server.URL)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(4), attempts)
+}
+
+func TestRetryTransportGivesUp(t *testing.T) {
+	var attempts int32
+	server := newFaultyServer(&attempts, nil, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway,
+		http.StatusBadGateway)
+	defer server.Close()
+
+	client := &http.Client{Transport: &RetryTransport{Policy: testRetryPolicy}}
+	resp, err := client.Get(server.URL)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode, "Last response should be returned")
+	assert.Equal(t, int32(4), attempts)
+}
+
+func TestRetryTransportDoesNotRetryServerErrors(t *testing.T) {
+	var attempts int32
+	server := newFaultyServer(&attempts, nil, http.StatusInternalServerError)
+	defer server.Close()
+
+	client := &http.Client{Transport: &RetryTransport{Policy: testRetryPolicy}}
+	resp, err := client.Get(server.URL)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, int32(1), attempts)
+}
+
+func TestRetryTransportReplaysBody(t *testing.T) {
+	var attempts int32
+	server := newFaultyServer(&attempts, nil, http.StatusTooManyRequests)
+	defer server.Close()
+
+	client := &http.Client{Transport: &RetryTransport{Policy: testRetryPolicy}}
+	resp, err := client.Post(server.URL, "text/plain", strings.NewReader("-body"))
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, "ok-body", string(body), "Body should be sent again")
+	assert.Equal(t, int32(2), attempts)
+}
+
+func TestRetryTransportHonoursRetryAfter(t *testing.T) {
+	var attempts int32
+	server := newFaultyServer(&attempts, http.Header{"Retry-After": {"1"}}, http.StatusTooManyRequests)
+	defer server.Close()
+
+	client := &http.Client{Transport: &RetryTransport{Policy: testRetryPolicy}}
+	start := time.Now()
+	resp, err := client.Get(server.URL)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.True(t, time.Since(start) >= time.Second, "Request should be retried after Retry-After")
+}
+
+func TestRetryTransportRetryAfterExceedsMaxWaitTime(t *testing.T) {
+	var attempts int32
+	server := newFaultyServer(&attempts, http.Header{"Retry-After": {"3600"}}, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	client := &http.Client{Transport: &RetryTransport{Policy: testRetryPolicy}}
+	resp, err := client.Get(server.URL)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(1), attempts)
+}
+
+func TestRetryTransportRetriesConnectionErrors(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			// drop the connection without a response
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &RetryTransport{Policy: testRetryPolicy}}
+	resp, err := client.Get(server.URL)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), attempts)
+
+	// requests which are not idempotent may have been processed, so they are not retried
+	atomic.StoreInt32(&attempts, 0)
+	_, err = client.Post(server.URL, "text/plain", strings.NewReader("body"))
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), attempts)
+}
+
+func TestRetryTransportPostRetriedWhenRejected(t *testing.T) {
+	var attempts int32
+	server := newFaultyServer(&attempts, nil, http.StatusTooManyRequests, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	client := &http.Client{Transport: &RetryTransport{Policy: testRetryPolicy}}
+	resp, err := client.Post(server.URL, "text/plain", strings.NewReader("body"))
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), attempts)
+}
+
+func TestRetryTransportPostNotRetriedOnGatewayErrors(t *testing.T) {
+	for _, status := range []int{http.StatusBadGateway, http.StatusGatewayTimeout} {
+		var attempts int32
+		server := newFaultyServer(&attempts, nil, status)
+
+		// the backend may have processed the import behind the gateway
+		client := &http.Client{Transport: &RetryTransport{Policy: testRetryPolicy}}
+		resp, err := client.Post(server.URL, "text/plain", strings.NewReader("body"))
+		assert.Nil(t, err)
+		assert.Equal(t, status, resp.StatusCode)
+		assert.Equal(t, int32(1), attempts)
+		resp.Body.Close()
+		server.Close()
+	}
+}
+
+func TestRetryTransportPostRetriedWhenConnectionRefused(t *testing.T) {
+	var attempts int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	// nothing accepts connections until the server is started
+	addr := server.Listener.Addr().String()
+	assert.Nil(t, server.Listener.Close())
+
+	started := make(chan struct{})
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		server.Listener, _ = net.Listen("tcp", addr)
+		server.Start()
+		close(started)
+	}()
+
+	policy := testRetryPolicy
+	policy.RetryCount = 10
+	policy.WaitTime = 10 * time.Millisecond
+	policy.MaxWaitTime = 20 * time.Millisecond
+	client := &http.Client{Transport: &RetryTransport{Policy: policy}}
+	resp, err := client.Post("http://"+addr, "text/plain", strings.NewReader("body"))
+	<-started
+	if assert.Nil(t, err) {
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	assert.Equal(t, int32(1), attempts)
+}
+
+func TestRateLimiter(t *testing.T) {
+	var attempts int32
+	server := newFaultyServer(&attempts, nil)
+	defer server.Close()
+
+	client := &http.Client{Transport: &RetryTransport{Policy: testRetryPolicy, Limiter: NewRateLimiter(20)}}
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		resp, err := client.Get(server.URL)
+		assert.Nil(t, err)
+		resp.Body.Close()
+	}
+	assert.True(t, time.Since(start) >= 200*time.Millisecond, "Requests should be limited to 20 per second")
+	assert.Nil(t, NewRateLimiter(0), "Requests should not be limited when the rate is zero")
+}
+
+func TestInvokeGETRequestRetries(t *testing.T) {
+	var attempts int32
+	server := newFaultyServer(&attempts, nil, http.StatusServiceUnavailable, http.StatusBadGateway)
+	defer server.Close()
+
+	defaultWaitTime := HttpRetryWaitTime
+	HttpRetryWaitTime = 1
+	defer func() { HttpRetryWaitTime = defaultWaitTime }()
+
+	resp, err := InvokeGETRequest(server.URL, map[string]string{})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.Equal(t, int32(3), attempts)
+}
@@ -0,0 +1,158 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// SecretPrefix is the prefix of encrypted values
+const SecretPrefix = "enc:v1:"
+
+// SecretKeyEnvName is the environment variable containing the base64 encoded secret key
+const SecretKeyEnvName = "APICTL_SECRET_KEY"
+
+// SecretKeyFileEnvName is the environment variable containing the path of the secret key file
+const SecretKeyFileEnvName = "APICTL_SECRET_KEY_FILE"
+
+// secretKeySize is the size of AES-256 keys in bytes
+const secretKeySize = 32
+
+// Match for enc:v1:<base64 url encoded nonce and cipher text>
+var reSecret = regexp.MustCompile(regexp.QuoteMeta(SecretPrefix) + `[A-Za-z0-9_-]+`)
+
+// LoadSecretKey loads the key used to encrypt secrets from keyFile. If keyFile is empty the key is read from
+// the environment variable APICTL_SECRET_KEY or from the file in APICTL_SECRET_KEY_FILE.
+// The key is 32 random bytes encoded in base64 (i.e. openssl rand -base64 32)
+func LoadSecretKey(keyFile string) ([]byte, error) {
+	encodedKey := ""
+	if keyFile == "" {
+		keyFile = os.Getenv(SecretKeyFileEnvName)
+		encodedKey = os.Getenv(SecretKeyEnvName)
+	}
+	if encodedKey == "" {
+		if keyFile == "" {
+			return nil, fmt.Errorf("secret key not found, set %s or %s", SecretKeyEnvName, SecretKeyFileEnvName)
+		}
+		p, err := homedir.Expand(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		content, err := ioutil.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		encodedKey = string(content)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedKey))
+	if err != nil {
+		return nil, fmt.Errorf("secret key should be base64 encoded: %v", err)
+	}
+	if len(key) != secretKeySize {
+		return nil, fmt.Errorf("secret key should be %d bytes, found %d bytes", secretKeySize, len(key))
+	}
+	return key, nil
+}
+
+// EncryptSecret encrypts value using AES-GCM with key and returns it as an enc:v1: token
+func EncryptSecret(key []byte, value string) (string, error) {
+	gcm, err := newSecretCipher(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(value), nil)
+	return SecretPrefix + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptSecret decrypts an enc:v1: token encrypted with key
+func DecryptSecret(key []byte, token string) (string, error) {
+	if !strings.HasPrefix(token, SecretPrefix) {
+		return "", fmt.Errorf("encrypted values should start with %s", SecretPrefix)
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(token, SecretPrefix))
+	if err != nil {
+		return "", err
+	}
+	gcm, err := newSecretCipher(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("encrypted value is too short")
+	}
+	value, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", errors.New("unable to decrypt value, check the secret key")
+	}
+	return string(value), nil
+}
+
+// ContainsSecrets returns true if content contains enc:v1: tokens
+func ContainsSecrets(content string) bool {
+	return reSecret.MatchString(content)
+}
+
+// DecryptSecrets replaces enc:v1: tokens in content with their decrypted values. The key is loaded with
+// LoadSecretKey only when content contains tokens.
+// Decrypted content should not be written outside of temporary workspaces
+func DecryptSecrets(content string) (string, error) {
+	if !reSecret.MatchString(content) {
+		return content, nil
+	}
+	key, err := LoadSecretKey("")
+	if err != nil {
+		return "", err
+	}
+	var decryptErr error
+	decrypted := reSecret.ReplaceAllStringFunc(content, func(token string) string {
+		value, err := DecryptSecret(key, token)
+		if err != nil && decryptErr == nil {
+			decryptErr = err
+		}
+		return value
+	})
+	if decryptErr != nil {
+		return "", decryptErr
+	}
+	return decrypted, nil
+}
+
+func newSecretCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
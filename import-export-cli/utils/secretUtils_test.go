@@ -0,0 +1,92 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testSecretKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestEncryptAndDecryptSecret(t *testing.T) {
+	token, err := EncryptSecret(testSecretKey, "p@ss: #1")
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(token, SecretPrefix))
+	assert.NotContains(t, token, "p@ss")
+
+	value, err := DecryptSecret(testSecretKey, token)
+	assert.Nil(t, err)
+	assert.Equal(t, "p@ss: #1", value)
+
+	_, err = DecryptSecret([]byte("fedcba9876543210fedcba9876543210"), token)
+	assert.NotNil(t, err, "Should not decrypt with a different key")
+}
+
+func TestLoadSecretKey(t *testing.T) {
+	encodedKey := base64.StdEncoding.EncodeToString(testSecretKey)
+	dir, err := ioutil.TempDir("", "secret")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	keyFile := filepath.Join(dir, "secret.key")
+	assert.Nil(t, ioutil.WriteFile(keyFile, []byte(encodedKey+"\n"), 0600))
+
+	key, err := LoadSecretKey(keyFile)
+	assert.Nil(t, err)
+	assert.Equal(t, testSecretKey, key)
+
+	_ = os.Setenv(SecretKeyFileEnvName, keyFile)
+	key, err = LoadSecretKey("")
+	_ = os.Unsetenv(SecretKeyFileEnvName)
+	assert.Nil(t, err)
+	assert.Equal(t, testSecretKey, key)
+
+	_ = os.Setenv(SecretKeyEnvName, base64.StdEncoding.EncodeToString([]byte("short")))
+	_, err = LoadSecretKey("")
+	_ = os.Unsetenv(SecretKeyEnvName)
+	assert.NotNil(t, err, "Should return an error for keys of wrong size")
+
+	_, err = LoadSecretKey("")
+	assert.NotNil(t, err, "Should return an error when no key is given")
+}
+
+func TestDecryptSecrets(t *testing.T) {
+	token, err := EncryptSecret(testSecretKey, "admin123")
+	assert.Nil(t, err)
+	content := "username: admin\npassword: " + token + "\n"
+
+	_, err = DecryptSecrets(content)
+	assert.NotNil(t, err, "Should return an error when the key is not set")
+
+	_ = os.Setenv(SecretKeyEnvName, base64.StdEncoding.EncodeToString(testSecretKey))
+	defer os.Unsetenv(SecretKeyEnvName)
+	decrypted, err := DecryptSecrets(content)
+	assert.Nil(t, err)
+	assert.Equal(t, "username: admin\npassword: admin123\n", decrypted)
+
+	plain, err := DecryptSecrets("username: admin\n")
+	assert.Nil(t, err)
+	assert.Equal(t, "username: admin\n", plain)
+}
@@ -34,10 +34,18 @@ type MainConfig struct {
 }
 
 type Config struct {
-	HttpRequestTimeout int    `yaml:"http_request_timeout"`
-	ExportDirectory    string `yaml:"export_directory"`
-	KubernetesMode     bool   `yaml:"kubernetes_mode"`
-	TokenType          string `yaml:"token_type"`
+	HttpRequestTimeout    int      `yaml:"http_request_timeout"`
+	ExportDirectory       string   `yaml:"export_directory"`
+	KubernetesMode        bool     `yaml:"kubernetes_mode"`
+	TokenType             string   `yaml:"token_type"`
+	SnapshotRetention     int      `yaml:"snapshot_retention"`
+	HttpRetryCount        int      `yaml:"http_retry_count"`
+	HttpRetryWaitTime     int      `yaml:"http_retry_wait_time"`
+	HttpRetryMaxWaitTime  int      `yaml:"http_retry_max_wait_time"`
+	HttpRequestsPerSecond float64  `yaml:"http_requests_per_second"`
+	CertExpiryWarningDays int      `yaml:"cert_expiry_warning_days"`
+	LintBeforeImport      bool     `yaml:"lint_before_import"`
+	LintRules             []string `yaml:"lint_rules"`
 }
 
 type EnvKeys struct {
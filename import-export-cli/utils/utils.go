@@ -20,27 +20,33 @@ package utils
 
 import (
 	"bufio"
-	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty"
 	"golang.org/x/crypto/ssh/terminal"
 )
 
+var restyTransportOnce sync.Once
+
+// setRestyTransport makes go-resty retry failed requests. The timeout is applied to each attempt.
+// The transport is set once, before the first request
+func setRestyTransport() {
+	restyTransportOnce.Do(func() {
+		resty.SetTransport(NewRetryTransport(time.Duration(HttpRequestTimeout) * time.Millisecond))
+		resty.SetTimeout(0)
+	})
+}
+
 // Invoke http-post request using go-resty
 func InvokePOSTRequest(url string, headers map[string]string, body string) (*resty.Response, error) {
-	if Insecure {
-		resty.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true}) // To bypass errors in SSL certificates
-	} else {
-		resty.SetTLSClientConfig(GetTlsConfigWithCertificate())
-	}
-	resty.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
+	setRestyTransport()
 	resp, err := resty.R().SetHeaders(headers).SetBody(body).Post(url)
 
 	return resp, err
@@ -48,12 +54,7 @@ func InvokePOSTRequest(url string, headers map[string]string, body string) (*res
 
 // Invoke http-post request without body using go-resty
 func InvokePOSTRequestWithoutBody(url string, headers map[string]string) (*resty.Response, error) {
-	if Insecure {
-		resty.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true}) // To bypass errors in SSL certificates
-	} else {
-		resty.SetTLSClientConfig(GetTlsConfigWithCertificate())
-	}
-	resty.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
+	setRestyTransport()
 	resp, err := resty.R().SetHeaders(headers).Post(url)
 
 	return resp, err
@@ -61,12 +62,7 @@ func InvokePOSTRequestWithoutBody(url string, headers map[string]string) (*resty
 
 // Invoke http-get request using go-resty
 func InvokeGETRequest(url string, headers map[string]string) (*resty.Response, error) {
-	if Insecure {
-		resty.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true}) // To bypass errors in SSL certificates
-	} else {
-		resty.SetTLSClientConfig(GetTlsConfigWithCertificate())
-	}
-	resty.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
+	setRestyTransport()
 	resp, err := resty.R().SetHeaders(headers).Get(url)
 
 	return resp, err
@@ -75,12 +71,7 @@ func InvokeGETRequest(url string, headers map[string]string) (*resty.Response, e
 // Invoke http-get request with query param
 func InvokeGETRequestWithQueryParam(queryParam string, paramValue string, url string, headers map[string]string) (
 	*resty.Response, error) {
-	if Insecure {
-		resty.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true}) // To bypass errors in SSL certificates
-	} else {
-		resty.SetTLSClientConfig(GetTlsConfigWithCertificate())
-	}
-	resty.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
+	setRestyTransport()
 	resp, err := resty.R().SetHeaders(headers).SetQueryParam(queryParam, paramValue).Get(url)
 
 	return resp, err
@@ -89,12 +80,7 @@ func InvokeGETRequestWithQueryParam(queryParam string, paramValue string, url st
 // Invoke http-get request with multiple query params
 func InvokeGETRequestWithMultipleQueryParams(queryParam map[string]string, url string, headers map[string]string) (
 	*resty.Response, error) {
-	if Insecure {
-		resty.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true}) // To bypass errors in SSL certificates
-	} else {
-		resty.SetTLSClientConfig(GetTlsConfigWithCertificate())
-	}
-	resty.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
+	setRestyTransport()
 	resp, err := resty.R().SetHeaders(headers).SetQueryParams(queryParam).Get(url)
 
 	return resp, err
@@ -103,12 +89,7 @@ func InvokeGETRequestWithMultipleQueryParams(queryParam map[string]string, url s
 // Invoke http-put request
 func InvokePutRequest(queryParam map[string]string, url string, headers map[string]string, body string) (
 	*resty.Response, error) {
-	if Insecure {
-		resty.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true}) // To bypass errors in SSL certificates
-	} else {
-		resty.SetTLSClientConfig(GetTlsConfigWithCertificate())
-	}
-	resty.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
+	setRestyTransport()
 	resp, err := resty.R().SetHeaders(headers).SetQueryParams(queryParam).SetBody(body).Put(url)
 
 	return resp, err
@@ -117,12 +98,7 @@ func InvokePutRequest(queryParam map[string]string, url string, headers map[stri
 //Invoke POST request with query parameters
 func InvokePostRequestWithQueryParam(queryParam map[string]string, url string, headers map[string]string, body string) (
 	*resty.Response, error) {
-	if Insecure {
-		resty.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true}) // To bypass errors in SSL certificates
-	} else {
-		resty.SetTLSClientConfig(GetTlsConfigWithCertificate())
-	}
-	resty.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
+	setRestyTransport()
 	resp, err := resty.R().SetHeaders(headers).SetQueryParams(queryParam).SetBody(body).Post(url)
 
 	return resp, err
@@ -130,12 +106,7 @@ func InvokePostRequestWithQueryParam(queryParam map[string]string, url string, h
 
 // Invoke http-delete request using go-resty
 func InvokeDELETERequest(url string, headers map[string]string) (*resty.Response, error) {
-	if Insecure {
-		resty.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true}) // To bypass errors in SSL certificates
-	} else {
-		resty.SetTLSClientConfig(GetTlsConfigWithCertificate())
-	}
-	resty.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
+	setRestyTransport()
 	resp, err := resty.R().SetHeaders(headers).Delete(url)
 
 	return resp, err